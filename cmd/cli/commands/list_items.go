@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config"
+	"github.com/lucax88x/wentsketchy/cmd/cli/console"
+	"github.com/lucax88x/wentsketchy/internal/wentsketchy"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewListItemsCmd(
+	ctx context.Context,
+	logger *slog.Logger,
+	_ *viper.Viper,
+	console *console.Console,
+	cfg *config.Cfg,
+) *cobra.Command {
+	listItemsCmd := &cobra.Command{
+		Use:   "list-items",
+		Short: "print the items of config.yaml by position, without starting wentsketchy",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cfg == nil {
+				return errors.New("list-items: config.yaml could not be read")
+			}
+
+			logger.DebugContext(ctx, "list-items: printing config")
+			printItems(console.Stdout, cfg, wentsketchy.ItemNames(ctx, logger))
+
+			return nil
+		},
+	}
+
+	listItemsCmd.SetOut(console.Stdout)
+	listItemsCmd.SetErr(console.Stderr)
+
+	return listItemsCmd
+}
+
+func printItems(out io.Writer, cfg *config.Cfg, registered []string) {
+	positions := []struct {
+		name  string
+		items []string
+	}{
+		{"left", cfg.Left},
+		{"left_notch", cfg.LeftNotch},
+		{"center", cfg.Center},
+		{"right", cfg.Right},
+		{"right_notch", cfg.RightNotch},
+	}
+
+	var unknownItems []string
+	for _, position := range positions {
+		fmt.Fprintf(out, "%s: %s\n", position.name, strings.Join(position.items, ", "))
+
+		for _, item := range position.items {
+			if !slices.Contains(registered, item) {
+				unknownItems = append(unknownItems, item)
+			}
+		}
+	}
+
+	if len(unknownItems) > 0 {
+		fmt.Fprintf(out, "\nnot registered, will fail: %s\n", strings.Join(unknownItems, ", "))
+	}
+
+	if len(cfg.Unknown) > 0 {
+		fmt.Fprintf(out, "unknown keys, ignored: %s\n", strings.Join(cfg.Unknown, ", "))
+	}
+
+	fmt.Fprintf(out, "\navailable items: %s\n", strings.Join(registered, ", "))
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/console"
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+	aerospaceEvents "github.com/lucax88x/wentsketchy/internal/aerospace/events"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewMoveWindowCmd is what the click script of the window icons runs, it does not need a running wentsketchy.
+// After the move it triggers aerospace_window_moved, so a running wentsketchy only moves the window icon.
+func NewMoveWindowCmd(
+	ctx context.Context,
+	logger *slog.Logger,
+	viper *viper.Viper,
+	console *console.Console,
+) *cobra.Command {
+	moveWindowCmd := &cobra.Command{
+		Use:   "move-window <window-id>",
+		Short: "move a window to another aerospace workspace",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, cmdArgs []string) error {
+			windowID, err := strconv.Atoi(cmdArgs[0])
+
+			if err != nil {
+				return fmt.Errorf("move-window: invalid window id %s. %w", cmdArgs[0], err)
+			}
+
+			workspaceID := viper.GetString("move-window.target-workspace")
+
+			if workspaceID == "" {
+				return errors.New("move-window: missing --target-workspace")
+			}
+
+			command := command.NewCommand(logger)
+			api := aerospace.NewAPI(logger, command)
+			data := aerospace.New(logger, api, aerospace.NewTreeBuilder(logger, api))
+
+			logger.DebugContext(ctx, "move-window: moving",
+				slog.Int("window", windowID),
+				slog.String("workspace", workspaceID))
+
+			if err = data.MoveWindowToWorkspace(ctx, windowID, workspaceID); err != nil {
+				return err
+			}
+
+			return triggerWindowMoved(ctx, sketchybar.NewAPI(logger, command), windowID, workspaceID)
+		},
+	}
+
+	moveWindowCmd.SetOut(console.Stdout)
+	moveWindowCmd.SetErr(console.Stderr)
+
+	moveWindowCmd.Flags().String("target-workspace", "", "The workspace receiving the window.")
+
+	_ = viper.BindPFlag("move-window.target-workspace", moveWindowCmd.Flags().Lookup("target-workspace"))
+
+	return moveWindowCmd
+}
+
+func triggerWindowMoved(
+	ctx context.Context,
+	sketchybarAPI sketchybar.API,
+	windowID aerospace.WindowID,
+	workspaceID aerospace.WorkspaceID,
+) error {
+	info, err := json.Marshal(aerospaceEvents.WindowEventInfo{WindowID: windowID, Workspace: workspaceID})
+
+	if err != nil {
+		return fmt.Errorf("move-window: could not serialize the moved window. %w", err)
+	}
+
+	err = sketchybarAPI.Run(ctx, []string{"--trigger", aerospaceEvents.WindowMoved, "INFO=" + string(info)})
+
+	if err != nil {
+		return fmt.Errorf("move-window: could not trigger %s. %w", aerospaceEvents.WindowMoved, err)
+	}
+
+	return nil
+}
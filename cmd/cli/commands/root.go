@@ -28,6 +28,9 @@ func NewRootCmd(
 	configureRootCmdFlags(viper, rootCmd)
 
 	rootCmd.AddCommand(NewStartCmd(ctx, logger, viper, console, cfg))
+	rootCmd.AddCommand(NewTriggerCmd(ctx, logger, viper, console))
+	rootCmd.AddCommand(NewListItemsCmd(ctx, logger, viper, console, cfg))
+	rootCmd.AddCommand(NewMoveWindowCmd(ctx, logger, viper, console))
 
 	return rootCmd
 }
@@ -2,9 +2,9 @@ package commands
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
-	"os"
-	"os/signal"
 	"sync"
 	"time"
 
@@ -29,23 +29,40 @@ func NewStartCmd(
 		Use:   "start",
 		Short: "start wentsketchy",
 		RunE: func(_ *cobra.Command, args []string) error {
-			return runner.RunCmdE(ctx, logger, viper, console, args, cfg, runStartCmd())
+			cfg.DryRun = viper.GetBool("dry-run")
+			startupTimeout := time.Duration(viper.GetInt("startup-timeout")) * time.Second
+			return runner.RunCmdE(ctx, logger, viper, console, args, cfg, runStartCmd(startupTimeout))
 		},
 	}
 
 	startCmd.SetOut(console.Stdout)
 	startCmd.SetErr(console.Stderr)
 
+	startCmd.Flags().Bool("dry-run", false, "Print the sketchybar commands instead of running them. (default: false)")
+	_ = viper.BindPFlag("dry-run", startCmd.Flags().Lookup("dry-run"))
+
+	startCmd.Flags().Int("startup-timeout", defaultStartupTimeout,
+		"Seconds the config init can take when sketchybar does not answer, 0 to wait forever.")
+	_ = viper.BindPFlag("startup-timeout", startCmd.Flags().Lookup("startup-timeout"))
+
 	return startCmd
 }
 
-func runStartCmd() runner.RunE {
+const defaultStartupTimeout = 30
+
+func runStartCmd(startupTimeout time.Duration) runner.RunE {
 	return func(
 		ctx context.Context,
 		_ *console.Console,
 		_ []string,
 		di *wentsketchy.Wentsketchy,
 	) error {
+		if err := di.Validate(); err != nil {
+			return fmt.Errorf("start: invalid dependencies. %w", err)
+		}
+
+		settings.WarnMissingFonts(ctx, di.Logger)
+
 		// Create PID file with error handling that doesn't exit
 		if err := runner.CreatePidFile(settings.PidFilePath); err != nil {
 			di.Logger.ErrorContext(ctx, "start: could not create pid file, continuing anyway", slog.Any("error", err))
@@ -66,7 +83,7 @@ func runStartCmd() runner.RunE {
 
 		// Initialize config with error handling
 		di.Logger.InfoContext(ctx, "start: config init")
-		if err := di.Config.Init(ctx); err != nil {
+		if err := initConfigWithTimeout(ctx, di, startupTimeout); err != nil {
 			di.Logger.ErrorContext(ctx, "start: config init failed, continuing anyway", slog.Any("error", err))
 		}
 
@@ -87,6 +104,25 @@ func runStartCmd() runner.RunE {
 	}
 }
 
+// initConfigWithTimeout stops waiting on sketchybar after startupTimeout, the rest of start runs on ctx.
+func initConfigWithTimeout(ctx context.Context, di *wentsketchy.Wentsketchy, startupTimeout time.Duration) error {
+	if startupTimeout <= 0 {
+		return di.Config.Init(ctx)
+	}
+
+	initCtx, cancel := context.WithTimeout(ctx, startupTimeout)
+	defer cancel()
+
+	err := di.Config.Init(initCtx)
+
+	if errors.Is(initCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("start: config init did not complete in %s, is sketchybar running? %w",
+			startupTimeout, errors.Join(err, initCtx.Err()))
+	}
+
+	return err
+}
+
 func startFifoWithRetry(ctx context.Context, di *wentsketchy.Wentsketchy) {
 	maxRetries := 5
 	retryDelay := time.Second * 2
@@ -100,11 +136,11 @@ func startFifoWithRetry(ctx context.Context, di *wentsketchy.Wentsketchy) {
 		)
 
 		if err := di.Fifo.Start(settings.FifoPath); err != nil {
-			di.Logger.ErrorContext(ctx, "start: could not start fifo", 
+			di.Logger.ErrorContext(ctx, "start: could not start fifo",
 				slog.Any("error", err),
 				slog.Int("attempt", attempt),
 				slog.Int("maxRetries", maxRetries))
-			
+
 			if attempt < maxRetries {
 				di.Logger.InfoContext(ctx, "start: retrying fifo start", slog.Duration("delay", retryDelay))
 				time.Sleep(retryDelay)
@@ -133,9 +169,6 @@ func runServerWithRecovery(
 
 	di.Logger.InfoContext(ctx, "server: starting")
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
-
 	cancelCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -154,12 +187,12 @@ func runServerWithRecovery(
 							di.Logger.ErrorContext(cancelCtx, "server: recovered from server panic", slog.Any("panic", r))
 						}
 					}()
-					
+
 					di.Logger.InfoContext(cancelCtx, "server: starting server instance")
 					di.Server.Start(cancelCtx)
 					di.Logger.InfoContext(cancelCtx, "server: server instance stopped")
 				}()
-				
+
 				// If server exits, wait a bit before restarting
 				select {
 				case <-cancelCtx.Done():
@@ -173,7 +206,7 @@ func runServerWithRecovery(
 
 	// Wait for shutdown signal or server completion
 	select {
-	case <-quit:
+	case <-ctx.Done():
 		di.Logger.InfoContext(ctx, "server: received shutdown signal")
 	case <-serverDone:
 		di.Logger.InfoContext(ctx, "server: server goroutine completed")
@@ -197,8 +230,9 @@ func runJobsWithRecovery(
 
 	di.Logger.InfoContext(ctx, "jobs: starting")
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+	if err := di.Jobs.Start(ctx); err != nil {
+		di.Logger.ErrorContext(ctx, "jobs: could not start jobs, continuing anyway", slog.Any("error", err))
+	}
 
 	tickerCtx, tickerCancel := context.WithCancel(ctx)
 	defer tickerCancel()
@@ -227,10 +261,10 @@ func runJobsWithRecovery(
 							di.Logger.ErrorContext(tickerCtx, "jobs: recovered from periodic job panic", slog.Any("panic", r))
 						}
 					}()
-					
+
 					// Periodic maintenance tasks
 					di.Logger.DebugContext(tickerCtx, "jobs: running periodic maintenance")
-					
+
 					// Refresh aerospace tree periodically
 					di.Aerospace.SingleFlightRefreshTree()
 				}()
@@ -240,12 +274,17 @@ func runJobsWithRecovery(
 
 	// Wait for shutdown signal or jobs completion
 	select {
-	case <-quit:
+	case <-ctx.Done():
 		di.Logger.InfoContext(ctx, "jobs: received shutdown signal")
 	case <-jobsDone:
 		di.Logger.InfoContext(ctx, "jobs: jobs goroutine completed")
 	}
 
 	tickerCancel()
+
+	if err := di.Jobs.Stop(); err != nil {
+		di.Logger.ErrorContext(ctx, "jobs: could not stop jobs", slog.Any("error", err))
+	}
+
 	di.Logger.InfoContext(ctx, "jobs: shutdown")
-}
\ No newline at end of file
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/console"
+	aerospaceEvents "github.com/lucax88x/wentsketchy/internal/aerospace/events"
+	"github.com/lucax88x/wentsketchy/internal/fifo"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const triggerInit = "init"
+
+// triggerEvents are the events a running wentsketchy handles, the sketchybar ones need an item --name.
+//
+//nolint:gochecknoglobals // ok
+var triggerEvents = []string{
+	triggerInit,
+	aerospaceEvents.AerospaceRefresh,
+	aerospaceEvents.WorkspaceChange,
+	aerospaceEvents.WindowCreated,
+	aerospaceEvents.WindowDestroyed,
+	aerospaceEvents.WindowMoved,
+	events.Forced,
+	events.Routine,
+	events.FrontAppSwitched,
+	events.VolumeChange,
+	events.PowerSourceChanged,
+	events.WifiChange,
+	events.MediaChange,
+	events.SystemWoke,
+	events.MouseClicked,
+	events.MouseScrolled,
+}
+
+func NewTriggerCmd(
+	ctx context.Context,
+	logger *slog.Logger,
+	viper *viper.Viper,
+	console *console.Console,
+) *cobra.Command {
+	triggerCmd := &cobra.Command{
+		Use:   "trigger <event>",
+		Short: "send an event to a running wentsketchy",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, cmdArgs []string) error {
+			if viper.GetBool("trigger.list") {
+				fmt.Fprintln(console.Stdout, strings.Join(triggerEvents, "\n"))
+				return nil
+			}
+
+			if len(cmdArgs) == 0 {
+				return errors.New("trigger: missing event, use --list to see the known ones")
+			}
+
+			msg, err := buildTriggerMessage(cmdArgs[0], viper.GetString("trigger.name"), viper.GetString("trigger.info"))
+
+			if err != nil {
+				return err
+			}
+
+			logger.DebugContext(ctx, "trigger: writing message", slog.String("message", msg))
+
+			return fifo.NewFifoWriter(logger).Write(settings.FifoPath, msg)
+		},
+	}
+
+	triggerCmd.SetOut(console.Stdout)
+	triggerCmd.SetErr(console.Stderr)
+
+	triggerCmd.Flags().String("info", "", "The $INFO of the event, usually json.")
+	triggerCmd.Flags().String("name", "", "The item receiving a sketchybar event.")
+	triggerCmd.Flags().Bool("list", false, "Print the known events. (default: false)")
+
+	_ = viper.BindPFlag("trigger.info", triggerCmd.Flags().Lookup("info"))
+	_ = viper.BindPFlag("trigger.name", triggerCmd.Flags().Lookup("name"))
+	_ = viper.BindPFlag("trigger.list", triggerCmd.Flags().Lookup("list"))
+
+	return triggerCmd
+}
+
+func buildTriggerMessage(event string, name string, info string) (string, error) {
+	switch event {
+	case triggerInit, aerospaceEvents.AerospaceRefresh:
+		return event, nil
+	case aerospaceEvents.WorkspaceChange:
+		if info == "" {
+			return "", fmt.Errorf(`trigger: %s needs --info '{"focused": "1", "prev": "2"}'`, event)
+		}
+		return fmt.Sprintf("%s %s", event, info), nil
+	}
+
+	if !slices.Contains(triggerEvents, event) {
+		return "", fmt.Errorf("trigger: unknown event %s, use --list to see the known ones", event)
+	}
+
+	msg, err := args.BuildMessage(&args.In{Name: name, Event: event, Info: info})
+
+	if err != nil {
+		return "", fmt.Errorf("trigger: %s needs the --name of an item. %w", event, err)
+	}
+
+	return msg, nil
+}
@@ -2,6 +2,7 @@ package args
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -70,6 +71,45 @@ func FromEvent(msg string) (*In, error) {
 	return args, nil
 }
 
+// Validate checks the fields needed to dispatch an update to the items.
+func Validate(in *In) error {
+	if in == nil {
+		return errors.New("args: no args")
+	}
+
+	if in.Name == "" {
+		return errors.New("args: name is empty")
+	}
+
+	if in.Event == "" {
+		return errors.New("args: event is empty")
+	}
+
+	return nil
+}
+
+// BuildMessage is the update message that FromEvent parses, like the one BuildEvent echoes.
+func BuildMessage(in *In) (string, error) {
+	if err := Validate(in); err != nil {
+		return "", err
+	}
+
+	data := &Out{
+		Name:     in.Name,
+		Event:    in.Event,
+		Button:   in.Button,
+		Modifier: in.Modifier,
+	}
+
+	bytes, err := json.Marshal(data)
+
+	if err != nil {
+		return "", fmt.Errorf("args: could not serialize data. %w", err)
+	}
+
+	return fmt.Sprintf("update args: %s info: %s", bytes, in.Info), nil
+}
+
 func BuildEvent() (string, error) {
 	data := &Out{
 		Name:     "$NAME",
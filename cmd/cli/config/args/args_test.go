@@ -2,9 +2,13 @@
 package args_test
 
 import (
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/fifo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,7 +19,18 @@ func TestUnitArgs(t *testing.T) {
 
 		// THEN
 		require.NoError(t, err)
-		require.Equal(t, `echo "update args: {\"name\":\"$NAME\",\"event\":\"$SENDER\",\"button\":\"$BUTTON\",\"modifier\":\"$MODIFIER\"} info: $INFO ¬" >> /tmp/wentsketchy`, event)
+		require.Equal(t, `echo "update args: {\"name\":\"$NAME\",\"event\":\"$SENDER\",\"button\":\"$BUTTON\",\"modifier\":\"$MODIFIER\"} info: $INFO ¬" >> `+settings.FifoPath, event)
+	})
+
+	t.Run("should build event writing to the fifo with the separator", func(t *testing.T) {
+		// WHEN
+		event, err := args.BuildEvent()
+
+		// THEN
+		require.NoError(t, err)
+		require.Contains(t, event, settings.FifoPath)
+		require.True(t, strings.ContainsRune(event, fifo.Separator))
+		require.Regexp(t, regexp.MustCompile(`^echo "update args: \{.*\} info: \$INFO ¬" >> `+regexp.QuoteMeta(settings.FifoPath)+`$`), event)
 	})
 
 	t.Run("should extract args from event", func(t *testing.T) {
@@ -51,4 +66,87 @@ func TestUnitArgs(t *testing.T) {
 	"display-1": 1
 }`, argsIn.Info)
 	})
+
+	t.Run("should extract args from event where info has json special characters", func(t *testing.T) {
+		// GIVEN
+		event := `update args: {"name":"front_app","event":"front_app_switched","button":"","modifier":""} info: {"app": "Quote \"s\" & {braces} [list] \\ info: nested"}`
+
+		// WHEN
+		argsIn, err := args.FromEvent(event)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "front_app", argsIn.Name)
+		require.Equal(t, "front_app_switched", argsIn.Event)
+		require.Equal(t, `{"app": "Quote \"s\" & {braces} [list] \\ info: nested"}`, argsIn.Info)
+	})
+
+	t.Run("should fail when args prefix is missing", func(t *testing.T) {
+		// GIVEN
+		event := `update {"name":"some-name","event":"some-sender"} info: `
+
+		// WHEN
+		argsIn, err := args.FromEvent(event)
+
+		// THEN
+		require.Error(t, err)
+		require.Nil(t, argsIn)
+		require.Contains(t, err.Error(), "could not find args prefix")
+	})
+
+	t.Run("should fail when event is empty", func(t *testing.T) {
+		// WHEN
+		argsIn, err := args.FromEvent("")
+
+		// THEN
+		require.Error(t, err)
+		require.Nil(t, argsIn)
+	})
+
+	t.Run("should fail when args json is null", func(t *testing.T) {
+		// GIVEN
+		event := `update args: null info: `
+
+		// WHEN
+		argsIn, err := args.FromEvent(event)
+
+		// THEN
+		require.Error(t, err)
+		require.Nil(t, argsIn)
+		require.Contains(t, err.Error(), "deserialized data is nil")
+	})
+
+	t.Run("should validate args with name and event", func(t *testing.T) {
+		// WHEN
+		err := args.Validate(&args.In{Name: "front_app", Event: "front_app_switched"})
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail validation without name or event", func(t *testing.T) {
+		// WHEN
+		errWithoutName := args.Validate(&args.In{Event: "front_app_switched"})
+		errWithoutEvent := args.Validate(&args.In{Name: "front_app"})
+		errWithoutArgs := args.Validate(nil)
+
+		// THEN
+		require.ErrorContains(t, errWithoutName, "name is empty")
+		require.ErrorContains(t, errWithoutEvent, "event is empty")
+		require.Error(t, errWithoutArgs)
+	})
+
+	t.Run("should build a message which can be parsed back", func(t *testing.T) {
+		// GIVEN
+		in := &args.In{Name: "battery", Event: "forced", Info: `{"key":"value"}`}
+
+		// WHEN
+		msg, err := args.BuildMessage(in)
+		require.NoError(t, err)
+		result, err := args.FromEvent(msg)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, in, result)
+	})
 }
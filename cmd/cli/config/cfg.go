@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
 	"github.com/lucax88x/wentsketchy/internal/homedir"
 	"gopkg.in/yaml.v2"
@@ -17,28 +21,80 @@ type Cfg struct {
 	LeftNotch  []string `yaml:"left_notch"`
 	RightNotch []string `yaml:"right_notch"`
 	LogLevel   string   `yaml:"log_level"`
+	// Animations are the per item animation overrides, by item name.
+	Animations map[string]settings.AnimationConfig `yaml:"animations"`
+	// Parallel updates the items concurrently, the items of the same items.UpdateGrouper group one at a time.
+	Parallel bool `yaml:"parallel"`
+	// ItemHeights are the per item background height overrides, by item name.
+	ItemHeights map[string]int `yaml:"item_heights"`
+	// Badges are the apps whose dock badge is shown next to an item, by sketchybar item name.
+	Badges map[string]string `yaml:"badges"`
+	// DryRun prints the sketchybar commands instead of running them, set by the --dry-run flag.
+	DryRun bool `yaml:"-"`
+	// Unknown holds the top level keys which are not recognized, usually a misspelled position.
+	Unknown []string `yaml:"-"`
 }
 
 type ConfigData struct {
-	Left       []string `yaml:"left"`
-	Center     []string `yaml:"center"`
-	Right      []string `yaml:"right"`
-	LeftNotch  []string `yaml:"left_notch"`
-	RightNotch []string `yaml:"right_notch"`
-	LogLevel   string   `yaml:"log_level"`
-	Icons      struct {
+	Left                           []string `yaml:"left"`
+	Center                         []string `yaml:"center"`
+	Right                          []string `yaml:"right"`
+	LeftNotch                      []string `yaml:"left_notch"`
+	RightNotch                     []string `yaml:"right_notch"`
+	LogLevel                       string   `yaml:"log_level"`
+	Parallel                       bool     `yaml:"parallel"`
+	BarBlurRadius                  *float64 `yaml:"bar_blur_radius"`
+	BarOpacity                     *float64 `yaml:"bar_opacity"`
+	BarCornerRadius                *int     `yaml:"bar_corner_radius"`
+	LabelFontSize                  string   `yaml:"label_font_size"`
+	IconFontSize                   string   `yaml:"icon_font_size"`
+	AerospaceRefreshTimeoutSeconds *float64 `yaml:"aerospace_refresh_timeout_seconds"`
+	ItemInitTimeoutSeconds         *float64 `yaml:"item_init_timeout_seconds"`
+	SparklineSize                  *int     `yaml:"sparkline_size"`
+	Icons                          struct {
 		Workspace map[string]string `yaml:"workspace"`
 	} `yaml:"icons"`
+	Calendar struct {
+		Use24Hour bool `yaml:"use_24h"`
+	} `yaml:"calendar"`
+	FrontApp struct {
+		IconLookupApps []string `yaml:"icon_lookup_apps"`
+	} `yaml:"front_app"`
+	Battery struct {
+		LabelColors struct {
+			Level1 string `yaml:"level1"`
+			Level2 string `yaml:"level2"`
+			Level3 string `yaml:"level3"`
+			Level4 string `yaml:"level4"`
+			Level5 string `yaml:"level5"`
+		} `yaml:"label_colors"`
+		Style string `yaml:"style"`
+	} `yaml:"battery"`
+	Animations   map[string]settings.AnimationConfig  `yaml:"animations"`
+	Visibility   map[string]settings.VisibilityConfig `yaml:"visibility"`
+	Badges       map[string]string                    `yaml:"badges"`
+	ItemHeights  map[string]int                       `yaml:"item_heights"`
+	ShellScripts []settings.ShellScriptConfig         `yaml:"shell_scripts"`
+	Aerospace    struct {
+		ShowMonitorLabels      bool     `yaml:"show_monitor_labels"`
+		FallbackToFirstAppIcon bool     `yaml:"fallback_to_first_app_icon"`
+		WorkspaceHiddenApps    []string `yaml:"workspace_hidden_apps"`
+		WorkspaceSort          []string `yaml:"workspace_sort"`
+		BorderWidth            *int     `yaml:"border_width"`
+		FocusedBorderWidth     *int     `yaml:"focused_border_width"`
+		WorkspaceWidth         *int     `yaml:"workspace_width"`
+	} `yaml:"aerospace"`
+	Unknown map[string]interface{} `yaml:",inline"`
 }
 
 func ReadYaml() (*Cfg, error) {
 	var configData ConfigData
 
-	dir, err := homedir.Get()
+	dir, err := homedir.ConfigDir()
 
 	if err != nil {
 		//nolint:errorlint // no wrap
-		return nil, fmt.Errorf("config: error getting home dir. %v", err)
+		return nil, fmt.Errorf("config: error getting config dir. %v", err)
 	}
 
 	yamlData, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
@@ -59,12 +115,85 @@ func ReadYaml() (*Cfg, error) {
 		icons.Workspace = configData.Icons.Workspace
 	}
 
+	settings.ApplyCfg(&settings.Cfg{
+		LabelFontSize: configData.LabelFontSize,
+		IconFontSize:  configData.IconFontSize,
+	})
+
+	if configData.BarBlurRadius != nil {
+		settings.Sketchybar.BarBlurRadius = configData.BarBlurRadius
+	}
+	if configData.BarOpacity != nil {
+		settings.Sketchybar.BarOpacity = configData.BarOpacity
+	}
+	if configData.BarCornerRadius != nil {
+		settings.Sketchybar.BarRadius = configData.BarCornerRadius
+	}
+
+	if configData.AerospaceRefreshTimeoutSeconds != nil && *configData.AerospaceRefreshTimeoutSeconds > 0 {
+		settings.Sketchybar.Aerospace.RefreshTimeout = time.Duration(
+			*configData.AerospaceRefreshTimeoutSeconds * float64(time.Second),
+		)
+	}
+
+	if configData.ItemInitTimeoutSeconds != nil && *configData.ItemInitTimeoutSeconds > 0 {
+		settings.Sketchybar.ItemInitTimeout = time.Duration(
+			*configData.ItemInitTimeoutSeconds * float64(time.Second),
+		)
+	}
+
+	if configData.SparklineSize != nil && *configData.SparklineSize > 0 {
+		settings.Sketchybar.SparklineSize = *configData.SparklineSize
+	}
+
+	for itemName, animation := range configData.Animations {
+		// sketchybar refuses the whole command on an unknown curve, keep the item default instead
+		if !animation.Easing.IsValid() {
+			animation.Easing = ""
+			configData.Animations[itemName] = animation
+		}
+	}
+
+	if configData.Aerospace.BorderWidth != nil {
+		settings.Sketchybar.Aerospace.WorkspaceBorderWidth = configData.Aerospace.BorderWidth
+	}
+	if configData.Aerospace.FocusedBorderWidth != nil {
+		settings.Sketchybar.Aerospace.WorkspaceFocusedBorderWidth = configData.Aerospace.FocusedBorderWidth
+	}
+	if configData.Aerospace.WorkspaceWidth != nil {
+		settings.Sketchybar.Aerospace.WorkspaceWidth = configData.Aerospace.WorkspaceWidth
+	}
+
+	settings.Sketchybar.Calendar.Use24Hour = configData.Calendar.Use24Hour
+	settings.Sketchybar.FrontApp.IconLookupApps = configData.FrontApp.IconLookupApps
+	settings.Sketchybar.Battery = settings.BatterySettings{
+		Level1Color: configData.Battery.LabelColors.Level1,
+		Level2Color: configData.Battery.LabelColors.Level2,
+		Level3Color: configData.Battery.LabelColors.Level3,
+		Level4Color: configData.Battery.LabelColors.Level4,
+		Level5Color: configData.Battery.LabelColors.Level5,
+		Style:       configData.Battery.Style,
+	}
+	settings.Sketchybar.Animations = configData.Animations
+	settings.Sketchybar.Visibility = configData.Visibility
+	settings.Sketchybar.Badges = configData.Badges
+	settings.Sketchybar.ShellScripts = configData.ShellScripts
+	settings.Sketchybar.Aerospace.ShowMonitorLabels = configData.Aerospace.ShowMonitorLabels
+	settings.Sketchybar.Aerospace.FallbackToFirstAppIcon = configData.Aerospace.FallbackToFirstAppIcon
+	settings.Sketchybar.Aerospace.WorkspaceHiddenApps = configData.Aerospace.WorkspaceHiddenApps
+	settings.Sketchybar.Aerospace.WorkspaceSort = configData.Aerospace.WorkspaceSort
+
 	return &Cfg{
-		Left:       configData.Left,
-		Center:     configData.Center,
-		Right:      configData.Right,
-		LeftNotch:  configData.LeftNotch,
-		RightNotch: configData.RightNotch,
-		LogLevel:   configData.LogLevel,
+		Left:        configData.Left,
+		Center:      configData.Center,
+		Right:       configData.Right,
+		LeftNotch:   configData.LeftNotch,
+		RightNotch:  configData.RightNotch,
+		LogLevel:    configData.LogLevel,
+		Parallel:    configData.Parallel,
+		Animations:  configData.Animations,
+		ItemHeights: configData.ItemHeights,
+		Badges:      configData.Badges,
+		Unknown:     slices.Sorted(maps.Keys(configData.Unknown)),
 	}, nil
 }
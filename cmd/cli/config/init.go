@@ -2,96 +2,137 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 )
 
 type Config struct {
-	Cfg          *Cfg
-	logger       *slog.Logger
-	sketchybar   sketchybar.API
+	Cfg        *Cfg
+	logger     *slog.Logger
+	sketchybar sketchybar.API
+	// IndexedItems are the items of config.yaml by name, built from the registry on init.
 	IndexedItems items.IndexedWentsketchyItems
-	Items        items.WentsketchyItems
+	// timedOut are the items whose last init timed out, they were never added so they are not updated.
+	timedOut map[string]bool
+	deps     items.ItemDeps
+	// initHooks run after every init added the items to sketchybar.
+	initHooks []func(ctx context.Context)
 }
 
+// NewConfig keeps the indexedItems already built, the others are built from the registry with deps.
 func NewConfig(
 	cfg *Cfg,
 	logger *slog.Logger,
 	sketchybar sketchybar.API,
 	indexedItems items.IndexedWentsketchyItems,
-	items items.WentsketchyItems,
+	deps items.ItemDeps,
 ) *Config {
+	if indexedItems == nil {
+		indexedItems = make(items.IndexedWentsketchyItems)
+	}
+
 	return &Config{
 		cfg,
 		logger,
 		sketchybar,
 		indexedItems,
-		items,
+		make(map[string]bool),
+		deps,
+		nil,
 	}
 }
 
+// OnInit registers a hook run after every init, for the jobs which only send changes:
+// init adds the items again with their default properties, so the jobs must send them again.
+func (cfg *Config) OnInit(hook func(ctx context.Context)) {
+	cfg.initHooks = append(cfg.initHooks, hook)
+}
+
 func (cfg *Config) Init(ctx context.Context) error {
-	var batches = make(items.Batches, 0)
+	cfg.validatePositions(ctx)
+	clear(cfg.timedOut)
 
-	batches, err := items.Defaults(batches)
+	allBatches, err := items.Defaults(make(items.Batches, 0))
 
 	if err != nil {
 		return fmt.Errorf("config: defaults %w", err)
 	}
 
-	batches, err = items.Bar(cfg.logger, batches)
+	barBatches, err := items.Bar(ctx, cfg.logger, cfg.deps.Aerospace, make(items.Batches, 0))
 
 	if err != nil {
 		return fmt.Errorf("config: bar %w", err)
 	}
 
-	batches, err = cfg.initList(ctx, batches, sketchybar.PositionLeft, cfg.Cfg.Left)
+	allBatches = allBatches.Merge(barBatches)
+
+	leftBatches, err := cfg.initList(ctx, sketchybar.PositionLeft, cfg.Cfg.Left)
 
 	if err != nil {
 		return fmt.Errorf("config: left %w", err)
 	}
 
-	batches, err = cfg.initList(ctx, batches, sketchybar.PositionLeftNotch, cfg.Cfg.LeftNotch)
+	allBatches = allBatches.Merge(leftBatches)
+
+	leftNotchBatches, err := cfg.initList(ctx, sketchybar.PositionLeftNotch, cfg.Cfg.LeftNotch)
 
 	if err != nil {
 		return fmt.Errorf("config: left notch %w", err)
 	}
 
-	batches, err = cfg.initList(ctx, batches, sketchybar.PositionCenter, cfg.Cfg.Center)
+	allBatches = allBatches.Merge(leftNotchBatches)
+
+	centerBatches, err := cfg.initList(ctx, sketchybar.PositionCenter, cfg.Cfg.Center)
 
 	if err != nil {
 		return fmt.Errorf("config: center %w", err)
 	}
 
-	batches, err = cfg.initList(ctx, batches, sketchybar.PositionRight, reverse(cfg.Cfg.Right))
+	allBatches = allBatches.Merge(centerBatches)
+
+	rightBatches, err := cfg.initList(ctx, sketchybar.PositionRight, reverse(cfg.Cfg.Right))
 
 	if err != nil {
 		return fmt.Errorf("config: right %w", err)
 	}
 
-	batches, err = cfg.initList(ctx, batches, sketchybar.PositionRightNotch, reverse(cfg.Cfg.RightNotch))
+	allBatches = allBatches.Merge(rightBatches)
+
+	rightNotchBatches, err := cfg.initList(ctx, sketchybar.PositionRightNotch, reverse(cfg.Cfg.RightNotch))
 
 	if err != nil {
 		return fmt.Errorf("config: right notch %w", err)
 	}
 
-	err = cfg.sketchybar.Run(ctx, items.Flatten(batches...))
+	allBatches = allBatches.Merge(rightNotchBatches)
+
+	// dozens of items, one sketchybar process is way faster than one per item
+	err = cfg.sketchybar.RunBatch(ctx, allBatches)
 
 	if err != nil {
 		return fmt.Errorf("config: apply to sketchybar %w", err)
 	}
 
-	batches = make(items.Batches, 0)
-	batches, err = items.ShowBar(cfg.logger, batches)
+	// before showing the bar, so the hidden items do not appear
+	for _, hook := range cfg.initHooks {
+		hook(ctx)
+	}
+
+	showBatches, err := items.ShowBar(ctx, cfg.logger, cfg.deps.Aerospace, make(items.Batches, 0))
 
 	if err != nil {
 		return fmt.Errorf("config: appear bar %w", err)
 	}
 
-	err = cfg.sketchybar.Run(ctx, items.Flatten(batches...))
+	err = cfg.sketchybar.Run(ctx, items.Flatten(showBatches...))
 
 	if err != nil {
 		return fmt.Errorf("config: apply to sketchybar %w", err)
@@ -108,28 +149,191 @@ func (cfg *Config) Init(ctx context.Context) error {
 
 func (cfg *Config) initList(
 	ctx context.Context,
-	batches items.Batches,
 	position sketchybar.Position,
 	list []string,
 ) (items.Batches, error) {
-	var err error
+	listBatches := make(items.Batches, 0)
+
 	for _, itemName := range list {
-		item, found := cfg.IndexedItems[itemName]
+		item, err := cfg.item(itemName)
+
+		if err != nil {
+			return listBatches, fmt.Errorf("init: did not find %s. %w", itemName, err)
+		}
+
+		item = items.WithLogging(item, cfg.logger.With(slog.String("item", itemName)))
+		itemBatches, err := cfg.initItemWithTimeout(ctx, item, position, settings.Sketchybar.ItemInitTimeout)
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			cfg.logger.WarnContext(ctx, "init: item init timed out, skipping it",
+				slog.String("item", itemName),
+				slog.Duration("timeout", settings.Sketchybar.ItemInitTimeout))
+
+			// its Init may still be running, the next init builds it again
+			delete(cfg.IndexedItems, itemName)
+			cfg.timedOut[itemName] = true
+			continue
+		}
 
-		if found {
-			batches, err = item.Init(ctx, position, batches)
+		if err != nil {
+			return listBatches, fmt.Errorf("init: error while init %s. %w", itemName, err)
+		}
+
+		badgeBatches, err := cfg.badges(itemBatches, position)
 
-			if err != nil {
-				return batches, fmt.Errorf("init: error while init %s. %w", itemName, err)
-			}
-		} else {
-			return batches, fmt.Errorf("init: did not find %s", itemName)
+		if err != nil {
+			return listBatches, fmt.Errorf("init: error while adding the badges of %s. %w", itemName, err)
+		}
+
+		listBatches = listBatches.
+			Merge(itemBatches).
+			Merge(cfg.itemHeight(ctx, itemName)).
+			Merge(badgeBatches)
+	}
+
+	return listBatches, nil
+}
+
+// item builds the item the first time, so an init message keeps the state of the items.
+func (cfg *Config) item(itemName string) (items.WentsketchyItem, error) {
+	if item, found := cfg.IndexedItems[itemName]; found {
+		return item, nil
+	}
+
+	item, err := items.NewFromName(itemName, cfg.deps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.IndexedItems[itemName] = item
+	return item, nil
+}
+
+// SketchybarItems are the sketchybar items of itemName, see items.SketchybarItemsOf.
+// An item which is not registered is taken as a sketchybar item name.
+func (cfg *Config) SketchybarItems(itemName string) []string {
+	item, err := cfg.item(itemName)
+
+	if err != nil {
+		return []string{itemName}
+	}
+
+	return items.SketchybarItemsOf(itemName, item)
+}
+
+// itemHeight sets the item_heights override, it goes after the item init so it wins over the item own height.
+// It is set on the sketchybar items of the item, see items.SketchybarItemsOf.
+func (cfg *Config) itemHeight(ctx context.Context, itemName string) items.Batches {
+	batches := make(items.Batches, 0)
+	height, found := cfg.Cfg.ItemHeights[itemName]
+
+	if !found {
+		return batches
+	}
+
+	targets := items.SketchybarItemsOf(itemName, cfg.IndexedItems[itemName])
+
+	if len(targets) == 0 {
+		cfg.logger.WarnContext(ctx, "config: item has no fixed sketchybar items, ignoring its height",
+			slog.String("item", itemName))
+		return batches
+	}
+
+	heightItem := sketchybar.ItemOptions{
+		Background: sketchybar.BackgroundOptions{
+			Height: &height,
+		},
+	}
+
+	for _, target := range targets {
+		batches = append(batches, append([]string{"--set", target}, heightItem.ToArgs()...))
+	}
+
+	return batches
+}
+
+// badges adds a badge item next to each item of itemBatches which has a badge.
+func (cfg *Config) badges(itemBatches items.Batches, position sketchybar.Position) (items.Batches, error) {
+	batches := make(items.Batches, 0)
+	var err error
+
+	for _, itemBatch := range itemBatches {
+		isAdd := len(itemBatch) > 2 && itemBatch[0] == "--add" && itemBatch[1] != "event"
+		if !isAdd {
+			continue
+		}
+
+		if _, found := cfg.Cfg.Badges[itemBatch[2]]; !found {
+			continue
+		}
+
+		batches, err = items.BadgeBatches(batches, itemBatch[2], position)
+
+		if err != nil {
+			return batches, err
 		}
 	}
 
 	return batches, nil
 }
 
+type initResult struct {
+	batches items.Batches
+	err     error
+}
+
+// initItemWithTimeout returns the batches of the item, context.DeadlineExceeded when the item is too slow.
+// The item gets its own batches, so it cannot touch ours while it is still running after the timeout.
+func (cfg *Config) initItemWithTimeout(
+	ctx context.Context,
+	item items.WentsketchyItem,
+	position sketchybar.Position,
+	timeout time.Duration,
+) (items.Batches, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan initResult, 1)
+	go func() {
+		itemBatches, err := item.Init(timeoutCtx, position, items.Batches{})
+		done <- initResult{itemBatches, err}
+	}()
+
+	select {
+	case <-timeoutCtx.Done():
+		return nil, timeoutCtx.Err()
+	case result := <-done:
+		return result.batches, result.err
+	}
+}
+
+// validatePositions only warns, sketchybar will still render what it can.
+func (cfg *Config) validatePositions(ctx context.Context) {
+	for _, key := range cfg.Cfg.Unknown {
+		cfg.logger.WarnContext(ctx, "config: unknown position, ignoring", slog.String("position", key))
+	}
+
+	sides := slices.Concat(cfg.Cfg.Left, cfg.Cfg.LeftNotch, cfg.Cfg.Right, cfg.Cfg.RightNotch)
+
+	for _, itemName := range slices.Sorted(maps.Keys(cfg.Cfg.ItemHeights)) {
+		if !slices.Contains(sides, itemName) && !slices.Contains(cfg.Cfg.Center, itemName) {
+			cfg.logger.WarnContext(ctx, "config: item_heights of an item which is not in any position, ignoring it",
+				slog.String("item", itemName))
+		}
+	}
+
+	for _, itemName := range cfg.Cfg.Center {
+		if slices.Contains(sides, itemName) {
+			cfg.logger.WarnContext(
+				ctx,
+				"config: item is registered at center and at left or right, its brackets cannot span both",
+				slog.String("item", itemName),
+			)
+		}
+	}
+}
+
 func reverse(items []string) []string {
 	for left, right := 0, len(items)-1; left < right; left, right = left+1, right-1 {
 		items[left], items[right] = items[right], items[left]
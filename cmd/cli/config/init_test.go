@@ -0,0 +1,153 @@
+//nolint:testpackage // want to test internals
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type initItem struct {
+	name string
+	// block makes Init wait until the context is done.
+	block bool
+}
+
+func (i initItem) Init(
+	ctx context.Context,
+	_ sketchybar.Position,
+	batches items.Batches,
+) (items.Batches, error) {
+	if i.block {
+		<-ctx.Done()
+	}
+
+	return append(batches, []string{"--add", "item", i.name}), nil
+}
+
+func (i initItem) Update(
+	_ context.Context,
+	batches items.Batches,
+	_ sketchybar.Position,
+	_ *args.In,
+) (items.Batches, error) {
+	return batches, nil
+}
+
+// ownerItem adds sketchybar items named differently than in config.yaml.
+type ownerItem struct {
+	initItem
+	sketchybarItems []string
+}
+
+func (i ownerItem) SketchybarItems() []string {
+	return i.sketchybarItems
+}
+
+func TestUnitConfigInit(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should skip an item whose init times out", func(t *testing.T) {
+		// GIVEN
+		timeout := settings.Sketchybar.ItemInitTimeout
+		settings.Sketchybar.ItemInitTimeout = 10 * time.Millisecond
+		t.Cleanup(func() { settings.Sketchybar.ItemInitTimeout = timeout })
+
+		cfg := NewConfig(&Cfg{}, logger, nil, items.IndexedWentsketchyItems{
+			"fast": initItem{name: "fast"},
+			"slow": initItem{name: "slow", block: true},
+		}, items.ItemDeps{})
+
+		// WHEN
+		batches, err := cfg.initList(ctx, sketchybar.PositionLeft, []string{"slow", "fast"})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, items.Batches{{"--add", "item", "fast"}}, batches)
+		require.NotContains(t, cfg.IndexedItems, "slow")
+	})
+
+	t.Run("should not update an item whose init timed out", func(t *testing.T) {
+		// GIVEN
+		timeout := settings.Sketchybar.ItemInitTimeout
+		settings.Sketchybar.ItemInitTimeout = 10 * time.Millisecond
+		t.Cleanup(func() { settings.Sketchybar.ItemInitTimeout = timeout })
+
+		cfg := NewConfig(&Cfg{}, logger, nil, items.IndexedWentsketchyItems{
+			"slow": initItem{name: "slow", block: true},
+		}, items.ItemDeps{})
+		_, err := cfg.initList(ctx, sketchybar.PositionLeft, []string{"slow"})
+		require.NoError(t, err)
+
+		// WHEN
+		batches, err := cfg.updateItem(ctx, items.Batches{}, sketchybar.PositionLeft, &args.In{}, "slow")
+
+		// THEN
+		require.NoError(t, err)
+		require.Empty(t, batches)
+	})
+	t.Run("should set the height override after the item init", func(t *testing.T) {
+		// GIVEN
+		cfg := NewConfig(&Cfg{ItemHeights: map[string]int{"battery": 20}}, logger, nil, items.IndexedWentsketchyItems{
+			"battery":  initItem{name: "battery"},
+			"calendar": initItem{name: "calendar"},
+		}, items.ItemDeps{})
+
+		// WHEN
+		batches, err := cfg.initList(ctx, sketchybar.PositionLeft, []string{"battery", "calendar"})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, items.Batches{
+			{"--add", "item", "battery"},
+			{"--set", "battery", "background.height=20"},
+			{"--add", "item", "calendar"},
+		}, batches)
+	})
+	t.Run("should add the badge item next to its host", func(t *testing.T) {
+		// GIVEN
+		cfg := NewConfig(&Cfg{Badges: map[string]string{"mail": "Mail"}}, logger, nil, items.IndexedWentsketchyItems{
+			"mail":     initItem{name: "mail"},
+			"calendar": initItem{name: "calendar"},
+		}, items.ItemDeps{})
+
+		// WHEN
+		batches, err := cfg.initList(ctx, sketchybar.PositionLeft, []string{"mail", "calendar"})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, []string{"--add", "item", "mail"}, batches[0])
+		require.Contains(t, batches, []string{"--add", "item", "mail.badge", sketchybar.PositionLeft})
+		require.Contains(t, batches, []string{"--move", "mail.badge", "after", "mail"})
+		require.Equal(t, []string{"--add", "item", "calendar"}, batches[len(batches)-1])
+	})
+
+	t.Run("should set the height override on the sketchybar items of the item", func(t *testing.T) {
+		// GIVEN
+		cfg := NewConfig(&Cfg{ItemHeights: map[string]int{"cpu": 20, "aerospace": 20}}, logger, nil,
+			items.IndexedWentsketchyItems{
+				"cpu":       ownerItem{initItem{name: "cpu.icon"}, []string{"cpu.icon", "cpu.bracket"}},
+				"aerospace": ownerItem{initItem: initItem{name: "aerospace.checker"}},
+			}, items.ItemDeps{})
+
+		// WHEN
+		batches, err := cfg.initList(ctx, sketchybar.PositionLeft, []string{"cpu", "aerospace"})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, items.Batches{
+			{"--add", "item", "cpu.icon"},
+			{"--set", "cpu.icon", "background.height=20"},
+			{"--set", "cpu.bracket", "background.height=20"},
+			{"--add", "item", "aerospace.checker"},
+		}, batches)
+	})
+}
@@ -6,6 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -22,14 +26,23 @@ import (
 )
 
 type AerospaceItem struct {
-	logger              *slog.Logger
-	aerospace           aerospace.Aerospace
-	sketchybar          sketchybar.API
-	position            sketchybar.Position
-	renderedItems       map[string]bool
-	closingItems        map[string]time.Time // Track items being closed for delayed removal
-	workspaceWindowIDs  map[string][]string  // Track window IDs for each workspace
-	bracketStates       map[string]string    // Track bracket creation state to prevent duplicates
+	logger             *slog.Logger
+	aerospace          aerospace.Aerospace
+	sketchybar         sketchybar.API
+	position           sketchybar.Position
+	renderedItems      map[string]bool
+	closingItems       map[string]time.Time // Track items being closed for delayed removal
+	workspaceWindowIDs map[string][]string  // Track window IDs for each workspace
+	bracketStates      map[string]string    // Track bracket creation state to prevent duplicates
+	hiddenApps         map[string]bool
+	iconResolver       WorkspaceIconResolver
+	// executable is the wentsketchy binary the window click scripts call back into.
+	executable string
+	// renderedTree and renderedState are of the last render, only the workspaces which changed since are rendered.
+	renderedTree  *aerospace.Tree
+	renderedState renderState
+	// displayNumbers maps the aerospace monitors to the sketchybar displays, it is refreshed on display changes.
+	displayNumbers map[aerospace.MonitorID]int
 	// mu is a mutex to protect the maps above from concurrent access.
 	// The Update method can be called from multiple goroutines, so we need to
 	// ensure that only one goroutine can modify the maps at a time.
@@ -41,27 +54,49 @@ func NewAerospaceItem(
 	aerospace aerospace.Aerospace,
 	sketchybarAPI sketchybar.API,
 ) *AerospaceItem {
+	hiddenApps := make(map[string]bool, len(settings.Sketchybar.Aerospace.WorkspaceHiddenApps))
+	for _, app := range settings.Sketchybar.Aerospace.WorkspaceHiddenApps {
+		hiddenApps[app] = true
+	}
+
+	// without it the window click scripts only focus the workspace
+	executable, _ := os.Executable()
+
 	return &AerospaceItem{
-		logger:                logger,
-		aerospace:             aerospace,
-		sketchybar:            sketchybarAPI,
-		position:              sketchybar.PositionLeft,
-		renderedItems:         make(map[string]bool),
-		closingItems:          make(map[string]time.Time),
-		workspaceWindowIDs:    make(map[string][]string),
-		bracketStates:         make(map[string]string),
+		logger:             logger,
+		aerospace:          aerospace,
+		sketchybar:         sketchybarAPI,
+		position:           sketchybar.PositionLeft,
+		renderedItems:      make(map[string]bool),
+		closingItems:       make(map[string]time.Time),
+		workspaceWindowIDs: make(map[string][]string),
+		bracketStates:      make(map[string]string),
+		hiddenApps:         hiddenApps,
+		iconResolver:       NewWorkspaceIconResolver(settings.Sketchybar.Aerospace.FallbackToFirstAppIcon, hiddenApps),
+		executable:         executable,
 	}
 }
 
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(aerospaceItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewAerospaceItem(deps.Logger, deps.Aerospace, deps.Sketchybar)
+	})
+}
+
 const aerospaceCheckerItemName = "aerospace.checker"
 const workspaceItemPrefix = "aerospace.workspace"
 const windowItemPrefix = "aerospace.window"
 const bracketItemPrefix = "aerospace.bracket"
 const bracketSpacerItemPrefix = "aerospace.bracket.spacer"
 const spacerItemPrefix = "aerospace.spacer"
+const monitorItemPrefix = "aerospace.monitor"
 
 const AerospaceName = aerospaceCheckerItemName
 
+// aerospaceItemName is how the item is referenced in config.yaml.
+const aerospaceItemName = "aerospace"
+
 func (item *AerospaceItem) Init(
 	ctx context.Context,
 	position sketchybar.Position,
@@ -77,15 +112,18 @@ func (item *AerospaceItem) Init(
 	}()
 
 	item.position = position
-	
-	result, err := item.renderSafely(ctx, batches, position)
+	item.refreshDisplayNumbers(ctx)
+	// init renders everything
+	item.renderedTree = nil
+
+	itemBatches, err := item.renderSafely(ctx, Batches{}, position, true)
 	if err != nil {
 		item.logger.ErrorContext(ctx, "aerospace item: Init failed, using fallback", slog.Any("error", err))
 		// Return a minimal fallback instead of failing completely
-		return item.createFallbackBatches(batches, position), nil
+		return batches.Merge(item.createFallbackBatches(Batches{}, position)), nil
 	}
-	
-	return result, nil
+
+	return batches.Merge(itemBatches), nil
 }
 
 func (item *AerospaceItem) Update(
@@ -99,7 +137,7 @@ func (item *AerospaceItem) Update(
 
 	defer func() {
 		if r := recover(); r != nil {
-			item.logger.ErrorContext(ctx, "aerospace item: recovered from panic in Update", 
+			item.logger.ErrorContext(ctx, "aerospace item: recovered from panic in Update",
 				slog.Any("panic", r),
 				slog.String("event", args.Event))
 		}
@@ -111,15 +149,22 @@ func (item *AerospaceItem) Update(
 		return batches, nil
 	}
 
+	if args.Event == aerospace_events.WindowMoved {
+		if result, moved := item.moveWindowSafely(ctx, batches, position, args.Info); moved {
+			return result, nil
+		}
+	}
+
 	// Handle events with error recovery
-	if err := item.handleEventSafely(ctx, args); err != nil {
-		item.logger.ErrorContext(ctx, "aerospace item: failed to handle event", 
+	refreshTree, err := item.handleEventSafely(ctx, args)
+	if err != nil {
+		item.logger.ErrorContext(ctx, "aerospace item: failed to handle event",
 			slog.Any("error", err),
 			slog.String("event", args.Event))
 		// Continue with render even if event handling fails
 	}
 
-	result, err := item.renderSafely(ctx, batches, position)
+	result, err := item.renderSafely(ctx, batches, position, refreshTree)
 	if err != nil {
 		item.logger.ErrorContext(ctx, "aerospace item: Update failed, using previous state", slog.Any("error", err))
 		// Return current batches instead of failing
@@ -129,10 +174,14 @@ func (item *AerospaceItem) Update(
 	return result, nil
 }
 
-func (item *AerospaceItem) handleEventSafely(ctx context.Context, args *args.In) error {
+// handleEventSafely returns false when the tree was already updated by the event and must not be refreshed.
+func (item *AerospaceItem) handleEventSafely(ctx context.Context, args *args.In) (refreshTree bool, err error) {
+	refreshTree = true
+
 	defer func() {
 		if r := recover(); r != nil {
 			item.logger.ErrorContext(ctx, "aerospace item: recovered from panic in handleEventSafely", slog.Any("panic", r))
+			refreshTree = true
 		}
 	}()
 
@@ -140,24 +189,134 @@ func (item *AerospaceItem) handleEventSafely(ctx context.Context, args *args.In)
 	case aerospace_events.WorkspaceChange:
 		var data aerospace_events.WorkspaceChangeEventInfo
 		if err := json.Unmarshal([]byte(args.Info), &data); err != nil {
-			return fmt.Errorf("aerospace: could not deserialize json for workspace-change: %w", err)
+			return true, fmt.Errorf("aerospace: could not deserialize json for workspace-change: %w", err)
 		}
 		item.aerospace.SetFocusedWorkspaceID(data.Focused)
-		
+
+	case aerospace_events.WindowCreated:
+		var data aerospace_events.WindowEventInfo
+		if err := json.Unmarshal([]byte(args.Info), &data); err != nil {
+			return true, fmt.Errorf("aerospace: could not deserialize json for window-created: %w", err)
+		}
+		added := item.aerospace.AddWindow(data.Workspace, &aerospace.Window{
+			ID:  data.WindowID,
+			App: data.App,
+		})
+		return !added, nil
+
+	case aerospace_events.WindowDestroyed:
+		var data aerospace_events.WindowEventInfo
+		if err := json.Unmarshal([]byte(args.Info), &data); err != nil {
+			return true, fmt.Errorf("aerospace: could not deserialize json for window-destroyed: %w", err)
+		}
+		// an unknown window has nothing to remove, the tree is still up to date
+		item.aerospace.RemoveWindow(data.WindowID)
+		return false, nil
+
 	case events.FrontAppSwitched:
 		item.aerospace.SetFocusedApp(args.Info)
-		
+
+	case events.DisplayChange:
+		item.refreshDisplayNumbers(ctx)
+
 	case aerospace_events.AerospaceRefresh:
 		// No data to parse, just re-render
 	}
 
-	return nil
+	return true, nil
+}
+
+// moveWindowSafely re-renders only the workspace the window left and the one it entered,
+// false when the window or the workspaces are not rendered yet and a full render is needed.
+func (item *AerospaceItem) moveWindowSafely(
+	ctx context.Context,
+	batches Batches,
+	position sketchybar.Position,
+	info string,
+) (result Batches, moved bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			item.logger.ErrorContext(ctx, "aerospace item: recovered from panic in moveWindowSafely", slog.Any("panic", r))
+			result, moved = batches, false
+		}
+	}()
+
+	var data aerospace_events.WindowEventInfo
+	if err := json.Unmarshal([]byte(info), &data); err != nil {
+		item.logger.ErrorContext(ctx, "aerospace item: could not deserialize json for window-moved", slog.Any("error", err))
+		return batches, false
+	}
+
+	sketchybarWindowID := getSketchybarWindowID(data.WindowID)
+	fromWorkspaceID, found := item.workspaceOfWindow(sketchybarWindowID)
+	if !found || !item.renderedItems[getSketchybarWorkspaceID(data.Workspace)] {
+		return batches, false
+	}
+
+	if fromWorkspaceID == data.Workspace {
+		return batches, true
+	}
+
+	if !item.aerospace.MoveWindow(data.WindowID, data.Workspace) {
+		return batches, false
+	}
+
+	item.workspaceWindowIDs[fromWorkspaceID] = slices.DeleteFunc(
+		slices.Clone(item.workspaceWindowIDs[fromWorkspaceID]),
+		func(id string) bool { return id == sketchybarWindowID },
+	)
+	item.workspaceWindowIDs[data.Workspace] = append(
+		slices.Clone(item.workspaceWindowIDs[data.Workspace]),
+		sketchybarWindowID,
+	)
+
+	tree := item.aerospace.GetTree()
+	focusedWorkspaceID := item.aerospace.GetFocusedWorkspaceID(ctx)
+
+	var aggregatedErr error
+	for _, monitor := range tree.Monitors {
+		for _, workspace := range monitor.Workspaces {
+			if workspace.Workspace != fromWorkspaceID && workspace.Workspace != data.Workspace {
+				continue
+			}
+
+			item.renderWorkspaceSafely(
+				ctx,
+				&batches,
+				&aggregatedErr,
+				workspace,
+				tree,
+				focusedWorkspaceID,
+				position,
+				len(tree.Monitors),
+				monitor.Monitor,
+				0,
+			)
+		}
+	}
+
+	if aggregatedErr != nil {
+		item.logger.ErrorContext(ctx, "aerospace item: could not render moved window", slog.Any("error", aggregatedErr))
+	}
+
+	return batches, true
+}
+
+func (item *AerospaceItem) workspaceOfWindow(sketchybarWindowID string) (string, bool) {
+	for workspaceID, sketchybarWindowIDs := range item.workspaceWindowIDs {
+		if slices.Contains(sketchybarWindowIDs, sketchybarWindowID) {
+			return workspaceID, true
+		}
+	}
+
+	return "", false
 }
 
 func (item *AerospaceItem) renderSafely(
 	ctx context.Context,
 	batches Batches,
 	position sketchybar.Position,
+	refreshTree bool,
 ) (Batches, error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -172,14 +331,12 @@ func (item *AerospaceItem) renderSafely(
 				item.logger.ErrorContext(ctx, "aerospace item: recovered from panic in SingleFlightRefreshTree", slog.Any("panic", r))
 			}
 		}()
-		item.aerospace.SingleFlightRefreshTree()
+		if refreshTree {
+			item.aerospace.SingleFlightRefreshTree()
+		}
 	}()
 
 	tree := item.aerospace.GetTree()
-	if tree == nil {
-		item.logger.WarnContext(ctx, "Tree is nil during render, using fallback")
-		return item.createFallbackBatches(batches, position), nil
-	}
 
 	// Get focused workspace safely
 	focusedWorkspaceID := ""
@@ -212,6 +369,13 @@ func (item *AerospaceItem) renderWithErrorRecovery(
 	newItems := make(map[string]bool)
 	var aggregatedErr error
 
+	state := renderState{
+		focusedWorkspaceID: focusedWorkspaceID,
+		recentWorkspaceID:  item.getRecentWorkspaceID(),
+		focusedApp:         item.aerospace.GetFocusedApp(),
+	}
+	scope := newRenderScope(item.renderedTree, item.renderedState, tree, state)
+
 	// Safely determine items that should be on the bar
 	func() {
 		defer func() {
@@ -227,30 +391,29 @@ func (item *AerospaceItem) renderWithErrorRecovery(
 			if monitor == nil {
 				continue
 			}
-			
-			visibleWorkspaces := []*aerospace.WorkspaceWithWindowIDs{}
-			for _, workspace := range monitor.Workspaces {
-				if workspace == nil {
-					continue
-				}
-				if _, ok := icons.Workspace[workspace.Workspace]; ok {
-					visibleWorkspaces = append(visibleWorkspaces, workspace)
-				}
+
+			if showMonitorLabels(tree) {
+				newItems[getSketchybarMonitorID(monitor.Monitor)] = true
 			}
 
+			visibleWorkspaces := item.getVisibleWorkspaces(monitor, tree)
+
 			for i, workspace := range visibleWorkspaces {
 				if workspace == nil {
 					continue
 				}
-				
+
 				newItems[getSketchybarWorkspaceID(workspace.Workspace)] = true
 				newItems[getSketchybarBracketID(workspace.Workspace)] = true
 				newItems[getSketchybarBracketSpacerID(workspace.Workspace)] = true
-				
+
 				for _, windowID := range workspace.Windows {
+					if item.isHiddenWindow(tree.IndexedWindows[windowID]) {
+						continue
+					}
 					newItems[getSketchybarWindowID(windowID)] = true
 				}
-				
+
 				if i < len(visibleWorkspaces)-1 {
 					newItems[getSketchybarSpacerID(workspace.Workspace)] = true
 				}
@@ -298,8 +461,7 @@ func (item *AerospaceItem) renderWithErrorRecovery(
 					}
 
 					if isWindowItem(itemID) {
-						batches = batch(batches, s(
-							"--animate", sketchybar.AnimationTanh, settings.Sketchybar.Aerospace.TransitionTime,
+						batches = batch(batches, aerospaceAnimation().Animate(
 							"--set", itemID,
 							"icon.drawing=off",
 							"width=0",
@@ -344,7 +506,9 @@ func (item *AerospaceItem) renderWithErrorRecovery(
 		if !item.renderedItems[sketchybarSpacerID] {
 			batches = batch(batches, s("--add", "item", sketchybarSpacerID, position))
 		}
-		batches = batch(batches, m(s("--set", sketchybarSpacerID), aerospaceSpacerItem.ToArgs()))
+		if scope.all || !item.renderedItems[sketchybarSpacerID] {
+			batches = batch(batches, m(s("--set", sketchybarSpacerID), aerospaceSpacerItem.ToArgs()))
+		}
 	}()
 
 	// Safely render workspaces and windows
@@ -359,12 +523,19 @@ func (item *AerospaceItem) renderWithErrorRecovery(
 			if monitor == nil {
 				continue
 			}
-			
-			item.renderMonitorSafely(ctx, &batches, &aggregatedErr, monitor, tree, focusedWorkspaceID, position)
+
+			item.renderMonitorSafely(ctx, &batches, &aggregatedErr, monitor, tree, focusedWorkspaceID, position, scope)
 		}
 	}()
 
 	item.renderedItems = newItems
+	item.renderedTree = tree
+	item.renderedState = state
+	if aggregatedErr != nil {
+		// what failed is rendered again by the next render
+		item.renderedTree = nil
+	}
+
 	return batches, aggregatedErr
 }
 
@@ -376,6 +547,7 @@ func (item *AerospaceItem) renderMonitorSafely(
 	tree *aerospace.Tree,
 	focusedWorkspaceID string,
 	position sketchybar.Position,
+	scope renderScope,
 ) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -383,32 +555,32 @@ func (item *AerospaceItem) renderMonitorSafely(
 		}
 	}()
 
-	visibleWorkspaces := []*aerospace.WorkspaceWithWindowIDs{}
-	for _, workspace := range monitor.Workspaces {
-		if workspace == nil {
-			continue
-		}
-		if _, ok := icons.Workspace[workspace.Workspace]; ok {
-			visibleWorkspaces = append(visibleWorkspaces, workspace)
-		}
+	visibleWorkspaces := item.getVisibleWorkspaces(monitor, tree)
+
+	if showMonitorLabels(tree) && (scope.all || !item.renderedItems[getSketchybarMonitorID(monitor.Monitor)]) {
+		*batches = item.renderMonitorLabel(*batches, monitor, visibleWorkspaces, len(tree.Monitors), position)
 	}
 
 	for i, workspace := range visibleWorkspaces {
 		if workspace == nil {
 			continue
 		}
-		
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					item.logger.ErrorContext(ctx, "aerospace item: recovered from panic rendering workspace", 
-						slog.Any("panic", r),
-						slog.String("workspace", workspace.Workspace))
-				}
+
+		// unchanged workspaces keep what sketchybar already has
+		isRendered := item.renderedItems[getSketchybarWorkspaceID(workspace.Workspace)]
+		if !isRendered || scope.includes(workspace.Workspace) {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						item.logger.ErrorContext(ctx, "aerospace item: recovered from panic rendering workspace",
+							slog.Any("panic", r),
+							slog.String("workspace", workspace.Workspace))
+					}
+				}()
+
+				item.renderWorkspaceSafely(ctx, batches, aggregatedErr, workspace, tree, focusedWorkspaceID, position, len(tree.Monitors), monitor.Monitor, i)
 			}()
-			
-			item.renderWorkspaceSafely(ctx, batches, aggregatedErr, workspace, tree, focusedWorkspaceID, position, len(tree.Monitors), monitor.Monitor)
-		}()
+		}
 
 		// Add spacer between workspaces
 		if i < len(visibleWorkspaces)-1 {
@@ -420,6 +592,43 @@ func (item *AerospaceItem) renderMonitorSafely(
 	}
 }
 
+// getVisibleWorkspaces returns the workspaces with an icon, in the order of WorkspaceSort.
+func (item *AerospaceItem) getVisibleWorkspaces(
+	monitor *aerospace.Branch,
+	tree *aerospace.Tree,
+) []*aerospace.WorkspaceWithWindowIDs {
+	visibleWorkspaces := []*aerospace.WorkspaceWithWindowIDs{}
+	for _, workspace := range monitor.Workspaces {
+		if workspace == nil {
+			continue
+		}
+		if _, ok := item.iconResolver.Resolve(workspace, tree.IndexedWindows); ok {
+			visibleWorkspaces = append(visibleWorkspaces, workspace)
+		}
+	}
+
+	return sortWorkspaces(visibleWorkspaces, settings.Sketchybar.Aerospace.WorkspaceSort)
+}
+
+// sortWorkspaces keeps the natural order of the workspaces which are not in order.
+func sortWorkspaces(
+	workspaces []*aerospace.WorkspaceWithWindowIDs,
+	order []string,
+) []*aerospace.WorkspaceWithWindowIDs {
+	rank := func(workspace *aerospace.WorkspaceWithWindowIDs) int {
+		if index := slices.Index(order, workspace.Workspace); index >= 0 {
+			return index
+		}
+		return len(order)
+	}
+
+	slices.SortStableFunc(workspaces, func(left, right *aerospace.WorkspaceWithWindowIDs) int {
+		return rank(left) - rank(right)
+	})
+
+	return workspaces
+}
+
 func (item *AerospaceItem) renderWorkspaceSafely(
 	ctx context.Context,
 	batches *Batches,
@@ -430,6 +639,7 @@ func (item *AerospaceItem) renderWorkspaceSafely(
 	position sketchybar.Position,
 	monitorsCount int,
 	monitorID int,
+	staggerIndex int,
 ) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -439,11 +649,11 @@ func (item *AerospaceItem) renderWorkspaceSafely(
 
 	isFocusedWorkspace := focusedWorkspaceID == workspace.Workspace
 	sketchybarSpaceID := getSketchybarWorkspaceID(workspace.Workspace)
-	
+
 	// Render workspace icon safely
-	workspaceSpace, err := item.workspaceToSketchybar(isFocusedWorkspace, monitorsCount, monitorID, workspace.Workspace)
+	workspaceSpace, err := item.workspaceToSketchybar(isFocusedWorkspace, monitorsCount, monitorID, workspace, tree)
 	if err != nil {
-		item.logger.ErrorContext(ctx, "aerospace item: failed to create workspace item", 
+		item.logger.ErrorContext(ctx, "aerospace item: failed to create workspace item",
 			slog.Any("error", err),
 			slog.String("workspace", workspace.Workspace))
 		*aggregatedErr = errors.Join(*aggregatedErr, err)
@@ -452,9 +662,10 @@ func (item *AerospaceItem) renderWorkspaceSafely(
 
 	if !item.renderedItems[sketchybarSpaceID] {
 		*batches = batch(*batches, s("--add", "item", sketchybarSpaceID, position))
+		*batches = staggerWorkspaceEntrance(*batches, sketchybarSpaceID, staggerIndex)
 	}
 	*batches = batch(*batches, m(
-		s("--animate", sketchybar.AnimationTanh, settings.Sketchybar.Aerospace.TransitionTime, "--set", sketchybarSpaceID),
+		aerospaceAnimation().Animate("--set", sketchybarSpaceID),
 		workspaceSpace.ToArgs(),
 	))
 
@@ -483,20 +694,27 @@ func (item *AerospaceItem) renderWindowsSafely(
 
 	for _, windowID := range workspace.Windows {
 		window := tree.IndexedWindows[windowID]
-		if window == nil {
+		if window == nil || item.isHiddenWindow(window) {
 			continue
 		}
-		
+
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
-					item.logger.ErrorContext(ctx, "aerospace item: recovered from panic rendering window", 
+					item.logger.ErrorContext(ctx, "aerospace item: recovered from panic rendering window",
 						slog.Any("panic", r),
 						slog.Int("windowID", windowID))
 				}
 			}()
 
-			windowItem := item.windowToSketchybar(isFocusedWorkspace, monitorID, workspace.Workspace, window.App)
+			windowItem, err := item.windowToSketchybar(isFocusedWorkspace, monitorID, workspace.Workspace, window)
+			if err != nil {
+				item.logger.ErrorContext(ctx, "aerospace item: failed to create window item",
+					slog.Any("error", err),
+					slog.Int("windowID", windowID))
+				return
+			}
+
 			sketchybarWindowID := getSketchybarWindowID(windowID)
 
 			isNewWindow := !item.renderedItems[sketchybarWindowID]
@@ -511,7 +729,7 @@ func (item *AerospaceItem) renderWindowsSafely(
 
 			*batches = batch(*batches, s("--move", sketchybarWindowID, "after", prevSketchybarItemID))
 			*batches = batch(*batches, m(
-				s("--animate", sketchybar.AnimationTanh, settings.Sketchybar.Aerospace.TransitionTime, "--set", sketchybarWindowID),
+				aerospaceAnimation().Animate("--set", sketchybarWindowID),
 				windowItem.ToArgs(),
 			))
 
@@ -567,7 +785,7 @@ func (item *AerospaceItem) handleBracketsAndSpacersSafely(
 func (item *AerospaceItem) createFallbackBatches(batches Batches, position sketchybar.Position) Batches {
 	// Create minimal fallback UI when everything fails
 	item.logger.InfoContext(context.Background(), "aerospace item: creating fallback batches")
-	
+
 	defer func() {
 		if r := recover(); r != nil {
 			item.logger.ErrorContext(context.Background(), "aerospace item: recovered from panic in createFallbackBatches", slog.Any("panic", r))
@@ -579,11 +797,11 @@ func (item *AerospaceItem) createFallbackBatches(batches Batches, position sketc
 		Width:      pointer(*settings.Sketchybar.ItemSpacing),
 		Background: sketchybar.BackgroundOptions{Drawing: "off"},
 	}
-	
+
 	fallbackID := "aerospace.fallback"
 	batches = batch(batches, s("--add", "item", fallbackID, position))
 	batches = batch(batches, m(s("--set", fallbackID), spacerItem.ToArgs()))
-	
+
 	return batches
 }
 
@@ -604,11 +822,15 @@ func (item *AerospaceItem) handleWorkspaceBracket(
 		borderColor = colorsPkg.Transparent
 	}
 
+	bracketArgs := []string{fmt.Sprintf("background.border_color=%s", borderColor)}
+	if borderWidth := getWorkspaceBorderWidth(isFocusedWorkspace); borderWidth != nil {
+		bracketArgs = append(bracketArgs, fmt.Sprintf("background.border_width=%d", *borderWidth))
+	}
+
 	// Always animate the color to handle visibility and focus changes
-	batches = batch(batches, s(
-		"--animate", sketchybar.AnimationTanh, settings.Sketchybar.Aerospace.TransitionTime,
-		"--set", sketchybarBracketID,
-		fmt.Sprintf("background.border_color=%s", borderColor),
+	batches = batch(batches, m(
+		aerospaceAnimation().Animate("--set", sketchybarBracketID),
+		bracketArgs,
 	))
 
 	// Update the internal state for the next render cycle.
@@ -645,93 +867,126 @@ func (item *AerospaceItem) workspaceToSketchybar(
 	isFocusedWorkspace bool,
 	monitorsCount int,
 	monitorID int,
-	workspaceID string,
+	workspace *aerospace.WorkspaceWithWindowIDs,
+	tree *aerospace.Tree,
 ) (*sketchybar.ItemOptions, error) {
-	icon, hasIcon := icons.Workspace[workspaceID]
+	workspaceID := workspace.Workspace
+	iconInfo, hasIcon := item.iconResolver.Resolve(workspace, tree.IndexedWindows)
 	if !hasIcon {
 		item.logger.Info(
-			"could not find icon for app",
-			slog.String("app", workspaceID),
+			"could not find icon for workspace",
+			slog.String("workspace", workspaceID),
 		)
 		return nil, fmt.Errorf("could not find icon for workspace %s", workspaceID)
 	}
 
+	iconFont := sketchybar.EmptyFontOptions
+	if iconInfo.Font != "" {
+		// the app icon of the fallback
+		iconFont = sketchybar.FontOptions{
+			Font: iconInfo.Font,
+			Kind: "Regular",
+			Size: settings.Sketchybar.IconFontSize,
+		}
+	}
+
 	colors := item.getWorkspaceColors(isFocusedWorkspace)
+	if !isFocusedWorkspace && workspaceID == item.getRecentWorkspaceID() {
+		colors.color = settings.Sketchybar.Aerospace.WorkspaceRecentColor
+	}
 
-	return &sketchybar.ItemOptions{
-		Display: item.getSketchybarDisplayIndex(monitorsCount, monitorID),
-		Padding: sketchybar.PaddingOptions{
-			Left:  pointer(0),
-			Right: pointer(0),
-		},
-		Background: sketchybar.BackgroundOptions{
+	workspaceItem, err := sketchybar.NewItem().
+		WithDisplay(item.getSketchybarDisplayIndex(monitorsCount, monitorID)).
+		WithPadding(pointer(0), pointer(0)).
+		WithBackground(sketchybar.BackgroundOptions{
 			Drawing: "on",
 			Color: sketchybar.ColorOptions{
 				Color: colors.backgroundColor,
 			},
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Value: icon,
-			Color: sketchybar.ColorOptions{
-				Color: colors.color,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.Aerospace.Padding,
-				Right: settings.Sketchybar.Aerospace.Padding,
+			Color2: sketchybar.ColorOptions{
+				Color: colors.backgroundColor2,
 			},
-		},
-		ClickScript: fmt.Sprintf(`aerospace workspace "%s"`, workspaceID),
-	}, nil
+		}).
+		WithIcon(iconInfo.Icon, iconFont).
+		WithIconColor(colors.color).
+		WithIconPadding(settings.Sketchybar.Aerospace.Padding, settings.Sketchybar.Aerospace.Padding).
+		WithClickScript(fmt.Sprintf(`aerospace workspace "%s"`, workspaceID)).
+		Build()
+
+	if err != nil {
+		return nil, fmt.Errorf("aerospace: could not build workspace %s. %w", workspaceID, err)
+	}
+
+	return &workspaceItem, nil
 }
 
 func (item *AerospaceItem) windowToSketchybar(
 	isFocusedWorkspace bool,
 	monitorID aerospace.MonitorID,
 	workspaceID aerospace.WorkspaceID,
-	windowApp string,
-) *sketchybar.ItemOptions {
-	iconInfo, hasIcon := icons.App[windowApp]
+	window *aerospace.Window,
+) (*sketchybar.ItemOptions, error) {
+	iconInfo, hasIcon := icons.App[window.App]
 	if !hasIcon {
 		item.logger.Info(
 			"could not find icon for app",
-			slog.String("app", windowApp),
+			slog.String("app", window.App),
+			slog.String("title", window.Title),
 		)
 		iconInfo = icons.IconInfo{Icon: icons.Unknown, Font: settings.FontAppIcon}
 	}
 
+	display := strconv.Itoa(monitorID)
+	if displayNumber, found := item.displayNumbers[monitorID]; found {
+		display = strconv.Itoa(displayNumber)
+	}
+
 	windowVisibility := item.getWindowVisibility(isFocusedWorkspace)
-	itemOptions := &sketchybar.ItemOptions{
-		Display: strconv.Itoa(monitorID),
-		Width:   windowVisibility.width,
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Drawing: windowVisibility.show,
-			Color: sketchybar.ColorOptions{
-				Color: windowVisibility.color,
-			},
-			Font: sketchybar.FontOptions{
-				Font: iconInfo.Font,
-				Kind: "Regular",
-				Size: "14.0",
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.Aerospace.Padding,
-				Right: settings.Sketchybar.Aerospace.Padding,
-			},
-			Value: iconInfo.Icon,
-		},
-		ClickScript: fmt.Sprintf(`aerospace workspace "%s"`, workspaceID),
+
+	iconColor := windowVisibility.color
+	if window.IsFloating && isFocusedWorkspace {
+		iconColor = settings.Sketchybar.Aerospace.WindowFloatingColor
+	}
+	if utils.Equals(window.App, item.aerospace.GetFocusedApp()) {
+		iconColor = windowVisibility.focusedColor
 	}
 
-	if utils.Equals(windowApp, item.aerospace.GetFocusedApp()) {
-		itemOptions.Icon.Color = sketchybar.ColorOptions{
-			Color: windowVisibility.focusedColor,
-		}
+	windowItem, err := sketchybar.NewItem().
+		WithDisplay(display).
+		WithWidth(*windowVisibility.width).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		WithIcon(iconInfo.Icon, sketchybar.FontOptions{
+			Font: iconInfo.Font,
+			Kind: "Regular",
+			Size: "14.0",
+		}).
+		WithIconDrawing(windowVisibility.show).
+		WithIconColor(iconColor).
+		WithIconPadding(settings.Sketchybar.Aerospace.Padding, settings.Sketchybar.Aerospace.Padding).
+		WithClickScript(windowClickScript(item.executable, window.ID, workspaceID)).
+		Build()
+
+	if err != nil {
+		return nil, fmt.Errorf("aerospace: could not build window %d. %w", window.ID, err)
+	}
+
+	return &windowItem, nil
+}
+
+// windowClickScript focuses the workspace of the window, shift-click moves the window to the focused workspace.
+func windowClickScript(executable string, windowID aerospace.WindowID, workspaceID aerospace.WorkspaceID) string {
+	focusWorkspace := fmt.Sprintf(`aerospace workspace "%s"`, workspaceID)
+
+	if executable == "" {
+		return focusWorkspace
 	}
 
-	return itemOptions
+	return fmt.Sprintf(
+		`if [ "$MODIFIER" = "shift" ]; then "%s" move-window %d --target-workspace "$(aerospace list-workspaces --focused)"; else %s; fi`,
+		executable,
+		windowID,
+		focusWorkspace,
+	)
 }
 
 func getSketchybarWorkspaceID(spaceID aerospace.WorkspaceID) string {
@@ -754,6 +1009,10 @@ func getSketchybarSpacerID(spaceID aerospace.WorkspaceID) string {
 	return fmt.Sprintf("%s.%s", spacerItemPrefix, spaceID)
 }
 
+func getSketchybarMonitorID(monitorID aerospace.MonitorID) string {
+	return fmt.Sprintf("%s.%d", monitorItemPrefix, monitorID)
+}
+
 func checker(batches Batches, position sketchybar.Position) (Batches, error) {
 	updateEvent, err := args.BuildEvent()
 	if err != nil {
@@ -768,6 +1027,9 @@ func checker(batches Batches, position sketchybar.Position) (Batches, error) {
 		Script:  updateEvent,
 	}
 
+	batches = batch(batches, s("--add", "event", aerospace_events.WindowCreated))
+	batches = batch(batches, s("--add", "event", aerospace_events.WindowDestroyed))
+	batches = batch(batches, s("--add", "event", aerospace_events.WindowMoved))
 	batches = batch(batches, s("--add", "item", aerospaceCheckerItemName, position))
 	batches = batch(batches, m(s("--set", aerospaceCheckerItemName), checkerItem.ToArgs()))
 	batches = batch(batches, s("--subscribe", aerospaceCheckerItemName,
@@ -775,31 +1037,65 @@ func checker(batches Batches, position sketchybar.Position) (Batches, error) {
 		events.SpaceWindowsChange,
 		events.SystemWoke,
 		events.FrontAppSwitched,
+		aerospace_events.WindowCreated,
+		aerospace_events.WindowDestroyed,
+		aerospace_events.WindowMoved,
 	))
 
 	return batches, nil
 }
 
+func getWorkspaceBorderWidth(isFocusedWorkspace bool) *int {
+	if isFocusedWorkspace {
+		return settings.Sketchybar.Aerospace.WorkspaceFocusedBorderWidth
+	}
+	return settings.Sketchybar.Aerospace.WorkspaceBorderWidth
+}
+
 type workspaceColors struct {
-	backgroundColor string
-	color           string
+	backgroundColor  string
+	backgroundColor2 string
+	color            string
 }
 
 func (item *AerospaceItem) getWorkspaceColors(isFocusedWorkspace bool) workspaceColors {
 	backgroundColor := settings.Sketchybar.Aerospace.WorkspaceBackgroundColor
-	color := settings.Sketchybar.Aerospace.WorkspaceColor
+	backgroundColor2 := ""
+	if settings.Sketchybar.Aerospace.WorkspaceFocusedBackgroundGradient != "" {
+		// sketchybar keeps the last color2, a workspace which lost the focus would keep the gradient
+		backgroundColor2 = backgroundColor
+	}
+	color := sketchybar.ColorOptions{Color: settings.Sketchybar.Aerospace.WorkspaceColor}.
+		WithAlpha(settings.Sketchybar.Aerospace.WorkspaceAlpha).
+		Color
 
 	if isFocusedWorkspace {
 		backgroundColor = settings.Sketchybar.Aerospace.WorkspaceFocusedBackgroundColor
+		backgroundColor2 = settings.Sketchybar.Aerospace.WorkspaceFocusedBackgroundGradient
 		color = settings.Sketchybar.Aerospace.WorkspaceFocusedColor
 	}
 
 	return workspaceColors{
 		backgroundColor,
+		backgroundColor2,
 		color,
 	}
 }
 
+func (item *AerospaceItem) isHiddenWindow(window *aerospace.Window) bool {
+	return window != nil && item.hiddenApps[window.App]
+}
+
+// getRecentWorkspaceID returns the workspace focused before the current one.
+func (item *AerospaceItem) getRecentWorkspaceID() string {
+	history := item.aerospace.GetWorkspaceHistory()
+	if len(history) < 2 {
+		return ""
+	}
+
+	return history[1]
+}
+
 type windowVisibility struct {
 	width        *int
 	show         string
@@ -832,6 +1128,11 @@ func (item *AerospaceItem) getSketchybarDisplayIndex(
 	monitorCount int,
 	monitorID aerospace.MonitorID,
 ) string {
+	if displayNumber, found := item.displayNumbers[monitorID]; found {
+		return strconv.Itoa(displayNumber)
+	}
+
+	// without the screens of aerospace the display is guessed
 	if monitorCount == 0 {
 		return "1"
 	}
@@ -843,48 +1144,109 @@ func (item *AerospaceItem) getSketchybarDisplayIndex(
 	return strconv.Itoa(result)
 }
 
+// refreshDisplayNumbers keeps the previous display numbers when aerospace does not answer.
+func (item *AerospaceItem) refreshDisplayNumbers(ctx context.Context) {
+	monitors, err := item.aerospace.ListMonitors(ctx)
+	if err != nil {
+		item.logger.ErrorContext(ctx, "aerospace item: could not list monitors for the displays", slog.Any("error", err))
+		return
+	}
+
+	displayNumbers := make(map[aerospace.MonitorID]int, len(monitors))
+	for _, monitor := range monitors {
+		if monitor.ScreenID > 0 {
+			displayNumbers[monitor.ID] = monitor.ScreenID
+		}
+	}
+
+	if !maps.Equal(item.displayNumbers, displayNumbers) {
+		// every item shows on a display, the tree alone does not tell
+		item.renderedTree = nil
+	}
+
+	item.displayNumbers = displayNumbers
+}
+
 func (item *AerospaceItem) addWorkspaceBracket(
 	batches Batches,
 	isFocusedWorkspace bool,
 	workspaceID string,
 ) Batches {
 	colors := item.getWorkspaceColors(isFocusedWorkspace)
-	workspaceBracketItem := sketchybar.BracketOptions{
-		Background: sketchybar.BackgroundOptions{
+	sketchybarSpaceID := getSketchybarWorkspaceID(workspaceID)
+	sketchybarBracketID := getSketchybarBracketID(workspaceID)
+	bracketSpacerID := getSketchybarBracketSpacerID(workspaceID)
+
+	// The bracket is defined by the workspace icon and the spacer.
+	// Windows will be moved between these two items.
+	workspaceBracketItem := sketchybar.BracketOptions{}.
+		WithMembers(sketchybarSpaceID, bracketSpacerID).
+		WithBackground(sketchybar.BackgroundOptions{
 			Drawing: "on",
 			Border: sketchybar.BorderOptions{
+				Width: getWorkspaceBorderWidth(isFocusedWorkspace),
 				Color: colors.backgroundColor,
 			},
 			Color: sketchybar.ColorOptions{
 				Color: colorsPkg.Transparent,
 			},
-		},
-	}
-
-	sketchybarSpaceID := getSketchybarWorkspaceID(workspaceID)
-	sketchybarBracketID := getSketchybarBracketID(workspaceID)
-	bracketSpacerID := getSketchybarBracketSpacerID(workspaceID)
-
-	// The bracket is defined by the workspace icon and the spacer.
-	// Windows will be moved between these two items.
-	itemsForBracket := []string{sketchybarSpaceID, bracketSpacerID}
+		})
 
 	item.logger.Debug("Adding workspace bracket",
 		slog.String("workspace", workspaceID),
 		slog.String("bracketID", sketchybarBracketID),
-		slog.Any("items", itemsForBracket))
+		slog.Any("items", workspaceBracketItem.Members))
 
-	batches = batch(batches, m(s(
-		"--add",
-		"bracket",
-		sketchybarBracketID),
-		itemsForBracket,
-	))
+	batches = batch(batches, workspaceBracketItem.Build(sketchybarBracketID))
 
-	batches = batch(batches, m(s(
-		"--set",
-		sketchybarBracketID,
-	), workspaceBracketItem.ToArgs()))
+	return batches
+}
+
+func showMonitorLabels(tree *aerospace.Tree) bool {
+	return settings.Sketchybar.Aerospace.ShowMonitorLabels && len(tree.Monitors) > 1
+}
+
+// renderMonitorLabel shows the monitor name, or its id, before the first workspace of the monitor.
+func (item *AerospaceItem) renderMonitorLabel(
+	batches Batches,
+	monitor *aerospace.Branch,
+	visibleWorkspaces []*aerospace.WorkspaceWithWindowIDs,
+	monitorsCount int,
+	position sketchybar.Position,
+) Batches {
+	label := monitor.MonitorName
+	if label == "" {
+		label = strconv.Itoa(monitor.Monitor)
+	}
+
+	monitorItem, err := sketchybar.NewItem().
+		WithDisplay(item.getSketchybarDisplayIndex(monitorsCount, monitor.Monitor)).
+		WithBackground(sketchybar.BackgroundOptions{
+			Drawing: "on",
+			Color: sketchybar.ColorOptions{
+				Color: settings.Sketchybar.Aerospace.MonitorLabelColor,
+			},
+		}).
+		WithIconDrawing("off").
+		WithLabel(label).
+		WithLabelPadding(settings.Sketchybar.Aerospace.Padding, settings.Sketchybar.Aerospace.Padding).
+		Build()
+
+	if err != nil {
+		item.logger.Error("aerospace item: could not build monitor item", slog.Any("error", err))
+		return batches
+	}
+
+	sketchybarMonitorID := getSketchybarMonitorID(monitor.Monitor)
+	if !item.renderedItems[sketchybarMonitorID] {
+		batches = batch(batches, s("--add", "item", sketchybarMonitorID, position))
+
+		if len(visibleWorkspaces) > 0 {
+			firstWorkspaceID := getSketchybarWorkspaceID(visibleWorkspaces[0].Workspace)
+			batches = batch(batches, s("--move", sketchybarMonitorID, "before", firstWorkspaceID))
+		}
+	}
+	batches = batch(batches, m(s("--set", sketchybarMonitorID), monitorItem.ToArgs()))
 
 	return batches
 }
@@ -943,6 +1305,39 @@ func (item *AerospaceItem) addBracketSpacer(
 	return batches
 }
 
+// staggerWorkspaceEntrance starts a new workspace hidden and at zero width, the inverse of the closing animation,
+// waiting index times the stagger delay so that workspaces added together appear one after the other.
+func staggerWorkspaceEntrance(batches Batches, sketchybarSpaceID string, index int) Batches {
+	aerospaceSettings := settings.Sketchybar.Aerospace
+
+	initialState := s("--set", sketchybarSpaceID, "icon.drawing=off")
+	finalState := s("--set", sketchybarSpaceID, "icon.drawing=on")
+	if aerospaceSettings.WorkspaceWidth != nil {
+		initialState = s("--set", sketchybarSpaceID, "width=0", "icon.drawing=off")
+		finalState = s("--set", sketchybarSpaceID, fmt.Sprintf("width=%d", *aerospaceSettings.WorkspaceWidth), "icon.drawing=on")
+	}
+
+	batches = batch(batches, initialState)
+
+	// sketchybar animates by frames, at 60 per second
+	delayFrames := int(math.Round(float64(index) * aerospaceSettings.WorkspaceStaggerDelay.Seconds() * 60))
+	if delayFrames > 0 {
+		batches = batch(batches, sketchybar.Animate(
+			sketchybar.AnimationLinear, strconv.Itoa(delayFrames),
+			initialState...,
+		))
+	}
+
+	return batch(batches, aerospaceAnimation().Animate(finalState...))
+}
+
+func aerospaceAnimation() settings.AnimationConfig {
+	return settings.Sketchybar.Animation(aerospaceItemName, settings.AnimationConfig{
+		Easing: sketchybar.AnimationTanh,
+		Time:   settings.Sketchybar.Aerospace.TransitionTime,
+	})
+}
+
 func isAerospace(name string) bool {
 	return name == AerospaceName
 }
@@ -952,6 +1347,10 @@ func pointer[T any](v T) *T {
 	return &v
 }
 
+// SketchybarItems is empty, the items follow the workspaces and windows of aerospace.
+func (item *AerospaceItem) SketchybarItems() []string {
+	return nil
+}
 
-
-var _ WentsketchyItem = (*AerospaceItem)(nil)
\ No newline at end of file
+var _ WentsketchyItem = (*AerospaceItem)(nil)
+var _ SketchybarItemsOwner = (*AerospaceItem)(nil)
@@ -0,0 +1,79 @@
+package items
+
+import (
+	"slices"
+
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+	"github.com/lucax88x/wentsketchy/internal/utils"
+)
+
+// renderState is what the items depend on besides the tree.
+type renderState struct {
+	focusedWorkspaceID aerospace.WorkspaceID
+	recentWorkspaceID  aerospace.WorkspaceID
+	focusedApp         string
+}
+
+// renderScope are the workspaces whose items differ from the last render, all of them on the first render.
+type renderScope struct {
+	all        bool
+	workspaces map[aerospace.WorkspaceID]bool
+}
+
+func (scope renderScope) includes(workspaceID aerospace.WorkspaceID) bool {
+	return scope.all || scope.workspaces[workspaceID]
+}
+
+func newRenderScope(
+	renderedTree *aerospace.Tree,
+	renderedState renderState,
+	tree *aerospace.Tree,
+	state renderState,
+) renderScope {
+	// the display of every item depends on the monitors
+	if renderedTree == nil || !sameMonitors(renderedTree, tree) {
+		return renderScope{all: true}
+	}
+
+	workspaces := make(map[aerospace.WorkspaceID]bool)
+	diff := aerospace.DiffTree(renderedTree, tree)
+
+	for _, workspaceID := range slices.Concat(diff.Added.Workspaces, diff.Changed.Workspaces) {
+		workspaces[workspaceID] = true
+	}
+
+	changedWindows := slices.Concat(diff.Added.Windows, diff.Changed.Windows)
+	for workspaceID, workspace := range tree.IndexedWorkspaces {
+		for _, windowID := range workspace.Windows {
+			window := tree.IndexedWindows[windowID]
+
+			if slices.Contains(changedWindows, windowID) ||
+				(renderedState.focusedApp != state.focusedApp && window != nil &&
+					(utils.Equals(window.App, renderedState.focusedApp) || utils.Equals(window.App, state.focusedApp))) {
+				workspaces[workspaceID] = true
+			}
+		}
+	}
+
+	if renderedState.focusedWorkspaceID != state.focusedWorkspaceID {
+		workspaces[renderedState.focusedWorkspaceID] = true
+		workspaces[state.focusedWorkspaceID] = true
+	}
+
+	if renderedState.recentWorkspaceID != state.recentWorkspaceID {
+		workspaces[renderedState.recentWorkspaceID] = true
+		workspaces[state.recentWorkspaceID] = true
+	}
+
+	return renderScope{workspaces: workspaces}
+}
+
+func sameMonitors(left *aerospace.Tree, right *aerospace.Tree) bool {
+	return slices.EqualFunc(left.Monitors, right.Monitors, func(leftBranch, rightBranch *aerospace.Branch) bool {
+		if leftBranch == nil || rightBranch == nil {
+			return leftBranch == rightBranch
+		}
+
+		return leftBranch.Monitor == rightBranch.Monitor && leftBranch.MonitorName == rightBranch.MonitorName
+	})
+}
@@ -0,0 +1,627 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+	aerospace_events "github.com/lucax88x/wentsketchy/internal/aerospace/events"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/lucax88x/wentsketchy/testutils/fake"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals // test flag
+var update = flag.Bool("update", false, "update golden files")
+
+func TestUnitAerospace(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should init workspaces and windows of all monitors", func(t *testing.T) {
+		// GIVEN
+		// the fifo is in the config dir, which is different on every machine
+		fifoPath := settings.FifoPath
+		settings.FifoPath = "/tmp/wentsketchy"
+		t.Cleanup(func() { settings.FifoPath = fifoPath })
+
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+			FocusedApp:         "kitty",
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		// the test binary is in a temporary dir, which is different on every run
+		item.executable = "wentsketchy"
+
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		assertGolden(t, "aerospace_init.golden", serializeBatches(batches))
+	})
+
+	t.Run("should init only the checker with an empty tree", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{Tree: aerospace.EmptyTree()}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		require.Contains(t, batches, []string{"--add", "item", aerospaceCheckerItemName, "left"})
+		require.NotContains(t, serializeBatches(batches), workspaceItemPrefix)
+	})
+
+	t.Run("should highlight the previously focused workspace", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+			WorkspaceHistory:   []string{"2", "3", "1"},
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		workspace, err := item.workspaceToSketchybar(false, 2, 1, &aerospace.WorkspaceWithWindowIDs{Workspace: "3"}, aerospaceData.Tree)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, settings.Sketchybar.Aerospace.WorkspaceRecentColor, workspace.Icon.Color.Color)
+	})
+
+	t.Run("should size the fallback app icon with the icon font size", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Aerospace.FallbackToFirstAppIcon = true
+		iconFontSize := settings.Sketchybar.IconFontSize
+		settings.Sketchybar.IconFontSize = "20.0"
+		t.Cleanup(func() {
+			settings.Sketchybar.Aerospace.FallbackToFirstAppIcon = false
+			settings.Sketchybar.IconFontSize = iconFontSize
+		})
+
+		aerospaceData := &fake.Aerospace{Tree: createAerospaceTestTree()}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		workspace, err := item.workspaceToSketchybar(false, 2, 1, &aerospace.WorkspaceWithWindowIDs{
+			Workspace: "Z",
+			Windows:   []aerospace.WindowID{10},
+		}, aerospaceData.Tree)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "20.0", workspace.Icon.Font.Size)
+	})
+
+	t.Run("should add monitor labels before the first workspace", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Aerospace.ShowMonitorLabels = true
+		t.Cleanup(func() { settings.Sketchybar.Aerospace.ShowMonitorLabels = false })
+
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		require.Contains(t, batches, []string{"--add", "item", "aerospace.monitor.2", "left"})
+		require.Contains(t, batches, []string{"--move", "aerospace.monitor.2", "before", "aerospace.workspace.4"})
+		require.Contains(t, serializeBatches(batches), "--set aerospace.monitor.2 background.color=")
+		require.Contains(t, serializeBatches(batches), "label=2")
+	})
+
+	t.Run("should not render windows of hidden apps", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Aerospace.WorkspaceHiddenApps = []string{"Slack"}
+		t.Cleanup(func() { settings.Sketchybar.Aerospace.WorkspaceHiddenApps = nil })
+
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "3",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		require.NotContains(t, serializeBatches(batches), getSketchybarWindowID(30))
+		require.Contains(t, serializeBatches(batches), getSketchybarWindowID(10))
+	})
+
+	t.Run("should add a created window without refreshing the tree", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "5",
+			FocusedMonitorID:   2,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.WindowCreated,
+			Info:  `{"window-id":50,"workspace":"5","app-name":"Finder"}`,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 0, aerospaceData.Refreshes)
+		require.Equal(t, []aerospace.WindowID{50}, aerospaceData.Tree.IndexedWorkspaces["5"].Windows)
+		require.Contains(t, serializeBatches(batches), getSketchybarWindowID(50))
+	})
+
+	t.Run("should remove a destroyed window without refreshing the tree", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		_, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.WindowDestroyed,
+			Info:  `{"window-id":21}`,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 0, aerospaceData.Refreshes)
+		require.Equal(t, []aerospace.WindowID{20}, aerospaceData.Tree.IndexedWorkspaces["2"].Windows)
+		require.NotContains(t, aerospaceData.Tree.IndexedWindows, 21)
+	})
+
+	t.Run("should refresh the tree when a window is created on an unknown workspace", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{Tree: createAerospaceTestTree()}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		_, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.WindowCreated,
+			Info:  `{"window-id":50,"workspace":"9","app-name":"Finder"}`,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 1, aerospaceData.Refreshes)
+	})
+
+	t.Run("should render only the workspaces of a moved window", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "1",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		_, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+		require.NoError(t, err)
+		aerospaceData.Refreshes = 0
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.WindowMoved,
+			Info:  `{"window-id":21,"workspace":"1"}`,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 0, aerospaceData.Refreshes)
+		require.Contains(t, batches, []string{"--move", getSketchybarWindowID(21), "after", getSketchybarWindowID(10)})
+		require.Equal(t, []string{getSketchybarWindowID(20)}, item.workspaceWindowIDs["2"])
+		require.NotContains(t, serializeBatches(batches), getSketchybarWorkspaceID("3"))
+	})
+
+	t.Run("should render everything when a window moves before being rendered", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{Tree: createAerospaceTestTree()}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		_, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.WindowMoved,
+			Info:  `{"window-id":21,"workspace":"1"}`,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 1, aerospaceData.Refreshes)
+	})
+
+	t.Run("should render no workspace when nothing changed since the last render", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		_, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+		require.NoError(t, err)
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.AerospaceRefresh,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.NotContains(t, serializeBatches(batches), workspaceItemPrefix)
+		require.NotContains(t, serializeBatches(batches), windowItemPrefix)
+	})
+
+	t.Run("should render only the workspace of a created window", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		_, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+		require.NoError(t, err)
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.WindowCreated,
+			Info:  `{"window-id":31,"workspace":"3","app-name":"Finder"}`,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		serialized := serializeBatches(batches)
+		require.Contains(t, serialized, getSketchybarWindowID(31))
+		require.Contains(t, serialized, getSketchybarWorkspaceID("3"))
+		require.NotContains(t, serialized, getSketchybarWorkspaceID("2"))
+		require.NotContains(t, serialized, getSketchybarWindowID(20))
+	})
+
+	t.Run("should render the previously and the newly focused workspace", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		_, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+		require.NoError(t, err)
+		aerospaceData.FocusedWorkspaceID = "3"
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.AerospaceRefresh,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		serialized := serializeBatches(batches)
+		require.Contains(t, serialized, getSketchybarWorkspaceID("2"))
+		require.Contains(t, serialized, getSketchybarWorkspaceID("3"))
+		require.NotContains(t, serialized, getSketchybarWorkspaceID("1"))
+		require.NotContains(t, serialized, getSketchybarWorkspaceID("4"))
+	})
+
+	t.Run("should remove the gradient of a workspace which lost the focus", func(t *testing.T) {
+		// GIVEN
+		gradient := settings.Sketchybar.Aerospace.WorkspaceFocusedBackgroundGradient
+		settings.Sketchybar.Aerospace.WorkspaceFocusedBackgroundGradient = "0xff8aadf4"
+		t.Cleanup(func() { settings.Sketchybar.Aerospace.WorkspaceFocusedBackgroundGradient = gradient })
+
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+		require.NoError(t, err)
+		require.Contains(t, findSetBatch(batches, getSketchybarWorkspaceID("2")), "background.color2=0xff8aadf4")
+
+		// WHEN
+		batches, err = item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.WorkspaceChange,
+			Info:  `{"focused":"3","prev":"2"}`,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		background := "background.color=" + settings.Sketchybar.Aerospace.WorkspaceBackgroundColor
+		background2 := "background.color2=" + settings.Sketchybar.Aerospace.WorkspaceBackgroundColor
+		unfocused := findSetBatch(batches, getSketchybarWorkspaceID("2"))
+		require.Contains(t, unfocused, background)
+		require.Contains(t, unfocused, background2)
+	})
+
+	t.Run("should show workspaces and windows on the display of their monitor screen", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+			Monitors:           []aerospace.Monitor{{ID: 1, ScreenID: 1}, {ID: 2, ScreenID: 2}},
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "1", lastDisplayOf(batches, getSketchybarWorkspaceID("1")))
+		require.Equal(t, "1", lastDisplayOf(batches, getSketchybarWindowID(10)))
+		require.Equal(t, "2", lastDisplayOf(batches, getSketchybarWorkspaceID("4")))
+		require.Equal(t, "2", lastDisplayOf(batches, getSketchybarWindowID(40)))
+	})
+
+	t.Run("should render everything again when the displays change", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{
+			Tree:               createAerospaceTestTree(),
+			FocusedWorkspaceID: "2",
+			FocusedMonitorID:   1,
+			Monitors:           []aerospace.Monitor{{ID: 1, ScreenID: 1}, {ID: 2, ScreenID: 2}},
+		}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		_, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+		require.NoError(t, err)
+		aerospaceData.Monitors = []aerospace.Monitor{{ID: 1, ScreenID: 2}, {ID: 2, ScreenID: 1}}
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: events.DisplayChange,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "2", lastDisplayOf(batches, getSketchybarWorkspaceID("1")))
+		require.Equal(t, "1", lastDisplayOf(batches, getSketchybarWorkspaceID("4")))
+	})
+
+	t.Run("should sort workspaces by config and keep the others at the end", func(t *testing.T) {
+		// GIVEN
+		workspaces := []*aerospace.WorkspaceWithWindowIDs{
+			{Workspace: "1"},
+			{Workspace: "2"},
+			{Workspace: "3"},
+			{Workspace: "4"},
+		}
+
+		// WHEN
+		sorted := sortWorkspaces(workspaces, []string{"3", "1"})
+
+		// THEN
+		ids := make([]string, 0, len(sorted))
+		for _, workspace := range sorted {
+			ids = append(ids, workspace.Workspace)
+		}
+		require.Equal(t, []string{"3", "1", "2", "4"}, ids)
+	})
+
+	t.Run("should render workspaces in the configured order", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Aerospace.WorkspaceSort = []string{"3", "2", "1"}
+		t.Cleanup(func() { settings.Sketchybar.Aerospace.WorkspaceSort = nil })
+
+		aerospaceData := &fake.Aerospace{Tree: createAerospaceTestTree(), FocusedMonitorID: 1}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		serialized := serializeBatches(batches)
+		first := strings.Index(serialized, "--add item "+getSketchybarWorkspaceID("3"))
+		last := strings.Index(serialized, "--add item "+getSketchybarWorkspaceID("1"))
+		require.Less(t, first, last)
+		require.Contains(t, batches, []string{"--add", "item", getSketchybarSpacerID("3"), "left"})
+		require.NotContains(t, batches, []string{"--add", "item", getSketchybarSpacerID("1"), "left"})
+	})
+
+	t.Run("should start new workspaces hidden and animate them to full size", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Aerospace.WorkspaceWidth = pointer(34)
+		t.Cleanup(func() { settings.Sketchybar.Aerospace.WorkspaceWidth = nil })
+
+		tree := createAerospaceTestTree()
+		tree.Monitors[1].Workspaces = tree.Monitors[1].Workspaces[:2]
+		aerospaceData := &fake.Aerospace{Tree: tree, FocusedMonitorID: 1}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		_, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+		require.NoError(t, err)
+
+		aerospaceData.Tree = createAerospaceTestTree()
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{
+			Name:  AerospaceName,
+			Event: aerospace_events.AerospaceRefresh,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		workspaceID := getSketchybarWorkspaceID("6")
+		require.Contains(t, batches, []string{"--set", workspaceID, "width=0", "icon.drawing=off"})
+		require.Contains(t, serializeBatches(batches), "--set "+workspaceID+" width=34 icon.drawing=on")
+		require.NotContains(t, batches, []string{"--set", getSketchybarWorkspaceID("5"), "width=0", "icon.drawing=off"})
+	})
+
+	t.Run("should keep the dynamic width of new workspaces without a workspace width", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{Tree: createAerospaceTestTree(), FocusedMonitorID: 1}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionLeft, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		require.NotContains(t, findSetBatch(batches, getSketchybarWorkspaceID("1")), "width=0")
+	})
+
+	t.Run("should tint floating windows", func(t *testing.T) {
+		// GIVEN
+		aerospaceData := &fake.Aerospace{Tree: createAerospaceTestTree()}
+		item := NewAerospaceItem(logger, aerospaceData, nil)
+		window := &aerospace.Window{ID: 50, App: "Finder", IsFloating: true}
+
+		// WHEN
+		windowItem, err := item.windowToSketchybar(true, 1, "1", window)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, settings.Sketchybar.Aerospace.WindowFloatingColor, windowItem.Icon.Color.Color)
+	})
+}
+
+func createAerospaceTestTree() *aerospace.Tree {
+	windows := []*aerospace.Window{
+		{ID: 10, App: "Safari"},
+		{ID: 20, App: "kitty"},
+		{ID: 21, App: "Code"},
+		{ID: 30, App: "Slack"},
+		{ID: 40, App: "Spotify"},
+	}
+	workspaceWindows := map[aerospace.WorkspaceID][]aerospace.WindowID{
+		"1": {10},
+		"2": {20, 21},
+		"3": {30},
+		"4": {40},
+		"5": {},
+		"6": {},
+	}
+	monitorWorkspaces := map[aerospace.MonitorID][]aerospace.WorkspaceID{
+		1: {"1", "2", "3"},
+		2: {"4", "5", "6"},
+	}
+
+	tree := &aerospace.Tree{
+		Monitors:          make([]*aerospace.Branch, 0),
+		IndexedMonitors:   make(aerospace.IndexedMonitors),
+		IndexedWorkspaces: make(aerospace.IndexedWorkspaces),
+		IndexedWindows:    make(aerospace.IndexedWindows),
+	}
+
+	for _, window := range windows {
+		tree.IndexedWindows[window.ID] = window
+	}
+
+	for _, monitorID := range []aerospace.MonitorID{1, 2} {
+		branch := &aerospace.Branch{Monitor: monitorID}
+
+		for _, workspaceID := range monitorWorkspaces[monitorID] {
+			workspace := &aerospace.WorkspaceWithWindowIDs{
+				Workspace: workspaceID,
+				Windows:   workspaceWindows[workspaceID],
+			}
+			tree.IndexedWorkspaces[workspaceID] = workspace
+			branch.Workspaces = append(branch.Workspaces, workspace)
+		}
+
+		tree.IndexedMonitors[monitorID] = &aerospace.MonitorWithWorkspaceIDs{
+			Monitor:    monitorID,
+			Workspaces: monitorWorkspaces[monitorID],
+		}
+		tree.Monitors = append(tree.Monitors, branch)
+	}
+
+	return tree
+}
+
+// lastDisplayOf is the display the item is set to by the last batch, empty when no batch sets it.
+func lastDisplayOf(batches Batches, itemID string) string {
+	display := ""
+	for _, batch := range batches {
+		if !slices.Contains(batch, itemID) {
+			continue
+		}
+
+		for _, arg := range batch {
+			if value, found := strings.CutPrefix(arg, "display="); found {
+				display = value
+			}
+		}
+	}
+	return display
+}
+
+func serializeBatches(batches Batches) string {
+	var sb strings.Builder
+	for _, batch := range batches {
+		sb.WriteString(strings.Join(batch, " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// findSetBatch returns the properties of every --set of the item.
+func findSetBatch(batches Batches, itemID string) []string {
+	var properties []string
+	for _, batch := range batches {
+		for i := 0; i+1 < len(batch); i++ {
+			if batch[i] != "--set" || batch[i+1] != itemID {
+				continue
+			}
+
+			for _, arg := range batch[i+2:] {
+				if strings.HasPrefix(arg, "--") {
+					break
+				}
+				properties = append(properties, arg)
+			}
+		}
+	}
+	return properties
+}
+
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0600))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), actual)
+}
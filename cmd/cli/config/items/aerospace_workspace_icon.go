@@ -0,0 +1,48 @@
+package items
+
+import (
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+)
+
+// WorkspaceIconResolver picks the icon of a workspace, the one of its first visible app
+// when the workspace has none and FallbackToFirstAppIcon is on.
+type WorkspaceIconResolver struct {
+	FallbackToFirstAppIcon bool
+	hiddenApps             map[string]bool
+}
+
+func NewWorkspaceIconResolver(fallbackToFirstAppIcon bool, hiddenApps map[string]bool) WorkspaceIconResolver {
+	return WorkspaceIconResolver{fallbackToFirstAppIcon, hiddenApps}
+}
+
+// Resolve is false when the workspace has no icon and no app to fall back to,
+// the font is empty for workspace icons.
+func (r WorkspaceIconResolver) Resolve(
+	workspace *aerospace.WorkspaceWithWindowIDs,
+	windows aerospace.IndexedWindows,
+) (icons.IconInfo, bool) {
+	if icon, found := icons.Workspace[workspace.Workspace]; found {
+		return icons.IconInfo{Icon: icon}, true
+	}
+
+	if !r.FallbackToFirstAppIcon {
+		return icons.IconInfo{}, false
+	}
+
+	for _, windowID := range workspace.Windows {
+		window := windows[windowID]
+		if window == nil || r.hiddenApps[window.App] {
+			continue
+		}
+
+		if iconInfo, found := icons.App[window.App]; found {
+			return iconInfo, true
+		}
+
+		return icons.IconInfo{Icon: icons.Unknown, Font: settings.FontAppIcon}, true
+	}
+
+	return icons.IconInfo{}, false
+}
@@ -0,0 +1,82 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitWorkspaceIconResolver(t *testing.T) {
+	windows := aerospace.IndexedWindows{
+		10: {ID: 10, App: "Finder"},
+		20: {ID: 20, App: "Safari"},
+		30: {ID: 30, App: "Unknown App"},
+	}
+
+	t.Run("should use the workspace icon", func(t *testing.T) {
+		// GIVEN
+		resolver := NewWorkspaceIconResolver(true, nil)
+		workspace := &aerospace.WorkspaceWithWindowIDs{Workspace: "1", Windows: []aerospace.WindowID{20}}
+
+		// WHEN
+		iconInfo, found := resolver.Resolve(workspace, windows)
+
+		// THEN
+		require.True(t, found)
+		require.Equal(t, icons.IconInfo{Icon: icons.Work}, iconInfo)
+	})
+
+	t.Run("should not fall back when disabled", func(t *testing.T) {
+		// GIVEN
+		resolver := NewWorkspaceIconResolver(false, nil)
+		workspace := &aerospace.WorkspaceWithWindowIDs{Workspace: "Z", Windows: []aerospace.WindowID{20}}
+
+		// WHEN
+		_, found := resolver.Resolve(workspace, windows)
+
+		// THEN
+		require.False(t, found)
+	})
+
+	t.Run("should fall back to the first visible app icon", func(t *testing.T) {
+		// GIVEN
+		resolver := NewWorkspaceIconResolver(true, map[string]bool{"Finder": true})
+		workspace := &aerospace.WorkspaceWithWindowIDs{Workspace: "Z", Windows: []aerospace.WindowID{10, 20}}
+
+		// WHEN
+		iconInfo, found := resolver.Resolve(workspace, windows)
+
+		// THEN
+		require.True(t, found)
+		require.Equal(t, icons.App["Safari"], iconInfo)
+	})
+
+	t.Run("should fall back to the unknown icon for apps without icon", func(t *testing.T) {
+		// GIVEN
+		resolver := NewWorkspaceIconResolver(true, nil)
+		workspace := &aerospace.WorkspaceWithWindowIDs{Workspace: "Z", Windows: []aerospace.WindowID{30}}
+
+		// WHEN
+		iconInfo, found := resolver.Resolve(workspace, windows)
+
+		// THEN
+		require.True(t, found)
+		require.Equal(t, icons.IconInfo{Icon: icons.Unknown, Font: settings.FontAppIcon}, iconInfo)
+	})
+
+	t.Run("should not fall back for empty workspaces", func(t *testing.T) {
+		// GIVEN
+		resolver := NewWorkspaceIconResolver(true, nil)
+		workspace := &aerospace.WorkspaceWithWindowIDs{Workspace: "Z"}
+
+		// WHEN
+		_, found := resolver.Resolve(workspace, windows)
+
+		// THEN
+		require.False(t, found)
+	})
+}
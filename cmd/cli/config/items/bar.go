@@ -1,22 +1,22 @@
 package items
 
 import (
-	"bytes"
+	"context"
 	"log/slog"
-	"os/exec"
 	"strings"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 )
 
-func Bar(logger *slog.Logger, batches Batches) (Batches, error) {
+func Bar(ctx context.Context, logger *slog.Logger, aerospace aerospace.Aerospace, batches Batches) (Batches, error) {
 	defer func() {
 		if r := recover(); r != nil {
 			logger.Error("bar: recovered from panic in Bar", slog.Any("panic", r))
 		}
 	}()
-	monitor := getMonitorName(logger)
+	monitor := getMonitorName(ctx, logger, aerospace)
 	left, right := getPaddingForMonitor(monitor)
 
 	bar := sketchybar.BarOptions{
@@ -37,27 +37,41 @@ func Bar(logger *slog.Logger, batches Batches) (Batches, error) {
 		Border: sketchybar.BorderOptions{
 			Width: settings.Sketchybar.BarBorderWidth,
 		},
+		BlurRadius:   settings.Sketchybar.BarBlurRadius,
+		CornerRadius: settings.Sketchybar.BarRadius,
+		Opacity:      settings.Sketchybar.BarOpacity,
+	}
+
+	if err := sketchybar.ValidateBarOptions(bar); err != nil {
+		logger.Warn("bar: invalid bar options", slog.Any("error", err))
 	}
 
 	batches = batch(batches, m(s("--bar"), bar.ToArgs()))
 	return batches, nil
 }
 
-func ShowBar(logger *slog.Logger, batches Batches) (Batches, error) {
+func ShowBar(ctx context.Context, logger *slog.Logger, aerospace aerospace.Aerospace, batches Batches) (Batches, error) {
 	defer func() {
 		if r := recover(); r != nil {
 			logger.Error("bar: recovered from panic in ShowBar", slog.Any("panic", r))
 		}
 	}()
-	monitor := getMonitorName(logger)
+	monitor := getMonitorName(ctx, logger, aerospace)
 	yOffset := getYOffsetForMonitor(monitor)
 
 	bar := sketchybar.BarOptions{
 		YOffset: pointer(yOffset),
 	}
 
-	batches = batch(batches, m(s(
-		"--animate",
+	// the height is set by Bar, it is here only to validate the offset against it
+	if err := sketchybar.ValidateBarOptions(sketchybar.BarOptions{
+		Height:  settings.Sketchybar.BarHeight,
+		YOffset: bar.YOffset,
+	}); err != nil {
+		logger.Warn("bar: invalid bar options", slog.Any("error", err))
+	}
+
+	batches = batch(batches, m(sketchybar.Animate(
 		sketchybar.AnimationTanh,
 		settings.Sketchybar.BarTransitionTime,
 		"--bar",
@@ -66,28 +80,26 @@ func ShowBar(logger *slog.Logger, batches Batches) (Batches, error) {
 	return batches, nil
 }
 
-// getMonitorName returns the name of the first monitor found via `aerospace list-monitors`.
-func getMonitorName(logger *slog.Logger) string {
+// getMonitorName returns the name of the first monitor of aerospace.
+func getMonitorName(ctx context.Context, logger *slog.Logger, aerospace aerospace.Aerospace) string {
 	defer func() {
 		if r := recover(); r != nil {
 			logger.Error("bar: recovered from panic in getMonitorName", slog.Any("panic", r))
 		}
 	}()
-	cmd := exec.Command("aerospace", "list-monitors")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+	if aerospace == nil {
+		return "default"
+	}
+
+	monitors, err := aerospace.ListMonitors(ctx)
 	if err != nil {
 		logger.Error("bar: failed to get monitor name", slog.Any("error", err))
 		return "default"
 	}
 
-	lines := strings.Split(out.String(), "\n")
-	for _, line := range lines {
-		parts := strings.Split(line, "|")
-		if len(parts) >= 2 {
-			monitorName := strings.TrimSpace(parts[1])
-			return monitorName
+	for _, monitor := range monitors {
+		if monitor.Name != "" {
+			return monitor.Name
 		}
 	}
 	return "default"
@@ -103,7 +115,7 @@ func getPaddingForMonitor(name string) (left int, right int) {
 	}
 }
 
-// getYOffsetForMonitor maps monitor names to specific y-offsets.
+// getYOffsetForMonitor maps monitor names to specific y-offsets, positive moves the bar down.
 func getYOffsetForMonitor(name string) int {
 	switch {
 	case strings.Contains(name, "DP2HDMI"):
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,16 +13,25 @@ import (
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/command"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
 )
 
 type BatteryItem struct {
-	logger *slog.Logger
+	logger  *slog.Logger
+	command *command.Command
 }
 
-func NewBatteryItem(logger *slog.Logger) BatteryItem {
-	return BatteryItem{logger}
+func NewBatteryItem(logger *slog.Logger, command *command.Command) BatteryItem {
+	return BatteryItem{logger, command}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(batteryItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewBatteryItem(deps.Logger, deps.Command)
+	})
 }
 
 const batteryItemName = "battery"
@@ -45,31 +53,21 @@ func (i BatteryItem) Init(
 		return batches, nil
 	}
 
-	batteryItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  settings.Sketchybar.ItemSpacing,
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.Battery100,
-			Font: sketchybar.FontOptions{
-				Font: settings.FontIcon,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.IconPadding,
-				Right: pointer(*settings.Sketchybar.IconPadding / 2),
-			},
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Padding: sketchybar.PaddingOptions{
-				Left:  pointer(0),
-				Right: settings.Sketchybar.IconPadding,
-			},
-		},
-		UpdateFreq: pointer(1), // This is for routine updates every 1 seconds
-		Updates:    "on",
-		Script:     updateEvent,
+	batteryItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Battery100, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(1). // This is for routine updates every 1 seconds
+		WithUpdates("on").
+		WithScript(updateEvent).
+		WithClickScript(`open "x-apple.systempreferences:com.apple.preference.battery"`).
+		Build()
+
+	if err != nil {
+		i.logger.Error("battery: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", batteryItemName, position))
@@ -84,7 +82,7 @@ func (i BatteryItem) Init(
 }
 
 func (i BatteryItem) Update(
-	_ context.Context,
+	ctx context.Context,
 	batches Batches,
 	_ sketchybar.Position,
 	args *args.In,
@@ -101,21 +99,25 @@ func (i BatteryItem) Update(
 	// Trigger an update if it's a routine update, a forced update,
 	// or if the power source changed (plugged in/unplugged).
 	if args.Event == events.Routine || args.Event == events.Forced || args.Event == events.PowerSourceChanged {
-		cmd := exec.Command("pmset", "-g", "batt")
-		output, err := cmd.Output()
+		output, stderr, err := i.command.RunBufferizedSplit(ctx, "pmset", "-g", "batt")
 		if err != nil {
-			i.logger.Error("battery: could not get battery info from pmset", slog.Any("error", err))
+			i.logger.Error("battery: could not get battery info from pmset",
+				slog.Any("error", err),
+				slog.String("stderr", strings.TrimSpace(stderr.String())))
 			return batches, nil
 		}
 
-		outputStr := string(output)
-		percentage, state, err := parsePmsetOutput(outputStr)
+		percentage, state, err := parsePmsetOutput(output.String())
 		if err != nil {
 			i.logger.Error("battery: could not parse pmset output", slog.Any("error", err))
 			return batches, nil
 		}
 
-		icon, color := getBatteryStatus(percentage, state)
+		icon, color, labelColor := getBatteryStatus(percentage, state)
+		// pmset is asked for the thermal state only on routine updates, it does not change on power events
+		if args.Event == events.Routine && i.isThermallyThrottled(ctx) {
+			color = colors.Orange
+		}
 
 		batteryItem := sketchybar.ItemOptions{
 			Icon: sketchybar.ItemIconOptions{
@@ -125,11 +127,23 @@ func (i BatteryItem) Update(
 				},
 			},
 			Label: sketchybar.ItemLabelOptions{
-				Value: fmt.Sprintf("%.0f%%", percentage),
+				Value: batteryLabel(percentage),
+				Color: sketchybar.ColorOptions{
+					Color: labelColor,
+				},
 			},
 		}
 
-		batches = batch(batches, m(s("--set", batteryItemName), batteryItem.ToArgs()))
+		setBattery := s("--set", batteryItemName)
+		// battery is not animated unless configured
+		if _, found := settings.Sketchybar.Animations[batteryItemName]; found {
+			setBattery = settings.Sketchybar.Animation(batteryItemName, settings.AnimationConfig{
+				Easing: sketchybar.AnimationTanh,
+				Time:   "15",
+			}).Animate(setBattery...)
+		}
+
+		batches = batch(batches, m(setBattery, batteryItem.ToArgs()))
 	}
 
 	return batches, nil
@@ -139,30 +153,96 @@ func isBattery(name string) bool {
 	return name == batteryItemName
 }
 
-func getBatteryStatus(percentage float64, state string) (string, string) {
+// getBatteryStatus returns the icon, the icon color and the label color.
+func getBatteryStatus(percentage float64, state string) (string, string, string) {
+	batterySettings := settings.Sketchybar.Battery
+
 	// If the battery is actively charging, or is idle (plugged in and maintaining charge),
 	// or is full (implies plugged in and at 100%).
 	// This covers scenarios where the battery is connected to power.
 	if state == "charging" || state == "charged" || state == "AC Power" {
-		return icons.BatteryCharging, colors.Battery1 // Show charging icon
+		return icons.BatteryCharging, colors.Battery1, batteryLabelColor(batterySettings.Level1Color) // Show charging icon
 	}
 
 	// If not in a "plugged-in" state, determine icon based on percentage (discharging)
 	switch {
 	case percentage >= 80 && percentage <= 100:
-		return icons.Battery100, colors.Battery1
+		return icons.Battery100, batteryIconColor(percentage), batteryLabelColor(batterySettings.Level1Color)
 	case percentage >= 70 && percentage < 80:
-		return icons.Battery75, colors.Battery2
+		return icons.Battery75, batteryIconColor(percentage), batteryLabelColor(batterySettings.Level2Color)
 	case percentage >= 40 && percentage < 70:
-		return icons.Battery50, colors.Battery3
+		return icons.Battery50, batteryIconColor(percentage), batteryLabelColor(batterySettings.Level3Color)
 	case percentage >= 10 && percentage < 40:
-		return icons.Battery25, colors.Battery4
+		return icons.Battery25, batteryIconColor(percentage), batteryLabelColor(batterySettings.Level4Color)
 	case percentage >= 0 && percentage < 10:
-		return icons.Battery0, colors.Battery5
+		return icons.Battery0, batteryIconColor(percentage), batteryLabelColor(batterySettings.Level5Color)
 	default:
 		// Fallback for unexpected percentages, though ideally percentages should be within 0-100
-		return "", ""
+		return "", "", ""
+	}
+}
+
+// batteryIconColor fades from Battery5 when empty to Battery1 when full.
+func batteryIconColor(percentage float64) string {
+	color, err := colors.Lerp(colors.Battery5, colors.Battery1, percentage/100)
+	if err != nil {
+		return colors.Battery3
+	}
+	return color
+}
+
+// batteryLabelColor keeps the label color of the bar when a level has no color,
+// otherwise the color of the previous level would stay.
+func batteryLabelColor(levelColor string) string {
+	if levelColor == "" {
+		return settings.Sketchybar.LabelColor
 	}
+	return levelColor
+}
+
+func batteryLabel(percentage float64) string {
+	if settings.Sketchybar.Battery.Style == settings.BatteryStyleProgressBar {
+		return ProgressBarItem{Value: percentage / 100}.Render()
+	}
+
+	return fmt.Sprintf("%.0f%%", percentage)
+}
+
+// isThermallyThrottled is false when pmset does not report a speed limit, like on Apple Silicon.
+func (i BatteryItem) isThermallyThrottled(ctx context.Context) bool {
+	output, stderr, err := i.command.RunBufferizedSplit(ctx, "pmset", "-g", "therm")
+	if err != nil {
+		i.logger.Error("battery: could not get thermal state from pmset",
+			slog.Any("error", err),
+			slog.String("stderr", strings.TrimSpace(stderr.String())))
+		return false
+	}
+
+	speedLimit, err := parseThermalState(output.String())
+	if err != nil {
+		i.logger.Debug("battery: no thermal state in pmset output", slog.Any("error", err))
+		return false
+	}
+
+	return speedLimit < 100
+}
+
+// parseThermalState returns the CPU_Speed_Limit percentage, 100 when the CPU is not throttled.
+func parseThermalState(output string) (int, error) {
+	// Example: '	CPU_Speed_Limit 	= 100'
+	speedLimitRegex := regexp.MustCompile(`CPU_Speed_Limit\s*=\s*(\d+)`)
+
+	speedLimitMatch := speedLimitRegex.FindStringSubmatch(output)
+	if len(speedLimitMatch) < 2 {
+		return 0, errors.New("could not find CPU_Speed_Limit in pmset output")
+	}
+
+	speedLimit, err := strconv.Atoi(speedLimitMatch[1])
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse CPU_Speed_Limit: %w", err)
+	}
+
+	return speedLimit, nil
 }
 
 func parsePmsetOutput(output string) (float64, string, error) {
@@ -170,8 +250,10 @@ func parsePmsetOutput(output string) (float64, string, error) {
 	// Example: ' 90%; discharging; 4:00 remaining'
 	// Example: '100%; charged; 0:00 remaining present: true'
 	// Example: 'Now drawing from 'AC Power''
+	// The state is whatever follows the percentage, up to the next ';' or the end of the line,
+	// since pmset omits the trailing ';' when there is no remaining time to report.
 	percentageRegex := regexp.MustCompile(`(\d+)%;`)
-	stateRegex := regexp.MustCompile(`;\s*([^;]+);`)
+	stateRegex := regexp.MustCompile(`\d+%;\s*([^;\n]+)`)
 
 	percentageMatch := percentageRegex.FindStringSubmatch(output)
 	stateMatch := stateRegex.FindStringSubmatch(output)
@@ -184,6 +266,9 @@ func parsePmsetOutput(output string) (float64, string, error) {
 		if err != nil {
 			return 0, "", fmt.Errorf("failed to parse percentage: %w", err)
 		}
+		if p > 100 {
+			return 0, "", fmt.Errorf("battery percentage out of range: %.0f", p)
+		}
 		percentage = p
 	}
 
@@ -220,4 +305,4 @@ var _ WentsketchyItem = (*BatteryItem)(nil)
 func pointer[T any](val T) *T {
     return &val
 }
-*/
\ No newline at end of file
+*/
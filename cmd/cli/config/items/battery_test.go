@@ -0,0 +1,144 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBattery(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		percentage float64
+		state      string
+		err        bool
+	}{
+		{
+			name:       "should parse charged without remaining time",
+			output:     "100%; charged",
+			percentage: 100,
+			state:      "charged",
+		},
+		{
+			name:       "should parse discharging with remaining time",
+			output:     "90%; discharging; 3:45 remaining",
+			percentage: 90,
+			state:      "discharging",
+		},
+		{
+			name:       "should parse ac power without percentage",
+			output:     "Now drawing from 'AC Power'",
+			percentage: 100,
+			state:      "AC Power",
+		},
+		{
+			name:       "should parse empty battery without estimate",
+			output:     "0%; discharging; (no estimate)",
+			percentage: 0,
+			state:      "discharging",
+		},
+		{
+			name: "should parse full pmset output",
+			output: "Now drawing from 'Battery Power'\n" +
+				" -InternalBattery-0 (id=4653155)\t76%; discharging; 5:12 remaining present: true",
+			percentage: 76,
+			state:      "discharging",
+		},
+		{
+			name:   "should fail on empty output",
+			output: "",
+			err:    true,
+		},
+		{
+			name:   "should fail on unrelated output",
+			output: "No batteries available",
+			err:    true,
+		},
+		{
+			name:   "should fail on state without percentage",
+			output: "abc%; charging",
+			err:    true,
+		},
+		{
+			name:   "should fail on percentage out of range",
+			output: "150%; charging",
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// WHEN
+			percentage, state, err := parsePmsetOutput(test.output)
+
+			// THEN
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.InDelta(t, test.percentage, percentage, 0.001)
+			require.Equal(t, test.state, state)
+		})
+	}
+}
+
+func TestUnitBatteryThermal(t *testing.T) {
+	t.Run("should parse the speed limit of a throttled cpu", func(t *testing.T) {
+		// GIVEN
+		output := "Note: No thermal warning level has been recorded\n" +
+			"2024-03-02 10:11:12 +0100 CPU Power notify\n" +
+			"\tCPU_Scheduler_Limit \t= 100\n" +
+			"\tCPU_Available_CPUs \t= 8\n" +
+			"\tCPU_Speed_Limit \t= 72\n"
+
+		// WHEN
+		speedLimit, err := parseThermalState(output)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 72, speedLimit)
+	})
+
+	t.Run("should fail when pmset does not report a speed limit", func(t *testing.T) {
+		// GIVEN
+		output := "Note: No thermal warning level has been recorded\n" +
+			"Note: No performance warning level has been recorded\n"
+
+		// WHEN
+		_, err := parseThermalState(output)
+
+		// THEN
+		require.Error(t, err)
+	})
+}
+
+func TestUnitBatteryLabelColor(t *testing.T) {
+	t.Run("should use the configured color of the battery level", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Battery = settings.BatterySettings{Level5Color: "0xfff44336"}
+		t.Cleanup(func() { settings.Sketchybar.Battery = settings.BatterySettings{} })
+
+		// WHEN
+		_, _, labelColor := getBatteryStatus(5, "discharging")
+
+		// THEN
+		require.Equal(t, "0xfff44336", labelColor)
+	})
+
+	t.Run("should keep the label color when the level has no color", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Battery = settings.BatterySettings{Level5Color: "0xfff44336"}
+		t.Cleanup(func() { settings.Sketchybar.Battery = settings.BatterySettings{} })
+
+		// WHEN
+		_, _, labelColor := getBatteryStatus(90, "discharging")
+
+		// THEN
+		require.Equal(t, settings.Sketchybar.LabelColor, labelColor)
+	})
+}
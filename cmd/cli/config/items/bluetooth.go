@@ -23,6 +23,13 @@ func NewBluetoothItem(logger *slog.Logger, command *command.Command) BluetoothIt
 	return BluetoothItem{logger, command}
 }
 
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(bluetoothItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewBluetoothItem(deps.Logger, deps.Command)
+	})
+}
+
 const bluetoothItemName = "bluetooth"
 
 func (i BluetoothItem) Init(
@@ -35,7 +42,7 @@ func (i BluetoothItem) Init(
 			i.logger.Error("bluetooth: recovered from panic in Init", slog.Any("panic", r))
 		}
 	}()
-	
+
 	// Create a simple shell script for updates instead of relying on args.BuildEvent()
 	updateScript := `#!/bin/bash
 # Try different paths for blueutil
@@ -57,33 +64,22 @@ else
     sketchybar --set "$NAME" label="Off" icon="` + icons.BluetoothOff + `" icon.color="` + colors.White + `"
 fi`
 
-	bluetoothItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  settings.Sketchybar.ItemSpacing,
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.Bluetooth,
-			Font: sketchybar.FontOptions{
-				Font: settings.FontIcon,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.IconPadding,
-				Right: pointer(*settings.Sketchybar.IconPadding / 2),
-			},
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Value: "Loading...",
-			Padding: sketchybar.PaddingOptions{
-				Left:  pointer(0),
-				Right: settings.Sketchybar.IconPadding,
-			},
-		},
-		UpdateFreq:  pointer(5), // Check every 5 seconds
-		Updates:     "on",
-		Script:      updateScript, // Use inline script instead of args.BuildEvent()
-		ClickScript: "blueutil -p toggle; sleep 0.2; sketchybar --trigger bluetooth_change",
+	bluetoothItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Bluetooth, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabel("Loading...").
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(5). // Check every 5 seconds
+		WithUpdates("on").
+		WithScript(updateScript). // Use inline script instead of args.BuildEvent()
+		WithClickScript("blueutil -p toggle; sleep 0.2; sketchybar --trigger bluetooth_change").
+		Build()
+
+	if err != nil {
+		i.logger.Error("bluetooth: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", bluetoothItemName, position))
@@ -106,7 +102,7 @@ func (i BluetoothItem) Update(
 			i.logger.ErrorContext(ctx, "bluetooth: recovered from panic in Update", slog.Any("panic", r))
 		}
 	}()
-	
+
 	if !isBluetooth(args.Name) {
 		return batches, nil
 	}
@@ -114,17 +110,7 @@ func (i BluetoothItem) Update(
 	// Handle custom events like bluetooth_change or system_woke
 	if args.Event == "bluetooth_change" || args.Event == events.SystemWoke {
 		// Trigger the update script manually
-		var output string
-		var err error
-		
-		// Try multiple command paths
-		paths := []string{"blueutil", "/usr/local/bin/blueutil", "/opt/homebrew/bin/blueutil"}
-		for _, path := range paths {
-			output, err = i.command.Run(ctx, path, "-p")
-			if err == nil {
-				break
-			}
-		}
+		output, err := i.command.Run(ctx, command.ResolveExecutable("blueutil"), "-p")
 
 		var label, color, icon string
 		if err != nil {
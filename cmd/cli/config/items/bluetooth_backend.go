@@ -0,0 +1,87 @@
+package items
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lucax88x/wentsketchy/internal/command"
+)
+
+type bluetoothBackend interface {
+	PowerState(ctx context.Context) (bool, error)
+}
+
+// BlueutilBackend asks blueutil, which answers faster than system_profiler.
+type BlueutilBackend struct {
+	command *command.Command
+	path    string
+}
+
+func (b BlueutilBackend) PowerState(ctx context.Context) (bool, error) {
+	output, err := b.command.Run(ctx, b.path, "-p")
+
+	if err != nil {
+		return false, fmt.Errorf("bluetooth: could not run blueutil. %w", err)
+	}
+
+	return strings.TrimSpace(output) == "1", nil
+}
+
+// SystemProfilerBackend is used when blueutil is not installed.
+type SystemProfilerBackend struct {
+	command *command.Command
+}
+
+func (b SystemProfilerBackend) PowerState(ctx context.Context) (bool, error) {
+	output, err := b.command.Run(ctx, "system_profiler", "SPBluetoothDataType", "-json")
+
+	if err != nil {
+		return false, fmt.Errorf("bluetooth: could not run system_profiler. %w", err)
+	}
+
+	return parseSystemProfilerBluetooth(output)
+}
+
+func newBluetoothBackend(cmd *command.Command) bluetoothBackend {
+	if path, found := command.LookupExecutable("blueutil"); found {
+		return BlueutilBackend{cmd, path}
+	}
+
+	return SystemProfilerBackend{cmd}
+}
+
+type systemProfilerBluetooth struct {
+	SPBluetoothDataType []struct {
+		ControllerProperties struct {
+			State string `json:"controller_state"`
+		} `json:"controller_properties"`
+		// LocalDevice is how macos before 12 reports the controller.
+		LocalDevice struct {
+			Power string `json:"general_power"`
+		} `json:"local_device_title"`
+	} `json:"SPBluetoothDataType"`
+}
+
+func parseSystemProfilerBluetooth(output string) (bool, error) {
+	var data systemProfilerBluetooth
+
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
+		return false, fmt.Errorf("bluetooth: could not deserialize system_profiler output. %w", err)
+	}
+
+	for _, controller := range data.SPBluetoothDataType {
+		state := controller.ControllerProperties.State
+		if state == "" {
+			state = controller.LocalDevice.Power
+		}
+
+		if state != "" {
+			return state == "attrib_on", nil
+		}
+	}
+
+	return false, errors.New("bluetooth: no controller in system_profiler output")
+}
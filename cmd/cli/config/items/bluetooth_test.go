@@ -0,0 +1,43 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBluetooth(t *testing.T) {
+	t.Run("should parse powered on controller of system_profiler", func(t *testing.T) {
+		// GIVEN
+		output := `{"SPBluetoothDataType":[{"controller_properties":{"controller_address":"00:00:00:00:00:01",` +
+			`"controller_state":"attrib_on"}}]}`
+
+		// WHEN
+		on, err := parseSystemProfilerBluetooth(output)
+
+		// THEN
+		require.NoError(t, err)
+		require.True(t, on)
+	})
+
+	t.Run("should parse powered off controller of older macos", func(t *testing.T) {
+		// GIVEN
+		output := `{"SPBluetoothDataType":[{"local_device_title":{"general_power":"attrib_off"}}]}`
+
+		// WHEN
+		on, err := parseSystemProfilerBluetooth(output)
+
+		// THEN
+		require.NoError(t, err)
+		require.False(t, on)
+	})
+
+	t.Run("should fail without controller", func(t *testing.T) {
+		// WHEN
+		_, err := parseSystemProfilerBluetooth(`{"SPBluetoothDataType":[]}`)
+
+		// THEN
+		require.Error(t, err)
+	})
+}
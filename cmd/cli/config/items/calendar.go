@@ -2,30 +2,43 @@ package items
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
-	"fmt"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
-	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
-	// "github.com/lucax88x/wentsketchy/internal/formatter"
+	"github.com/lucax88x/wentsketchy/internal/clock"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
 )
-  
+
 type CalendarItem struct {
 	logger *slog.Logger
+	clock  clock.Clock
 }
 
-func NewCalendarItem(logger *slog.Logger) CalendarItem {
-	return CalendarItem{logger}
+func NewCalendarItem(logger *slog.Logger, clock clock.Clock) CalendarItem {
+	return CalendarItem{logger, clock}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(calendarItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewCalendarItem(deps.Logger, deps.Clock)
+	})
 }
 
 const calendarItemName = "calendar"
 
+// calendar label formats, the inline script uses the equivalent `date` formats.
+const calendarFormat12h = "Jan 2 3:04 PM"
+const calendarFormat24h = "Jan 2 15:04"
+const calendarDateFormat12h = "+%b %e %l:%M %p"
+const calendarDateFormat24h = "+%b %e %H:%M"
+
 func (i CalendarItem) Init(
-	ctx context.Context,
+	_ context.Context,
 	position sketchybar.Position,
 	batches Batches,
 ) (Batches, error) {
@@ -34,35 +47,32 @@ func (i CalendarItem) Init(
 			i.logger.Error("calendar: recovered from panic in Init", slog.Any("panic", r))
 		}
 	}()
-	
+
+	dateFormat := calendarDateFormat12h
+	if settings.Sketchybar.Calendar.Use24Hour {
+		dateFormat = calendarDateFormat24h
+	}
+
 	// Use a simple shell script that updates the time directly
-	updateScript := `#!/bin/bash
-TIME=$(date "+%b %e %l:%M %p" | sed -e 's/  / /g')
-sketchybar --set "$NAME" label="$TIME"`
-
-	calendarItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  settings.Sketchybar.ItemSpacing,
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.None,
-			Padding: sketchybar.PaddingOptions{
-				Left:  pointer(*settings.Sketchybar.IconPadding / 2),
-				Right: pointer(*settings.Sketchybar.IconPadding / 2),
-			},
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Value: "Loading...",
-			Padding: sketchybar.PaddingOptions{
-				Left:  pointer(0),
-				Right: settings.Sketchybar.IconPadding,
-			},
-		},
-		UpdateFreq: pointer(1), // Update every minute
-		Updates:    "on",
-		Script:     updateScript, // Use inline script for time updates
+	updateScript := fmt.Sprintf(`#!/bin/bash
+TIME=$(date "%s" | sed -e 's/  / /g')
+sketchybar --set "$NAME" label="$TIME"`, dateFormat)
+
+	calendarItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIconDrawing("off").
+		WithIconPadding(pointer(*settings.Sketchybar.IconPadding/2), pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabel("Loading...").
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(1). // Update every minute
+		WithUpdates("on").
+		WithScript(updateScript). // Use inline script for time updates
+		Build()
+
+	if err != nil {
+		i.logger.Error("calendar: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", calendarItemName, position))
@@ -84,27 +94,20 @@ func (i CalendarItem) Update(
 			i.logger.ErrorContext(ctx, "calendar: recovered from panic in Update", slog.Any("panic", r))
 		}
 	}()
-	
+
 	if !isCalendar(args.Name) {
 		return batches, nil
 	}
 
 	if args.Event == events.SystemWoke {
-		now := time.Now()
-		hour := now.Hour() % 12
-		if hour == 0 {
-			hour = 12
-		}
-		formattedTime := fmt.Sprintf("%s %d:%02d %s", now.Format("Jan 2"), hour, now.Minute(), now.Format("PM"))
-	
 		calendarItem := sketchybar.ItemOptions{
 			Label: sketchybar.ItemLabelOptions{
-				Value: formattedTime,
+				Value: formatCalendarTime(i.clock.Now(), settings.Sketchybar.Calendar.Use24Hour),
 			},
 		}
-	
+
 		batches = batch(batches, m(s("--set", calendarItemName), calendarItem.ToArgs()))
-	}	
+	}
 
 	return batches, nil
 }
@@ -113,4 +116,12 @@ func isCalendar(name string) bool {
 	return name == calendarItemName
 }
 
-var _ WentsketchyItem = (*CalendarItem)(nil)
\ No newline at end of file
+func formatCalendarTime(now time.Time, use24Hour bool) string {
+	if use24Hour {
+		return now.Format(calendarFormat24h)
+	}
+
+	return now.Format(calendarFormat12h)
+}
+
+var _ WentsketchyItem = (*CalendarItem)(nil)
@@ -0,0 +1,68 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/lucax88x/wentsketchy/testutils/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCalendar(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+	clock := &fake.Clock{Time: time.Date(2024, time.March, 15, 14, 32, 0, 0, time.UTC)}
+	item := NewCalendarItem(logger, clock)
+	wake := &args.In{Name: calendarItemName, Event: events.SystemWoke}
+
+	t.Run("should init the calendar without icon", func(t *testing.T) {
+		// WHEN
+		batches, err := item.Init(ctx, sketchybar.PositionRight, Batches{})
+
+		// THEN
+		require.NoError(t, err)
+		require.Contains(t, batches, []string{"--add", "item", calendarItemName, "right"})
+		require.Contains(t, batches[1], "icon.drawing=off")
+	})
+
+	t.Run("should format label in 12h mode", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Calendar.Use24Hour = false
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, "right", wake)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, Batches{{"--set", calendarItemName, "label=Mar 15 2:32 PM"}}, batches)
+	})
+
+	t.Run("should format label in 24h mode", func(t *testing.T) {
+		// GIVEN
+		settings.Sketchybar.Calendar.Use24Hour = true
+		t.Cleanup(func() { settings.Sketchybar.Calendar.Use24Hour = false })
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, "right", wake)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, Batches{{"--set", calendarItemName, "label=Mar 15 14:32"}}, batches)
+	})
+
+	t.Run("should ignore other items", func(t *testing.T) {
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, "right", &args.In{Name: "battery", Event: events.SystemWoke})
+
+		// THEN
+		require.NoError(t, err)
+		require.Empty(t, batches)
+	})
+}
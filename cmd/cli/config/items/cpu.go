@@ -21,17 +21,29 @@ import (
 )
 
 type CPUItem struct {
-	logger  *slog.Logger
-	command *command.Command
+	logger    *slog.Logger
+	command   *command.Command
+	sparkline *SparklineWidget
 }
 
 func NewCPUItem(logger *slog.Logger, command *command.Command) CPUItem {
+	sparkline := NewSparklineWidget(settings.Sketchybar.SparklineSize)
+	sparkline.Max = 100
+
 	return CPUItem{
 		logger,
 		command,
+		sparkline,
 	}
 }
 
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem("cpu", func(deps ItemDeps) WentsketchyItem {
+		return NewCPUItem(deps.Logger, deps.Command)
+	})
+}
+
 const cpuBracketName = "cpu.bracket"
 const cpuItemIconName = "cpu.icon"
 const cpuItemTopName = "cpu.top"
@@ -57,64 +69,49 @@ func (i CPUItem) Init(
 		return batches, nil
 	}
 
-	cpuIconItem := sketchybar.ItemOptions{
-		Display: "active",
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.CPU,
-			Font: sketchybar.FontOptions{
-				Font: settings.FontIcon,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.IconPadding,
-				Right: pointer(*settings.Sketchybar.IconPadding / 2),
-			},
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
+	cpuIconItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithIcon(icons.CPU, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+
+	if err != nil {
+		i.logger.Error("cpu: could not build icon item", slog.Any("error", err))
+		return batches, nil
 	}
 
-	cpuTopItem := sketchybar.ItemOptions{
-		Display: "active",
-		Label: sketchybar.ItemLabelOptions{
-			Value: "",
-			Font: sketchybar.FontOptions{
-				Size: "8.0",
-			},
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Drawing: "off",
-		},
-		Padding: sketchybar.PaddingOptions{
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		YOffset: pointer(4),
-		Width:   pointer(0),
+	cpuTopItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithLabelFont(sketchybar.FontOptions{Size: "8.0"}).
+		WithIconDrawing("off").
+		WithPadding(nil, settings.Sketchybar.ItemSpacing).
+		WithYOffset(4).
+		WithWidth(0).
+		Build()
+
+	if err != nil {
+		i.logger.Error("cpu: could not build top item", slog.Any("error", err))
+		return batches, nil
 	}
-	cpuPercentItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  pointer(10),
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Value: "",
-			Font: sketchybar.FontOptions{
-				Size: "8.0",
-			},
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Drawing: "off",
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
-		YOffset: pointer(-6),
-		// Width:      pointer(0),
-		UpdateFreq: pointer(4),
-		Updates:    "on",
-		Script:     updateEvent,
+
+	cpuPercentItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(pointer(10), settings.Sketchybar.ItemSpacing).
+		WithLabelFont(sketchybar.FontOptions{Size: "8.0"}).
+		WithIconDrawing("off").
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		WithYOffset(-6).
+		WithUpdateFreq(4).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		Build()
+
+	if err != nil {
+		i.logger.Error("cpu: could not build percent item", slog.Any("error", err))
+		return batches, nil
 	}
+
 	cpuSysItem := sketchybar.GraphOptions{
 		Display: "active",
 		Width:   pointer(75),
@@ -152,25 +149,29 @@ func (i CPUItem) Init(
 			Height:  pointer(0),
 		},
 	}
-	cpuBracketItem := sketchybar.BracketOptions{
-		Background: sketchybar.BackgroundOptions{
+	cpuBracketItem := sketchybar.BracketOptions{}.
+		WithMembers(
+			cpuItemIconName,
+			cpuItemTopName,
+			cpuItemPercentName,
+			cpuItemSysName,
+			cpuItemUserName,
+		).
+		WithBackground(sketchybar.BackgroundOptions{
 			Drawing: "on",
 			Color: sketchybar.ColorOptions{
 				Color: settings.Sketchybar.ItemBackgroundColor,
 			},
-		},
-	}
-	cpuSpacerItem := sketchybar.ItemOptions{
-		Display: "active",
-		Label: sketchybar.ItemLabelOptions{
-			Value: "",
-		},
-		Padding: sketchybar.PaddingOptions{
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
+		})
+	cpuSpacerItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(nil, settings.Sketchybar.ItemSpacing).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+
+	if err != nil {
+		i.logger.Error("cpu: could not build spacer item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", cpuItemSpacerName, position))
@@ -191,17 +192,7 @@ func (i CPUItem) Init(
 	batches = batch(batches, s("--add", "item", cpuItemIconName, position))
 	batches = batch(batches, m(s("--set", cpuItemIconName), cpuIconItem.ToArgs()))
 
-	batches = batch(batches, s(
-		"--add",
-		"bracket",
-		cpuBracketName,
-		cpuItemIconName,
-		cpuItemTopName,
-		cpuItemPercentName,
-		cpuItemSysName,
-		cpuItemUserName,
-	))
-	batches = batch(batches, m(s("--set", cpuBracketName), cpuBracketItem.ToArgs()))
+	batches = batch(batches, cpuBracketItem.Build(cpuBracketName))
 
 	return batches, nil
 }
@@ -241,9 +232,11 @@ func (i CPUItem) Update(
 				Value: fmt.Sprintf("%.2f%% %s", topProcess.cpu, truncateString(topProcess.name, 8)),
 			},
 		}
+		i.sparkline.Append(float64(cpuLoad.sys + cpuLoad.user))
+
 		cpuPercentItem := sketchybar.ItemOptions{
 			Label: sketchybar.ItemLabelOptions{
-				Value: fmt.Sprintf("%.2f%%", cpuLoad.sys+cpuLoad.user),
+				Value: fmt.Sprintf("%.2f%% %s", cpuLoad.sys+cpuLoad.user, i.sparkline.Render()),
 			},
 		}
 
@@ -257,6 +250,18 @@ func (i CPUItem) Update(
 	return batches, nil
 }
 
+func (i CPUItem) SketchybarItems() []string {
+	return []string{
+		cpuBracketName,
+		cpuItemIconName,
+		cpuItemTopName,
+		cpuItemPercentName,
+		cpuItemSysName,
+		cpuItemUserName,
+		cpuItemSpacerName,
+	}
+}
+
 func isCPU(name string) bool {
 	return name == cpuItemPercentName
 }
@@ -395,3 +400,4 @@ func truncateString(s string, maxLen int) string {
 }
 
 var _ WentsketchyItem = (*CPUItem)(nil)
+var _ SketchybarItemsOwner = (*CPUItem)(nil)
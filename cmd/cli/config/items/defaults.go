@@ -5,7 +5,7 @@ import (
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 )
 
-func Defaults(batches [][]string) ([][]string, error) {
+func Defaults(batches Batches) (Batches, error) {
 	defaults := sketchybar.ItemOptions{
 		YOffset: pointer(0),
 		Padding: sketchybar.PaddingOptions{
@@ -43,6 +43,9 @@ func Defaults(batches [][]string) ([][]string, error) {
 			Color: sketchybar.ColorOptions{
 				Color: settings.Sketchybar.ItemBackgroundColor,
 			},
+			Color2: sketchybar.ColorOptions{
+				Color: settings.Sketchybar.ItemBackgroundColor2,
+			},
 			Border: sketchybar.BorderOptions{
 				Color: settings.Sketchybar.ItemBorderColor,
 				Width: settings.Sketchybar.ItemBorderWidth,
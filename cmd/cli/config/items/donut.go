@@ -0,0 +1,21 @@
+package items
+
+import "math"
+
+//nolint:gochecknoglobals // ok
+var donutQuarters = []rune("◴◷◶◵")
+
+// DonutWidget is not an item on its own, it draws a percentage as a single pie character.
+type DonutWidget struct{}
+
+// Render is ◌ at 0%, ● at 100% and one of ◴◷◶◵ for each quarter started in between.
+func (DonutWidget) Render(percent float64) rune {
+	switch {
+	case percent <= 0:
+		return '◌'
+	case percent >= 100:
+		return '●'
+	default:
+		return donutQuarters[int(math.Ceil(percent/25))-1]
+	}
+}
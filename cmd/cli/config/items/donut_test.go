@@ -0,0 +1,24 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitDonut(t *testing.T) {
+	t.Run("should map the percentage to its quarter", func(t *testing.T) {
+		// GIVEN
+		donut := DonutWidget{}
+
+		// THEN
+		require.Equal(t, '◌', donut.Render(0))
+		require.Equal(t, '◴', donut.Render(10))
+		require.Equal(t, '◴', donut.Render(25))
+		require.Equal(t, '◷', donut.Render(26))
+		require.Equal(t, '◶', donut.Render(62))
+		require.Equal(t, '◵', donut.Render(99.9))
+		require.Equal(t, '●', donut.Render(100))
+	})
+}
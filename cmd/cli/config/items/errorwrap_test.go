@@ -0,0 +1,36 @@
+package items_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/lint/errorwrap"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// TestIntegrationErrorWrap type checks the package and its dependencies with go list.
+func TestIntegrationErrorWrap(t *testing.T) {
+	t.Run("should wrap every error of fmt.Errorf with %w", func(t *testing.T) {
+		// GIVEN
+		pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax}, ".")
+		require.NoError(t, err)
+
+		// WHEN
+		graph, err := checker.Analyze([]*analysis.Analyzer{errorwrap.Analyzer}, pkgs, nil)
+
+		// THEN
+		require.NoError(t, err)
+		for action := range graph.All() {
+			if !action.IsRoot {
+				continue
+			}
+
+			require.NoError(t, action.Err)
+			for _, diagnostic := range action.Diagnostics {
+				t.Errorf("%s: %s", action.Package.Fset.Position(diagnostic.Pos), diagnostic.Message)
+			}
+		}
+	})
+}
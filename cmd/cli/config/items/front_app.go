@@ -4,19 +4,30 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/command"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
 )
 
 type FrontAppItem struct {
-	logger *slog.Logger
+	logger  *slog.Logger
+	command *command.Command
 }
 
-func NewFrontAppItem(logger *slog.Logger) FrontAppItem {
-	return FrontAppItem{logger}
+func NewFrontAppItem(logger *slog.Logger, command *command.Command) FrontAppItem {
+	return FrontAppItem{logger, command}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(frontAppItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewFrontAppItem(deps.Logger, deps.Command)
+	})
 }
 
 const frontAppItemName = "front_app"
@@ -38,33 +49,28 @@ func (i FrontAppItem) Init(
 		return batches, nil
 	}
 
-	frontAppItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  settings.Sketchybar.ItemSpacing,
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Background: sketchybar.BackgroundOptions{
+	frontAppItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIconBackground(sketchybar.BackgroundOptions{
+			Drawing: "on",
+			Image: sketchybar.ImageOptions{
 				Drawing: "on",
-				Image: sketchybar.ImageOptions{
-					Drawing: "on",
-					Padding: sketchybar.PaddingOptions{
-						Left:  settings.Sketchybar.IconPadding,
-						Right: pointer(*settings.Sketchybar.IconPadding / 2),
-					},
+				Padding: sketchybar.PaddingOptions{
+					Left:  settings.Sketchybar.IconPadding,
+					Right: pointer(*settings.Sketchybar.IconPadding / 2),
 				},
 			},
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Padding: sketchybar.PaddingOptions{
-				Left:  pointer(0),
-				Right: settings.Sketchybar.IconPadding,
-			},
-		},
-		Updates:     "on",
-		Script:      updateEvent,
-		ClickScript: "open -a 'Mission Control'",
+		}).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		WithClickScript("open -a 'Mission Control'").
+		Build()
+
+	if err != nil {
+		i.logger.Error("front_app: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", frontAppItemName, position))
@@ -97,7 +103,7 @@ func (i FrontAppItem) Update(
 			Icon: sketchybar.ItemIconOptions{
 				Background: sketchybar.BackgroundOptions{
 					Image: sketchybar.ImageOptions{
-						Value: fmt.Sprintf("app.%s", args.Info),
+						Value: i.appImage(ctx, args.Info),
 						Scale: "0.8",
 					},
 				},
@@ -110,6 +116,21 @@ func (i FrontAppItem) Update(
 	return batches, nil
 }
 
+// appImage is the icon sketchybar knows for the app, unless the app is configured to be looked up.
+func (i FrontAppItem) appImage(ctx context.Context, app string) string {
+	if slices.Contains(settings.Sketchybar.FrontApp.IconLookupApps, app) {
+		path, err := icons.AppIconPath(ctx, i.command, app)
+
+		if err == nil {
+			return path
+		}
+
+		i.logger.WarnContext(ctx, "front_app: could not look up app icon", slog.String("app", app), slog.Any("error", err))
+	}
+
+	return fmt.Sprintf("app.%s", app)
+}
+
 func isFrontApp(name string) bool {
 	return name == frontAppItemName
 }
@@ -1,6 +1,8 @@
 package items
 
-type Batches = [][]string
+import "github.com/lucax88x/wentsketchy/internal/sketchybar"
+
+type Batches = sketchybar.Batches
 
 func batch(arr Batches, args []string) Batches {
 	return append(arr, args)
@@ -14,8 +16,6 @@ func m(left []string, right []string) []string {
 	return append(left, right...)
 }
 
-
-
 func Flatten(slices ...[]string) []string {
 	result := []string{}
 	for _, slice := range slices {
@@ -21,19 +21,29 @@ type WentsketchyItem interface {
 	) (Batches, error)
 }
 
-type IndexedWentsketchyItems = map[string]WentsketchyItem
+// UpdateGrouper is implemented by the items which share state with other items,
+// the items of the same group are never updated concurrently when updates are parallel.
+type UpdateGrouper interface {
+	UpdateGroup() string
+}
+
+// SketchybarItemsOwner is implemented by the items whose sketchybar items are not named after them,
+// the others own the single sketchybar item of their name.
+type SketchybarItemsOwner interface {
+	// SketchybarItems are empty when the sketchybar items come and go, like the aerospace windows.
+	SketchybarItems() []string
+}
+
+// SketchybarItemsOf are the sketchybar items of itemName, the name of item in config.yaml.
+func SketchybarItemsOf(itemName string, item WentsketchyItem) []string {
+	if owner, ok := item.(SketchybarItemsOwner); ok {
+		return owner.SketchybarItems()
+	}
 
-type WentsketchyItems struct {
-	MainIcon    MainIconItem
-	Calendar    CalendarItem
-	FrontApp    FrontAppItem
-	Aerospace   *AerospaceItem
-	Battery     BatteryItem
-	CPU         CPUItem
-	Sensors     SensorsItem
-	Volume      VolumeItem
-	Bluetooth   BluetoothItem
-	Wifi        WifiItem
-	Power       PowerItem
-	Media       *MediaItem
-}
\ No newline at end of file
+	return []string{itemName}
+}
+
+// osascriptUpdateGroup serializes the items asking applescript, concurrent osascript calls are slow to answer.
+const osascriptUpdateGroup = "osascript"
+
+type IndexedWentsketchyItems = map[string]WentsketchyItem
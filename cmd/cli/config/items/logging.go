@@ -0,0 +1,57 @@
+package items
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+)
+
+type loggingItem struct {
+	item   WentsketchyItem
+	logger *slog.Logger
+}
+
+// WithLogging logs duration and error of every Init and Update at debug level,
+// attach the item name to the logger with logger.With.
+func WithLogging(item WentsketchyItem, logger *slog.Logger) WentsketchyItem {
+	return loggingItem{item, logger}
+}
+
+func (i loggingItem) Init(
+	ctx context.Context,
+	position sketchybar.Position,
+	batches Batches,
+) (Batches, error) {
+	start := time.Now()
+	batches, err := i.item.Init(ctx, position, batches)
+
+	i.logger.DebugContext(ctx, "item: init",
+		slog.String("position", position),
+		slog.Duration("duration", time.Since(start)),
+		slog.Any("error", err))
+
+	return batches, err
+}
+
+func (i loggingItem) Update(
+	ctx context.Context,
+	batches Batches,
+	position sketchybar.Position,
+	args *args.In,
+) (Batches, error) {
+	start := time.Now()
+	batches, err := i.item.Update(ctx, batches, position, args)
+
+	i.logger.DebugContext(ctx, "item: update",
+		slog.String("event", args.Event),
+		slog.String("name", args.Name),
+		slog.Duration("duration", time.Since(start)),
+		slog.Any("error", err))
+
+	return batches, err
+}
+
+var _ WentsketchyItem = (*loggingItem)(nil)
@@ -0,0 +1,34 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitLogging(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should log item, event and duration on update", func(t *testing.T) {
+		// GIVEN
+		var out bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		item := WithLogging(NewMainIconItem(testutils.CreateTestLogger()), logger.With(slog.String("item", "main_icon")))
+
+		// WHEN
+		_, err := item.Update(ctx, Batches{}, sketchybar.PositionLeft, &args.In{Name: "main_icon", Event: "forced"})
+
+		// THEN
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "item=main_icon")
+		require.Contains(t, out.String(), "event=forced")
+		require.Contains(t, out.String(), "duration=")
+	})
+}
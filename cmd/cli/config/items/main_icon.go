@@ -19,6 +19,13 @@ func NewMainIconItem(logger *slog.Logger) MainIconItem {
 	return MainIconItem{logger}
 }
 
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(mainIconItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewMainIconItem(deps.Logger)
+	})
+}
+
 const mainIconItemName = "main_icon"
 
 func (i MainIconItem) Init(
@@ -31,21 +38,17 @@ func (i MainIconItem) Init(
 			i.logger.Error("main_icon: recovered from panic in Init", slog.Any("panic", r))
 		}
 	}()
-	mainIcon := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  settings.Sketchybar.ItemSpacing,
-			Right: pointer(0),
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.Apple,
-			Color: sketchybar.ColorOptions{
-				Color: colors.White,
-			},
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
+	mainIcon, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, pointer(0)).
+		WithIcon(icons.Apple, sketchybar.EmptyFontOptions).
+		WithIconColor(colors.White).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+
+	if err != nil {
+		i.logger.Error("main_icon: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", mainIconItemName, position))
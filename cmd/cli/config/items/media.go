@@ -37,6 +37,13 @@ func NewMediaItem(
 	}
 }
 
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(mediaItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewMediaItem(deps.Logger, deps.Command)
+	})
+}
+
 const (
 	mediaItemName        = "media"
 	mediaEvent           = "media_change"
@@ -77,61 +84,68 @@ func (i *MediaItem) Init(
 	batches = batch(batches, m(s("--set", mediaCheckerItemName), checkerItem.ToArgs()))
 	batches = batch(batches, s("--subscribe", mediaCheckerItemName, events.SystemWoke, mediaEvent, "routine", "forced"))
 
-	nextItem := sketchybar.ItemOptions{
-		Display:     "active",
-		Icon:        sketchybar.ItemIconOptions{Value: icons.MediaNext, Font: sketchybar.FontOptions{Font: settings.FontIcon}, Padding: sketchybar.PaddingOptions{Left: pointer(0), Right: settings.Sketchybar.IconPadding}},
-		Label:       sketchybar.ItemLabelOptions{Drawing: "off"},
-		ClickScript: `osascript -e 'tell application "Spotify" to next track' && sketchybar --trigger media_change`,
-		Background:  sketchybar.BackgroundOptions{Drawing: "off"},
+	nextItem, err := mediaControlItem(
+		icons.MediaNext,
+		pointer(0),
+		settings.Sketchybar.IconPadding,
+		`osascript -e 'tell application "Spotify" to next track' && sketchybar --trigger media_change`,
+	)
+	if err != nil {
+		i.logger.Error("media: could not build next item", slog.Any("error", err))
+		return batches, nil
 	}
 	batches = batch(batches, s("--add", "item", mediaNextItemName, position))
 	batches = batch(batches, m(s("--set", mediaNextItemName), nextItem.ToArgs()))
 
-	playPauseItem := sketchybar.ItemOptions{
-		Display:     "active",
-		Icon:        sketchybar.ItemIconOptions{Value: icons.MediaPlay, Font: sketchybar.FontOptions{Font: settings.FontIcon}, Padding: sketchybar.PaddingOptions{Left: settings.Sketchybar.IconPadding, Right: settings.Sketchybar.IconPadding}},
-		Label:       sketchybar.ItemLabelOptions{Drawing: "off"},
-		ClickScript: `osascript -e 'tell application "Spotify" to playpause' && sketchybar --trigger media_change`,
-		Background:  sketchybar.BackgroundOptions{Drawing: "off"},
+	playPauseItem, err := mediaControlItem(
+		icons.MediaPlay,
+		settings.Sketchybar.IconPadding,
+		settings.Sketchybar.IconPadding,
+		`osascript -e 'tell application "Spotify" to playpause' && sketchybar --trigger media_change`,
+	)
+	if err != nil {
+		i.logger.Error("media: could not build play pause item", slog.Any("error", err))
+		return batches, nil
 	}
 	batches = batch(batches, s("--add", "item", mediaPlayPauseItemName, position))
 	batches = batch(batches, m(s("--set", mediaPlayPauseItemName), playPauseItem.ToArgs()))
 
-	prevItem := sketchybar.ItemOptions{
-		Display:     "active",
-		Icon:        sketchybar.ItemIconOptions{Value: icons.MediaPrevious, Font: sketchybar.FontOptions{Font: settings.FontIcon}, Padding: sketchybar.PaddingOptions{Left: settings.Sketchybar.IconPadding, Right: pointer(0)}},
-		Label:       sketchybar.ItemLabelOptions{Drawing: "off"},
-		ClickScript: `osascript -e 'tell application "Spotify" to previous track' && sketchybar --trigger media_change`,
-		Background:  sketchybar.BackgroundOptions{Drawing: "off"},
+	prevItem, err := mediaControlItem(
+		icons.MediaPrevious,
+		settings.Sketchybar.IconPadding,
+		pointer(0),
+		`osascript -e 'tell application "Spotify" to previous track' && sketchybar --trigger media_change`,
+	)
+	if err != nil {
+		i.logger.Error("media: could not build previous item", slog.Any("error", err))
+		return batches, nil
 	}
 	batches = batch(batches, s("--add", "item", mediaPrevItemName, position))
 	batches = batch(batches, m(s("--set", mediaPrevItemName), prevItem.ToArgs()))
 
-	infoItem := sketchybar.ItemOptions{
-		Display:     "active",
-		Width:       pointer(0),
-		ScrollTexts: "off",
-		Label: sketchybar.ItemLabelOptions{
-			Drawing: "off",
-			Padding: sketchybar.PaddingOptions{Left: settings.Sketchybar.IconPadding, Right: pointer(1)},
-		},
-		Background: sketchybar.BackgroundOptions{Drawing: "off"},
+	infoItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithWidth(0).
+		WithScrollTexts("off").
+		WithLabelDrawing("off").
+		WithLabelPadding(settings.Sketchybar.IconPadding, pointer(1)).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+	if err != nil {
+		i.logger.Error("media: could not build info item", slog.Any("error", err))
+		return batches, nil
 	}
 	batches = batch(batches, s("--add", "item", mediaInfoItemName, position))
 	batches = batch(batches, m(s("--set", mediaInfoItemName), infoItem.ToArgs()))
 
-	bracketItem := sketchybar.BracketOptions{
-		Background: sketchybar.BackgroundOptions{
+	bracketItem := sketchybar.BracketOptions{}.
+		WithMembers(mediaPrevItemName, mediaPlayPauseItemName, mediaNextItemName, mediaInfoItemName).
+		WithBackground(sketchybar.BackgroundOptions{
 			Drawing: "on",
 			Color:   sketchybar.ColorOptions{Color: colors.Transparent},
 			Border:  sketchybar.BorderOptions{Color: colors.WhiteA05},
-		},
-	}
-	batches = batch(batches, s(
-		"--add", "bracket", mediaBracketItemName,
-		mediaPrevItemName, mediaPlayPauseItemName, mediaNextItemName, mediaInfoItemName,
-	))
-	batches = batch(batches, m(s("--set", mediaBracketItemName), bracketItem.ToArgs()))
+		})
+	batches = batch(batches, bracketItem.Build(mediaBracketItemName))
 
 	return batches, nil
 }
@@ -190,17 +204,17 @@ func (i *MediaItem) Update(
 		artistBuff, _ := i.command.RunBufferized(ctx, "osascript", "-e", `tell application "Spotify" to artist of current track`)
 		track, _ := encoding.DecodeAppleScriptOutput(trackBuff.Bytes())
 		artist, _ := encoding.DecodeAppleScriptOutput(artistBuff.Bytes())
-	
+
 		// Clean and trim the strings
 		track = strings.TrimSpace(track)
 		artist = strings.TrimSpace(artist)
-		
+
 		// Remove quotes that might be in the output
 		track = strings.Trim(track, "\"'")
 		artist = strings.Trim(artist, "\"'")
-	
+
 		cleanLabel := fmt.Sprintf("%s • %s", track, artist)
-	
+
 		// Truncate if needed
 		labelRunes := []rune(cleanLabel)
 		if len(labelRunes) > 20 {
@@ -208,7 +222,7 @@ func (i *MediaItem) Update(
 		} else {
 			newLabel = cleanLabel
 		}
-		
+
 		targetWidth = len([]rune(newLabel))*avgCharWidth + *settings.Sketchybar.IconPadding + 1
 		isPlaying = true
 	} else {
@@ -216,7 +230,7 @@ func (i *MediaItem) Update(
 		targetWidth = 0
 		isPlaying = false
 	}
-	
+
 	if targetWidth != i.currentWidth || newLabel != i.currentLabel {
 		var animationArgs []string
 		if targetWidth > i.currentWidth {
@@ -238,7 +252,10 @@ func (i *MediaItem) Update(
 				animationArgs = append(animationArgs, "label.drawing=off")
 			}
 		}
-		batches = batch(batches, m(s("--animate", sketchybar.AnimationTanh, "15", "--set", mediaInfoItemName), animationArgs))
+		batches = batch(batches, m(settings.Sketchybar.Animation(mediaItemName, settings.AnimationConfig{
+			Easing: sketchybar.AnimationTanh,
+			Time:   "15",
+		}).Animate("--set", mediaInfoItemName), animationArgs))
 		i.currentWidth = targetWidth
 		i.currentLabel = newLabel
 	}
@@ -254,4 +271,32 @@ func (i *MediaItem) Update(
 	return batches, nil
 }
 
-var _ WentsketchyItem = (*MediaItem)(nil)
\ No newline at end of file
+// mediaControlItem is a button of the player, only its icon is drawn.
+func mediaControlItem(icon string, left *int, right *int, clickScript string) (sketchybar.ItemOptions, error) {
+	return sketchybar.NewItem().
+		WithDisplay("active").
+		WithIcon(icon, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(left, right).
+		WithLabelDrawing("off").
+		WithClickScript(clickScript).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+}
+
+// SketchybarItems leaves out the checker, it is never drawn.
+func (i *MediaItem) SketchybarItems() []string {
+	return []string{
+		mediaPrevItemName,
+		mediaPlayPauseItemName,
+		mediaNextItemName,
+		mediaInfoItemName,
+		mediaBracketItemName,
+	}
+}
+
+func (i *MediaItem) UpdateGroup() string {
+	return osascriptUpdateGroup
+}
+
+var _ WentsketchyItem = (*MediaItem)(nil)
+var _ SketchybarItemsOwner = (*MediaItem)(nil)
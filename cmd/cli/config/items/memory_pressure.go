@@ -0,0 +1,184 @@
+package items
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+)
+
+type MemoryPressureItem struct {
+	logger  *slog.Logger
+	command *command.Command
+}
+
+func NewMemoryPressureItem(logger *slog.Logger, command *command.Command) MemoryPressureItem {
+	return MemoryPressureItem{logger, command}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(memoryPressureItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewMemoryPressureItem(deps.Logger, deps.Command)
+	})
+}
+
+const memoryPressureItemName = "memory_pressure"
+
+type memoryPressureLevel string
+
+const (
+	memoryPressureNominal  memoryPressureLevel = "nominal"
+	memoryPressureWarn     memoryPressureLevel = "warn"
+	memoryPressureCritical memoryPressureLevel = "critical"
+)
+
+// memory_pressure prints like "System-wide memory free percentage: 63%".
+//
+//nolint:gochecknoglobals // ok
+var memoryFreePercentageRegex = regexp.MustCompile(`System-wide memory free percentage:\s*(\d+)%`)
+
+func (i MemoryPressureItem) Init(
+	_ context.Context,
+	position sketchybar.Position,
+	batches Batches,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.Error("memory pressure: recovered from panic in Init", slog.Any("panic", r))
+		}
+	}()
+	updateEvent, err := args.BuildEvent()
+
+	if err != nil {
+		i.logger.Error("memory pressure: could not generate update event", slog.Any("error", err))
+		return batches, nil
+	}
+
+	memoryPressureItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Memory, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(5).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		Build()
+
+	if err != nil {
+		i.logger.Error("memory pressure: could not build item", slog.Any("error", err))
+		return batches, nil
+	}
+
+	batches = batch(batches, s("--add", "item", memoryPressureItemName, position))
+	batches = batch(batches, m(s("--set", memoryPressureItemName), memoryPressureItem.ToArgs()))
+	batches = batch(batches, s("--subscribe", memoryPressureItemName, events.SystemWoke))
+
+	return batches, nil
+}
+
+func (i MemoryPressureItem) Update(
+	ctx context.Context,
+	batches Batches,
+	_ sketchybar.Position,
+	args *args.In,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.ErrorContext(ctx, "memory pressure: recovered from panic in Update", slog.Any("panic", r))
+		}
+	}()
+
+	if !isMemoryPressure(args.Name) {
+		return batches, nil
+	}
+
+	if args.Event == events.Routine || args.Event == events.Forced || args.Event == events.SystemWoke {
+		output, err := i.command.RunWithTimeout(ctx, 2*time.Second, "memory_pressure")
+		if err != nil {
+			i.logger.ErrorContext(ctx, "memory pressure: could not run memory_pressure", slog.Any("error", err))
+			return batches, nil
+		}
+
+		freePercentage, err := parseMemoryFreePercentage(output)
+		if err != nil {
+			i.logger.ErrorContext(ctx, "memory pressure: could not parse free percentage", slog.Any("error", err))
+			return batches, nil
+		}
+
+		// memory_pressure does not print the level, the kernel does
+		levelOutput, err := i.command.RunWithTimeout(ctx, time.Second, "sysctl", "-n", "kern.memorystatus_vm_pressure_level")
+		if err != nil {
+			i.logger.ErrorContext(ctx, "memory pressure: could not get pressure level", slog.Any("error", err))
+			return batches, nil
+		}
+
+		memoryPressureItem := sketchybar.ItemOptions{
+			Icon: sketchybar.ItemIconOptions{
+				Color: sketchybar.ColorOptions{
+					Color: memoryPressureColor(parseMemoryPressureLevel(levelOutput)),
+				},
+			},
+			Label: sketchybar.ItemLabelOptions{
+				Value: fmt.Sprintf("%c %d%%", DonutWidget{}.Render(float64(freePercentage)), freePercentage),
+			},
+		}
+
+		batches = batch(batches, m(s("--set", memoryPressureItemName), memoryPressureItem.ToArgs()))
+	}
+
+	return batches, nil
+}
+
+func parseMemoryFreePercentage(output string) (int, error) {
+	match := memoryFreePercentageRegex.FindStringSubmatch(output)
+	if len(match) < 2 {
+		return 0, errors.New("memory pressure: no free percentage in memory_pressure output")
+	}
+
+	return strconv.Atoi(match[1])
+}
+
+// parseMemoryPressureLevel reads kern.memorystatus_vm_pressure_level, which is 1, 2 or 4.
+func parseMemoryPressureLevel(output string) memoryPressureLevel {
+	switch strings.TrimSpace(output) {
+	case "2":
+		return memoryPressureWarn
+	case "4":
+		return memoryPressureCritical
+	default:
+		return memoryPressureNominal
+	}
+}
+
+func memoryPressureColor(level memoryPressureLevel) string {
+	switch level {
+	case memoryPressureWarn:
+		return colors.Yellow
+	case memoryPressureCritical:
+		return colors.Red
+	case memoryPressureNominal:
+		return colors.Green
+	default:
+		return colors.Green
+	}
+}
+
+func isMemoryPressure(name string) bool {
+	return name == memoryPressureItemName
+}
+
+var _ WentsketchyItem = (*MemoryPressureItem)(nil)
@@ -0,0 +1,40 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitMemoryPressure(t *testing.T) {
+	t.Run("should parse the free percentage", func(t *testing.T) {
+		// GIVEN
+		output := "The system has 17179869184 (4194304 pages with a page size of 4096).\n" +
+			"Pages free: 12345\n" +
+			"System-wide memory free percentage: 63%\n"
+
+		// WHEN
+		freePercentage, err := parseMemoryFreePercentage(output)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 63, freePercentage)
+	})
+
+	t.Run("should fail without free percentage", func(t *testing.T) {
+		// WHEN
+		_, err := parseMemoryFreePercentage("memory_pressure: command not found")
+
+		// THEN
+		require.Error(t, err)
+	})
+
+	t.Run("should color by pressure level", func(t *testing.T) {
+		// THEN
+		require.Equal(t, colors.Green, memoryPressureColor(parseMemoryPressureLevel("1\n")))
+		require.Equal(t, colors.Yellow, memoryPressureColor(parseMemoryPressureLevel("2\n")))
+		require.Equal(t, colors.Red, memoryPressureColor(parseMemoryPressureLevel("4\n")))
+	})
+}
@@ -0,0 +1,210 @@
+package items
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/clock"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+)
+
+const networkSparklineItemName = "network_sparkline"
+
+type networkBytes struct {
+	in  uint64
+	out uint64
+	at  time.Time
+}
+
+type NetworkSparklineItem struct {
+	logger   *slog.Logger
+	command  *command.Command
+	clock    clock.Clock
+	mu       sync.Mutex
+	last     *networkBytes
+	download *SparklineWidget
+}
+
+func NewNetworkSparklineItem(
+	logger *slog.Logger,
+	command *command.Command,
+	clock clock.Clock,
+) *NetworkSparklineItem {
+	return &NetworkSparklineItem{
+		logger:   logger,
+		command:  command,
+		clock:    clock,
+		download: NewSparklineWidget(settings.Sketchybar.SparklineSize),
+	}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(networkSparklineItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewNetworkSparklineItem(deps.Logger, deps.Command, deps.Clock)
+	})
+}
+
+func (i *NetworkSparklineItem) Init(
+	_ context.Context,
+	position sketchybar.Position,
+	batches Batches,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.Error("network sparkline: recovered from panic in Init", slog.Any("panic", r))
+		}
+	}()
+	updateEvent, err := args.BuildEvent()
+
+	if err != nil {
+		i.logger.Error("network sparkline: could not generate update event", slog.Any("error", err))
+		return batches, nil
+	}
+
+	networkItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Wifi, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(2).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		Build()
+
+	if err != nil {
+		i.logger.Error("network sparkline: could not build item", slog.Any("error", err))
+		return batches, nil
+	}
+
+	batches = batch(batches, s("--add", "item", networkSparklineItemName, position))
+	batches = batch(batches, m(s("--set", networkSparklineItemName), networkItem.ToArgs()))
+	batches = batch(batches, s("--subscribe", networkSparklineItemName, events.SystemWoke))
+
+	return batches, nil
+}
+
+func (i *NetworkSparklineItem) Update(
+	ctx context.Context,
+	batches Batches,
+	_ sketchybar.Position,
+	args *args.In,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.ErrorContext(ctx, "network sparkline: recovered from panic in Update", slog.Any("panic", r))
+		}
+	}()
+
+	if !isNetworkSparkline(args.Name) {
+		return batches, nil
+	}
+
+	if args.Event != events.Routine && args.Event != events.Forced && args.Event != events.SystemWoke {
+		return batches, nil
+	}
+
+	wirelessInterface := getWirelessInterface(ctx, i.logger)
+	output, err := i.command.RunWithTimeout(ctx, time.Second, "netstat", "-ibn", "-I", wirelessInterface)
+	if err != nil {
+		i.logger.ErrorContext(ctx, "network sparkline: could not get interface bytes", slog.Any("error", err))
+		return batches, nil
+	}
+
+	in, out, err := parseNetstatBytes(output)
+	if err != nil {
+		i.logger.ErrorContext(ctx, "network sparkline: could not parse interface bytes", slog.Any("error", err))
+		return batches, nil
+	}
+
+	download, upload, ok := i.addSample(networkBytes{in, out, i.clock.Now()})
+	if !ok {
+		return batches, nil
+	}
+
+	sparkline := i.download.Render()
+
+	networkItem := sketchybar.ItemOptions{
+		Label: sketchybar.ItemLabelOptions{
+			Value: fmt.Sprintf("↓%s ↑%s %s", formatMegabits(download), formatMegabits(upload), sparkline),
+		},
+	}
+
+	batches = batch(batches, m(s("--set", networkSparklineItemName), networkItem.ToArgs()))
+
+	return batches, nil
+}
+
+// addSample returns the speeds in bytes per second since the previous sample, not ok on the first one
+// or when the counters went back, like after a reconnection.
+func (i *NetworkSparklineItem) addSample(current networkBytes) (float64, float64, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	last := i.last
+	i.last = &current
+
+	if last == nil || current.in < last.in || current.out < last.out {
+		return 0, 0, false
+	}
+
+	elapsed := current.at.Sub(last.at).Seconds()
+	if elapsed <= 0 {
+		return 0, 0, false
+	}
+
+	download := float64(current.in-last.in) / elapsed
+	upload := float64(current.out-last.out) / elapsed
+
+	i.download.Append(download)
+
+	return download, upload, true
+}
+
+// parseNetstatBytes reads Ibytes and Obytes of the link row of `netstat -ibn -I <interface>`.
+func parseNetstatBytes(output string) (uint64, uint64, error) {
+	// Example: 'en0  1500  <Link#6>  a4:83:e7:00:00:01  1234567  0  1234567890  654321  0  123456789  0'
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+
+		if len(fields) < 11 || !strings.HasPrefix(fields[2], "<Link#") {
+			continue
+		}
+
+		in, err := strconv.ParseUint(fields[6], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("network sparkline: could not parse ibytes. %w", err)
+		}
+
+		out, err := strconv.ParseUint(fields[9], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("network sparkline: could not parse obytes. %w", err)
+		}
+
+		return in, out, nil
+	}
+
+	return 0, 0, errors.New("network sparkline: no link row in netstat output")
+}
+
+func formatMegabits(bytesPerSecond float64) string {
+	return fmt.Sprintf("%.1f", bytesPerSecond*8/1_000_000)
+}
+
+func isNetworkSparkline(name string) bool {
+	return name == networkSparklineItemName
+}
+
+var _ WentsketchyItem = (*NetworkSparklineItem)(nil)
@@ -0,0 +1,43 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitNetworkSparkline(t *testing.T) {
+	t.Run("should parse the bytes of the link row", func(t *testing.T) {
+		// GIVEN
+		output := "Name       Mtu   Network       Address            Ipkts Ierrs     Ibytes    Opkts Oerrs     Obytes  Coll\n" +
+			"en0        1500  <Link#6>    a4:83:e7:00:00:01  1234567     0 1234567890   654321     0  123456789     0\n" +
+			"en0        1500  192.168.1     192.168.1.20      1234567     -  1234567890   654321     -  123456789     -\n"
+
+		// WHEN
+		in, out, err := parseNetstatBytes(output)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, uint64(1234567890), in)
+		require.Equal(t, uint64(123456789), out)
+	})
+
+	t.Run("should compute the speed since the previous sample", func(t *testing.T) {
+		// GIVEN
+		item := NewNetworkSparklineItem(testutils.CreateTestLogger(), nil, nil)
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		// WHEN
+		_, _, first := item.addSample(networkBytes{1000, 500, start})
+		download, upload, second := item.addSample(networkBytes{3000, 1500, start.Add(2 * time.Second)})
+
+		// THEN
+		require.False(t, first)
+		require.True(t, second)
+		require.InDelta(t, 1000.0, download, 0.001)
+		require.InDelta(t, 500.0, upload, 0.001)
+	})
+}
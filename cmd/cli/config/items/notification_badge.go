@@ -0,0 +1,179 @@
+package items
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+	"github.com/lucax88x/wentsketchy/internal/clock"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/jobs"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+)
+
+const notificationBadgeInterval = 10 * time.Second
+
+// NotificationBadgeItem shows the dock badge count of an app next to the items configured in badges,
+// the unread mails of Mail for example. It is not placed on the bar, it runs as a job
+// and sets the badge items which BadgeBatches adds on init.
+type NotificationBadgeItem struct {
+	logger     *slog.Logger
+	clock      clock.Clock
+	command    *command.Command
+	sketchybar sketchybar.API
+	// badges are the app by sketchybar item name.
+	badges map[string]string
+	// counts are the last counts sent by item name, so only changes are sent.
+	counts map[string]int
+	mutex  sync.Mutex
+}
+
+func NewNotificationBadgeItem(
+	logger *slog.Logger,
+	clock clock.Clock,
+	command *command.Command,
+	sketchybar sketchybar.API,
+	badges map[string]string,
+) *NotificationBadgeItem {
+	return &NotificationBadgeItem{
+		logger:     logger,
+		clock:      clock,
+		command:    command,
+		sketchybar: sketchybar,
+		badges:     badges,
+		counts:     make(map[string]int, len(badges)),
+	}
+}
+
+func (i *NotificationBadgeItem) Name() string {
+	return "notification_badge"
+}
+
+func (i *NotificationBadgeItem) Start(ctx context.Context) {
+	if len(i.badges) == 0 {
+		return
+	}
+
+	ticks, stop := i.clock.Ticker(notificationBadgeInterval)
+	defer stop()
+
+	i.updateBadges(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticks:
+			i.updateBadges(ctx)
+		}
+	}
+}
+
+// Reset sets every badge again on the next check, after BadgeBatches added the badge items again hidden.
+func (i *NotificationBadgeItem) Reset(_ context.Context) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	clear(i.counts)
+}
+
+// updateBadges only sets the badges whose count changed.
+func (i *NotificationBadgeItem) updateBadges(ctx context.Context) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	var args []string
+
+	for _, itemName := range slices.Sorted(maps.Keys(i.badges)) {
+		count := i.badgeCount(ctx, i.badges[itemName])
+
+		if last, found := i.counts[itemName]; found && last == count {
+			continue
+		}
+
+		i.counts[itemName] = count
+
+		badge := sketchybar.BadgeOptions{
+			Value: count,
+			Color: colors.Red,
+			Font: sketchybar.FontOptions{
+				Font: settings.Sketchybar.LabelFont,
+				Kind: "Bold",
+				Size: settings.Sketchybar.LabelFontSize,
+			}.String(),
+		}
+		args = m(m(args, s("--set", sketchybar.BadgeItemName(itemName))), badge.ToArgs())
+	}
+
+	if len(args) == 0 {
+		return
+	}
+
+	if err := i.sketchybar.Run(ctx, args); err != nil {
+		i.logger.ErrorContext(ctx, "notification badge: could not set badges", slog.Any("error", err))
+		// set them again on the next check
+		clear(i.counts)
+	}
+}
+
+// BadgeBatches adds the hidden badge item of host next to it, the job draws the count on it.
+func BadgeBatches(batches Batches, host string, position sketchybar.Position) (Batches, error) {
+	badgeItemName := sketchybar.BadgeItemName(host)
+
+	badgeItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(pointer(0), pointer(0)).
+		WithIconDrawing("off").
+		WithLabelDrawing("off").
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+
+	if err != nil {
+		return batches, fmt.Errorf("notification badge: could not build badge of %s. %w", host, err)
+	}
+
+	batches = batch(batches, s("--add", "item", badgeItemName, position))
+	batches = batch(batches, m(s("--set", badgeItemName), badgeItem.ToArgs()))
+	batches = batch(batches, s("--move", badgeItemName, "after", host))
+
+	return batches, nil
+}
+
+func (i *NotificationBadgeItem) badgeCount(ctx context.Context, app string) int {
+	output, err := i.command.Run(ctx, "lsappinfo", "info", "-only", "StatusLabel", app)
+
+	if err != nil {
+		i.logger.DebugContext(ctx, "notification badge: could not run lsappinfo",
+			slog.String("app", app),
+			slog.Any("error", err))
+		return 0
+	}
+
+	return parseBadgeCount(output)
+}
+
+// parseBadgeCount reads the dock badge of `lsappinfo info -only StatusLabel`,
+// a badge which is not a number, like the dot of some apps, counts as 0.
+func parseBadgeCount(output string) int {
+	// Example: '"StatusLabel"={ "label"="3" }'
+	labelMatch := regexp.MustCompile(`"label"="([^"]*)"`).FindStringSubmatch(output)
+	if len(labelMatch) < 2 {
+		return 0
+	}
+
+	count, err := strconv.Atoi(labelMatch[1])
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+var _ jobs.Job = (*NotificationBadgeItem)(nil)
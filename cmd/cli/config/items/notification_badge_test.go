@@ -0,0 +1,94 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/query"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/lucax88x/wentsketchy/testutils/fake"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAPI struct {
+	mutex sync.Mutex
+	calls [][]string
+}
+
+func (api *recordingAPI) Run(_ context.Context, args []string) error {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	api.calls = append(api.calls, args)
+	return nil
+}
+
+func (api *recordingAPI) RunBatch(ctx context.Context, batches sketchybar.Batches) error {
+	return sketchybar.RunBatchWithFallback(ctx, testutils.CreateTestLogger(), api, batches)
+}
+
+func (api *recordingAPI) RunAsync(ctx context.Context, args []string) {
+	_ = api.Run(ctx, args)
+}
+
+func (api *recordingAPI) QueryBar(_ context.Context) (query.Bar, error) {
+	return query.Bar{}, nil
+}
+
+func (api *recordingAPI) count() int {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	return len(api.calls)
+}
+
+func TestUnitNotificationBadge(t *testing.T) {
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should parse the dock badge count", func(t *testing.T) {
+		// WHEN
+		count := parseBadgeCount(`"StatusLabel"={ "label"="12" }`)
+
+		// THEN
+		require.Equal(t, 12, count)
+	})
+
+	t.Run("should count 0 without badge or with a badge which is not a number", func(t *testing.T) {
+		// WHEN
+		none := parseBadgeCount(`"StatusLabel"=[ NULL ]`)
+		dot := parseBadgeCount(`"StatusLabel"={ "label"="•" }`)
+
+		// THEN
+		require.Zero(t, none)
+		require.Zero(t, dot)
+	})
+
+	t.Run("should set the badges again on the tick after a reset", func(t *testing.T) {
+		// GIVEN
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		clock := &fake.Clock{Ticks: make(chan time.Time)}
+		api := &recordingAPI{}
+		// lsappinfo is not installed on the build machines, every count is 0
+		item := NewNotificationBadgeItem(logger, clock, command.NewCommand(logger), api, map[string]string{
+			"mail": "Mail",
+		})
+		go item.Start(ctx)
+		require.Eventually(t, func() bool { return api.count() == 1 }, time.Second, time.Millisecond)
+
+		// WHEN
+		clock.Ticks <- time.Time{}
+		item.Reset(ctx)
+		clock.Ticks <- time.Time{}
+
+		// THEN
+		require.Eventually(t, func() bool { return api.count() == 2 }, time.Second, time.Millisecond)
+		require.Equal(t, []string{"--set", "mail.badge"}, api.calls[1][:2])
+	})
+}
@@ -0,0 +1,18 @@
+package items
+
+import (
+	"fmt"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+)
+
+// popupArgs are the popup settings shared by the items with a popup, aligned right like the items.
+func popupArgs() []string {
+	return []string{
+		"popup.align=right",
+		fmt.Sprintf("popup.background.color=%s", colors.PopupBackgroundColor),
+		fmt.Sprintf("popup.background.border_color=%s", colors.PopupBorderColor),
+		fmt.Sprintf("popup.background.border_width=%d", *settings.Sketchybar.ItemBorderWidth),
+	}
+}
@@ -2,6 +2,7 @@ package items
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
@@ -20,6 +21,13 @@ func NewPowerItem(logger *slog.Logger, command *command.Command) PowerItem {
 	return PowerItem{logger, command}
 }
 
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(powerItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewPowerItem(deps.Logger, deps.Command)
+	})
+}
+
 const (
 	powerItemName = "power"
 )
@@ -35,23 +43,21 @@ func (i PowerItem) Init(
 		}
 	}()
 
-	powerItem := sketchybar.ItemOptions{
-		Display: "active",
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.Power,
-			Font: sketchybar.FontOptions{
-				Font: settings.Sketchybar.IconFont,
-				Kind: settings.Sketchybar.IconFontKind,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.IconPadding,
-				Right: settings.Sketchybar.IconPadding,
-			},
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Drawing: "off",
-		},
-		ClickScript: `pmset displaysleepnow`,
+	powerItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithIcon(icons.Power, sketchybar.FontOptions{
+			Font: settings.Sketchybar.IconFont,
+			Kind: settings.Sketchybar.IconFontKind,
+		}).
+		WithIconPadding(settings.Sketchybar.IconPadding, settings.Sketchybar.IconPadding).
+		WithLabelDrawing("off").
+		WithClickScript(`pmset displaysleepnow`).
+		WithRightClickScript(`sketchybar --set "$NAME" popup.drawing=toggle`).
+		Build()
+
+	if err != nil {
+		i.logger.Error("power: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	itemArgs := powerItem.ToArgs()
@@ -64,7 +70,8 @@ func (i PowerItem) Init(
 	)
 
 	batches = batch(batches, s("--add", "item", powerItemName, position))
-	batches = batch(batches, m(s("--set", powerItemName), itemArgs))
+	batches = batch(batches, m(m(s("--set", powerItemName), itemArgs), popupArgs()))
+	batches = addPowerPopup(batches)
 
 	return batches, nil
 }
@@ -79,4 +86,42 @@ func (i PowerItem) Update(
 	return batches, nil
 }
 
-var _ WentsketchyItem = (*PowerItem)(nil)
\ No newline at end of file
+type powerPopupRow struct {
+	itemName string
+	title    string
+	script   string
+}
+
+//nolint:gochecknoglobals // ok
+var powerPopupRows = []powerPopupRow{
+	{"power.lock", "Lock Screen", `osascript -e 'tell application "System Events" to keystroke "q" using {control down, command down}'`},
+	{"power.sleep", "Sleep", `pmset sleepnow`},
+	{"power.restart", "Restart", `osascript -e 'tell application "System Events" to restart'`},
+	{"power.shutdown", "Shut Down", `osascript -e 'tell application "System Events" to shut down'`},
+}
+
+// addPowerPopup closes the popup before running the action of the row.
+func addPowerPopup(batches Batches) Batches {
+	for _, row := range powerPopupRows {
+		rowItem := sketchybar.ItemOptions{
+			Padding: sketchybar.PaddingOptions{
+				Left:  settings.Sketchybar.ItemSpacing,
+				Right: settings.Sketchybar.ItemSpacing,
+			},
+			Icon: sketchybar.ItemIconOptions{
+				Drawing: "off",
+			},
+			Label: sketchybar.ItemLabelOptions{
+				Value: row.title,
+			},
+			ClickScript: fmt.Sprintf("sketchybar --set %s popup.drawing=off; %s", powerItemName, row.script),
+		}
+
+		batches = batch(batches, s("--add", "item", row.itemName, "popup."+powerItemName))
+		batches = batch(batches, m(s("--set", row.itemName), rowItem.ToArgs()))
+	}
+
+	return batches
+}
+
+var _ WentsketchyItem = (*PowerItem)(nil)
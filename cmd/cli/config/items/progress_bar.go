@@ -0,0 +1,45 @@
+package items
+
+import (
+	"fmt"
+	"strings"
+)
+
+const progressBarDefaultSegments = 8
+
+// ProgressBarItem is not an item on its own, it renders a label like "████░░░░ 62%" for other items.
+// Zero Segments, FillChar and EmptyChar use the defaults.
+type ProgressBarItem struct {
+	// Value is from 0 to 1, anything outside is clamped.
+	Value     float64
+	Segments  int
+	FillChar  rune
+	EmptyChar rune
+}
+
+// Render only fills the segments which are complete, so a bar is full only at 100%.
+func (p ProgressBarItem) Render() string {
+	segments := p.Segments
+	if segments <= 0 {
+		segments = progressBarDefaultSegments
+	}
+
+	fillChar := p.FillChar
+	if fillChar == 0 {
+		fillChar = '█'
+	}
+
+	emptyChar := p.EmptyChar
+	if emptyChar == 0 {
+		emptyChar = '░'
+	}
+
+	value := min(max(p.Value, 0), 1)
+	filled := int(value * float64(segments))
+
+	return fmt.Sprintf("%s%s %.0f%%",
+		strings.Repeat(string(fillChar), filled),
+		strings.Repeat(string(emptyChar), segments-filled),
+		value*100,
+	)
+}
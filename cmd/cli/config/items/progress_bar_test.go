@@ -0,0 +1,32 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitProgressBar(t *testing.T) {
+	t.Run("should fill only complete segments", func(t *testing.T) {
+		// WHEN
+		label := ProgressBarItem{Value: 0.62}.Render()
+
+		// THEN
+		require.Equal(t, "████░░░░ 62%", label)
+	})
+
+	t.Run("should use the configured segments and chars", func(t *testing.T) {
+		// WHEN
+		label := ProgressBarItem{Value: 0.5, Segments: 4, FillChar: '#', EmptyChar: '-'}.Render()
+
+		// THEN
+		require.Equal(t, "##-- 50%", label)
+	})
+
+	t.Run("should clamp the value", func(t *testing.T) {
+		// THEN
+		require.Equal(t, "████████ 100%", ProgressBarItem{Value: 1.2}.Render())
+		require.Equal(t, "░░░░░░░░ 0%", ProgressBarItem{Value: -0.1}.Render())
+	})
+}
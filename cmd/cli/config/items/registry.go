@@ -0,0 +1,68 @@
+package items
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+	"github.com/lucax88x/wentsketchy/internal/clock"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+)
+
+// ItemDeps are the dependencies an ItemFactory can pick from.
+type ItemDeps struct {
+	Logger     *slog.Logger
+	Command    *command.Command
+	Clock      clock.Clock
+	Aerospace  aerospace.Aerospace
+	Sketchybar sketchybar.API
+}
+
+type ItemFactory func(deps ItemDeps) WentsketchyItem
+
+// Registry is what plugins get to register their items.
+type Registry interface {
+	RegisterItem(name string, factory ItemFactory)
+}
+
+type defaultRegistry struct{}
+
+func (defaultRegistry) RegisterItem(name string, factory ItemFactory) {
+	RegisterItem(name, factory)
+}
+
+// DefaultRegistry registers in the same registry as RegisterItem.
+func DefaultRegistry() Registry {
+	return defaultRegistry{}
+}
+
+//nolint:gochecknoglobals // ok
+var registry = make(map[string]ItemFactory)
+
+// RegisterItem makes the item available by name in the positions of config.yaml,
+// it is meant to be called from the init of the item file.
+func RegisterItem(name string, factory ItemFactory) {
+	if _, found := registry[name]; found {
+		panic(fmt.Sprintf("items: %s is registered twice", name))
+	}
+
+	registry[name] = factory
+}
+
+func NewFromName(name string, deps ItemDeps) (WentsketchyItem, error) {
+	factory, found := registry[name]
+
+	if !found {
+		return nil, fmt.Errorf("items: %s is not registered", name)
+	}
+
+	return factory(deps), nil
+}
+
+// RegisteredItemNames are sorted.
+func RegisteredItemNames() []string {
+	return slices.Sorted(maps.Keys(registry))
+}
@@ -0,0 +1,38 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitRegistry(t *testing.T) {
+	deps := ItemDeps{Logger: testutils.CreateTestLogger()}
+
+	t.Run("should build a registered item by name", func(t *testing.T) {
+		// WHEN
+		item, err := NewFromName("battery", deps)
+
+		// THEN
+		require.NoError(t, err)
+		require.IsType(t, BatteryItem{}, item)
+	})
+
+	t.Run("should fail on an unknown name", func(t *testing.T) {
+		// WHEN
+		_, err := NewFromName("baterry", deps)
+
+		// THEN
+		require.Error(t, err)
+	})
+
+	t.Run("should register every item file", func(t *testing.T) {
+		// THEN
+		require.Equal(t, []string{
+			"aerospace", "battery", "bluetooth", "calendar", "cpu", "front_app", "main_icon", "media",
+			"memory_pressure", "network_sparkline", "power", "sensors", "swap", "uptime", "volume", "wifi",
+		}, RegisteredItemNames())
+	})
+}
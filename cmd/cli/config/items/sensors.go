@@ -1,12 +1,10 @@
 package items
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"log/slog"
-	"strconv"
-	"strings"
+	"sync"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
@@ -16,28 +14,35 @@ import (
 	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
 )
 
-const statsApp = "/Applications/Stats.app/Contents/Resources/smc"
-
 type SensorsItem struct {
-	logger  *slog.Logger
-	command *command.Command
+	logger      *slog.Logger
+	backends    []ThermalBackend
+	backendOnce sync.Once
+	backend     ThermalBackend
 }
 
-func NewSensorsItem(logger *slog.Logger, command *command.Command) SensorsItem {
-	return SensorsItem{
-		logger,
-		command,
+func NewSensorsItem(logger *slog.Logger, command *command.Command) *SensorsItem {
+	return &SensorsItem{
+		logger:   logger,
+		backends: newThermalBackends(command),
 	}
 }
 
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem("sensors", func(deps ItemDeps) WentsketchyItem {
+		return NewSensorsItem(deps.Logger, deps.Command)
+	})
+}
+
 const sensorsBracketName = "sensors.bracket"
 const sensorsItemIconName = "sensors.icon"
 const sensorsItemFansName = "sensors.fans"
 const sensorsItemTemperaturesName = "sensors.temperatures"
 const sensorsItemSpacerName = "sensors.spacer"
 
-func (i SensorsItem) Init(
-	_ context.Context,
+func (i *SensorsItem) Init(
+	ctx context.Context,
 	position sketchybar.Position,
 	batches Batches,
 ) (Batches, error) {
@@ -53,84 +58,64 @@ func (i SensorsItem) Init(
 		return batches, nil
 	}
 
-	sensorsIconItem := sketchybar.ItemOptions{
-		Display: "active",
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.ThermoMedium,
-			Font: sketchybar.FontOptions{
-				Font: settings.FontIcon,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.IconPadding,
-				Right: pointer(*settings.Sketchybar.IconPadding / 2),
-			},
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
+	sensorsIconItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithIcon(icons.ThermoMedium, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+
+	if err != nil {
+		i.logger.Error("sensors: could not build icon item", slog.Any("error", err))
+		return batches, nil
 	}
 
-	sensorsFansItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  pointer(0),
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Value: "",
-			Font: sketchybar.FontOptions{
-				Size: "8.0",
-			},
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Drawing: "off",
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
-		YOffset:    pointer(-6),
-		Width:      pointer(0),
-		UpdateFreq: pointer(4),
-		Updates:    "on",
-		Script:     updateEvent,
+	sensorsFansItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(pointer(0), settings.Sketchybar.ItemSpacing).
+		WithLabelFont(sketchybar.FontOptions{Size: "8.0"}).
+		WithIconDrawing("off").
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		WithYOffset(-6).
+		WithWidth(0).
+		WithUpdateFreq(4).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		Build()
+
+	if err != nil {
+		i.logger.Error("sensors: could not build fans item", slog.Any("error", err))
+		return batches, nil
 	}
-	sensorsTemperaturesItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  pointer(0),
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Value: "",
-			Font: sketchybar.FontOptions{
-				Size: "8.0",
-			},
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Drawing: "off",
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
-		YOffset: pointer(4),
-		// Width:   pointer(0),
+
+	sensorsTemperaturesItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(pointer(0), settings.Sketchybar.ItemSpacing).
+		WithLabelFont(sketchybar.FontOptions{Size: "8.0"}).
+		WithIconDrawing("off").
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		WithYOffset(4).
+		Build()
+
+	if err != nil {
+		i.logger.Error("sensors: could not build temperatures item", slog.Any("error", err))
+		return batches, nil
 	}
-	sensorsBracketItem := sketchybar.BracketOptions{
-		Background: sketchybar.BackgroundOptions{
+
+	sensorsBracketItem := sketchybar.BracketOptions{}.
+		WithMembers(sensorsItemIconName, sensorsItemFansName, sensorsItemTemperaturesName).
+		WithBackground(sketchybar.BackgroundOptions{
 			Drawing: "on",
-		},
-	}
-	sensorsSpacerItem := sketchybar.ItemOptions{
-		Display: "active",
-		Label: sketchybar.ItemLabelOptions{
-			Value: "",
-		},
-		Padding: sketchybar.PaddingOptions{
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Background: sketchybar.BackgroundOptions{
-			Drawing: "off",
-		},
+		})
+	sensorsSpacerItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(nil, settings.Sketchybar.ItemSpacing).
+		WithBackground(sketchybar.BackgroundOptions{Drawing: "off"}).
+		Build()
+
+	if err != nil {
+		i.logger.Error("sensors: could not build spacer item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", sensorsItemSpacerName, position))
@@ -145,20 +130,14 @@ func (i SensorsItem) Init(
 	batches = batch(batches, s("--add", "item", sensorsItemIconName, position))
 	batches = batch(batches, m(s("--set", sensorsItemIconName), sensorsIconItem.ToArgs()))
 
-	batches = batch(batches, s(
-		"--add",
-		"bracket",
-		sensorsBracketName,
-		sensorsItemIconName,
-		sensorsItemFansName,
-		sensorsItemTemperaturesName,
-	))
-	batches = batch(batches, m(s("--set", sensorsBracketName), sensorsBracketItem.ToArgs()))
+	batches = batch(batches, sensorsBracketItem.Build(sensorsBracketName))
+
+	i.thermalBackend(ctx)
 
 	return batches, nil
 }
 
-func (i SensorsItem) Update(
+func (i *SensorsItem) Update(
 	ctx context.Context,
 	batches Batches,
 	_ sketchybar.Position,
@@ -174,29 +153,30 @@ func (i SensorsItem) Update(
 	}
 
 	if args.Event == events.Routine || args.Event == events.Forced {
-		fanSpeeds, err := i.getFanSpeeds(ctx)
+		backend := i.thermalBackend(ctx)
 
-		if err != nil {
-			i.logger.ErrorContext(ctx, "sensors: could not get fan speeds", slog.Any("error", err))
+		if backend == nil {
 			return batches, nil
 		}
 
-		temperatures, err := i.getTemperatures(ctx)
+		fanSpeed, err := backend.GetFanRPM(ctx)
 
 		if err != nil {
-			i.logger.ErrorContext(ctx, "sensors: could not get temperatures", slog.Any("error", err))
+			i.logger.ErrorContext(ctx, "sensors: could not get fan speed", slog.Any("error", err))
 			return batches, nil
 		}
 
-		fanSpeed := float32(-1)
-		if len(fanSpeeds) > 0 {
-			fanSpeed = fanSpeeds[0]
+		temperature, err := backend.GetTemp(ctx)
+
+		if err != nil {
+			i.logger.ErrorContext(ctx, "sensors: could not get temperature", slog.Any("error", err))
+			return batches, nil
 		}
 
 		actualFanSpeed := "Fans Off"
 
-		if fanSpeed > -1 {
-			actualFanSpeed = fmt.Sprintf("%.0f RPM", fanSpeed)
+		if fanSpeed > 0 {
+			actualFanSpeed = fmt.Sprintf("%d RPM", fanSpeed)
 		}
 
 		sensorsFanItem := sketchybar.ItemOptions{
@@ -206,7 +186,7 @@ func (i SensorsItem) Update(
 		}
 		sensorsTemperaturesItem := sketchybar.ItemOptions{
 			Label: sketchybar.ItemLabelOptions{
-				Value: fmt.Sprintf("%.0f°C / %.0f°C", temperatures.highest, temperatures.averageCPUs),
+				Value: fmt.Sprintf("%.0f°C", temperature),
 			},
 		}
 		batches = batch(batches, m(s("--set", sensorsItemFansName), sensorsFanItem.ToArgs()))
@@ -216,119 +196,28 @@ func (i SensorsItem) Update(
 	return batches, nil
 }
 
-func isFAN(name string) bool {
-	return name == sensorsItemFansName
-}
-
-func (i SensorsItem) getFanSpeeds(ctx context.Context) ([]float32, error) {
-	defer func() {
-		if r := recover(); r != nil {
-			i.logger.ErrorContext(ctx, "sensors: recovered from panic in getFanSpeeds", slog.Any("panic", r))
-		}
-	}()
-	out, err := i.command.Run(ctx, statsApp, "fans")
-
-	if err != nil {
-		return make([]float32, 0), fmt.Errorf("sensors: could not get fan speed. %w", err)
-	}
-
-	scanner := bufio.NewScanner(strings.NewReader(out))
-	results := make([]float32, 0)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		speedFromLine, cut := strings.CutPrefix(line, "Actual speed: ")
-		if cut {
-			conv, err := strconv.ParseFloat(speedFromLine, 32)
-
-			if err != nil {
-				//nolint:errorlint // no wrap
-				return make([]float32, 0), fmt.Errorf("sensors: could not parse fan speed from line %s. %v", line, err)
-			}
-
-			results = append(results, float32(conv))
-		}
+func (i *SensorsItem) SketchybarItems() []string {
+	return []string{
+		sensorsBracketName,
+		sensorsItemIconName,
+		sensorsItemFansName,
+		sensorsItemTemperaturesName,
+		sensorsItemSpacerName,
 	}
-
-	return results, nil
 }
 
-type temperatures struct {
-	highest     float32
-	averageCPUs float32
-}
-
-func (i SensorsItem) getTemperatures(ctx context.Context) (temperatures, error) {
-	defer func() {
-		if r := recover(); r != nil {
-			i.logger.ErrorContext(ctx, "sensors: recovered from panic in getTemperatures", slog.Any("panic", r))
-		}
-	}()
-	var results temperatures
-	out, err := i.command.Run(ctx, statsApp, "list", "-t")
-
-	if err != nil {
-		return results, fmt.Errorf("sensors: could not get fan speed. %w", err)
-	}
-
-	var cpuTemps []float32
-	scanner := bufio.NewScanner(strings.NewReader(out))
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		if strings.HasPrefix(line, "[INFO]") {
-			continue
-		}
-
-		if len(line) == 0 {
-			continue
-		}
-
-		temp, err := parseTemperature(line)
-
-		if err != nil {
-			continue
-		}
-
-		if temp <= 0 {
-			continue
-		}
-
-		if temp > results.highest {
-			results.highest = temp
-		}
-
-		if strings.HasPrefix(line, "[TC") {
-			cpuTemps = append(cpuTemps, temp)
-		}
-	}
-
-	if len(cpuTemps) > 0 {
-		sum := float32(0)
-		for _, temp := range cpuTemps {
-			sum += temp
-		}
-		results.averageCPUs = sum / float32(len(cpuTemps))
-	}
-
-	return results, nil
+func isFAN(name string) bool {
+	return name == sensorsItemFansName
 }
 
-func parseTemperature(line string) (float32, error) {
-	parts := strings.Fields(line)
+// thermalBackend picks the backend the first time, so the commands are not tried on every update.
+func (i *SensorsItem) thermalBackend(ctx context.Context) ThermalBackend {
+	i.backendOnce.Do(func() {
+		i.backend = selectThermalBackend(ctx, i.logger, i.backends)
+	})
 
-	if len(parts) < 2 {
-		return 0, fmt.Errorf("sensors: invalid temperature line format with %s", line)
-	}
-
-	part := parts[len(parts)-1]
-
-	temp, err := strconv.ParseFloat(part, 32)
-	if err != nil {
-		//nolint:errorlint // no wrap
-		return 0, fmt.Errorf("sensors failed to parse temperature from %s: %v", part, err)
-	}
-	return float32(temp), nil
+	return i.backend
 }
 
 var _ WentsketchyItem = (*SensorsItem)(nil)
+var _ SketchybarItemsOwner = (*SensorsItem)(nil)
@@ -13,13 +13,12 @@ import (
 func TestUnitSensors(t *testing.T) {
 	t.Skip("Skipping sensor tests as they depend on an external application (Stats.app) and fail in CI.")
 	ctx := context.Background()
-	logger := testutils.CreateTestLogger()
-	command := command.NewCommand(logger)
-	item := NewSensorsItem(logger, command)
+	command := command.NewCommand(testutils.CreateTestLogger())
+	backend := StatsBackend{command}
 
 	t.Run("should get fan speeds", func(t *testing.T) {
 		// WHEN
-		result, err := item.getFanSpeeds(ctx)
+		result, err := backend.getFanSpeeds(ctx)
 
 		// THEN
 		require.NoError(t, err)
@@ -30,7 +29,7 @@ func TestUnitSensors(t *testing.T) {
 
 	t.Run("should get temperatures", func(t *testing.T) {
 		// WHEN
-		result, err := item.getTemperatures(ctx)
+		result, err := backend.getTemperatures(ctx)
 
 		// THEN
 		require.NoError(t, err)
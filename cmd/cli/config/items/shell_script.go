@@ -0,0 +1,171 @@
+package items
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/homedir"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+)
+
+const shellScriptDefaultUpdateFreq = 5
+
+// ShellScriptItem shows the first line of the stdout of a user script, one item per shell_scripts entry.
+type ShellScriptItem struct {
+	logger  *slog.Logger
+	command *command.Command
+	config  settings.ShellScriptConfig
+}
+
+func NewShellScriptItem(
+	logger *slog.Logger,
+	command *command.Command,
+	config settings.ShellScriptConfig,
+) ShellScriptItem {
+	if config.UpdateFreq <= 0 {
+		config.UpdateFreq = shellScriptDefaultUpdateFreq
+	}
+
+	config.Script = expandHome(config.Script)
+
+	return ShellScriptItem{logger, command, config}
+}
+
+// RegisterShellScriptItems registers every shell script by its name, skipping the names already taken.
+func RegisterShellScriptItems(logger *slog.Logger, configs []settings.ShellScriptConfig) {
+	for _, config := range configs {
+		if config.Name == "" || config.Script == "" {
+			logger.Warn("shell script: name and script are required, skipping it", slog.String("name", config.Name))
+			continue
+		}
+
+		if slices.Contains(RegisteredItemNames(), config.Name) {
+			logger.Warn("shell script: name is already taken by another item, skipping it",
+				slog.String("name", config.Name))
+			continue
+		}
+
+		RegisterItem(config.Name, func(deps ItemDeps) WentsketchyItem {
+			return NewShellScriptItem(deps.Logger, deps.Command, config)
+		})
+	}
+}
+
+func (i ShellScriptItem) Init(
+	_ context.Context,
+	position sketchybar.Position,
+	batches Batches,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.Error("shell script: recovered from panic in Init", slog.Any("panic", r))
+		}
+	}()
+	updateEvent, err := args.BuildEvent()
+
+	if err != nil {
+		i.logger.Error("shell script: could not generate update event", slog.Any("error", err))
+		return batches, nil
+	}
+
+	builder := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(i.config.UpdateFreq).
+		WithUpdates("on").
+		WithScript(updateEvent)
+
+	if i.config.Icon != "" {
+		builder = builder.
+			WithIcon(i.config.Icon, sketchybar.FontOptions{Font: settings.FontIcon}).
+			WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2))
+	}
+
+	shellScriptItem, err := builder.Build()
+
+	if err != nil {
+		i.logger.Error("shell script: could not build item", slog.String("name", i.config.Name), slog.Any("error", err))
+		return batches, nil
+	}
+
+	if i.config.Icon == "" {
+		shellScriptItem.Icon.Drawing = "off"
+		shellScriptItem.Label.Padding.Left = settings.Sketchybar.IconPadding
+	}
+
+	batches = batch(batches, s("--add", "item", i.config.Name, position))
+	batches = batch(batches, m(s("--set", i.config.Name), shellScriptItem.ToArgs()))
+	batches = batch(batches, s("--subscribe", i.config.Name, events.SystemWoke))
+
+	return batches, nil
+}
+
+func (i ShellScriptItem) Update(
+	ctx context.Context,
+	batches Batches,
+	_ sketchybar.Position,
+	args *args.In,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.ErrorContext(ctx, "shell script: recovered from panic in Update", slog.Any("panic", r))
+		}
+	}()
+
+	if args.Name != i.config.Name {
+		return batches, nil
+	}
+
+	if args.Event == events.Routine || args.Event == events.Forced || args.Event == events.SystemWoke {
+		// a script slower than its own update frequency would pile up
+		timeout := time.Duration(i.config.UpdateFreq) * time.Second
+		output, err := i.command.RunWithTimeout(ctx, timeout, i.config.Script)
+
+		if err != nil {
+			i.logger.ErrorContext(ctx, "shell script: could not run script",
+				slog.String("name", i.config.Name),
+				slog.Any("error", err))
+			return batches, nil
+		}
+
+		shellScriptItem := sketchybar.ItemOptions{
+			Label: sketchybar.ItemLabelOptions{
+				Value: firstLine(output),
+			},
+		}
+
+		batches = batch(batches, m(s("--set", i.config.Name), shellScriptItem.ToArgs()))
+	}
+
+	return batches, nil
+}
+
+func firstLine(output string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(line)
+}
+
+func expandHome(path string) string {
+	rest, found := strings.CutPrefix(path, "~/")
+	if !found {
+		return path
+	}
+
+	home, err := homedir.Get()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, rest)
+}
+
+var _ WentsketchyItem = (*ShellScriptItem)(nil)
@@ -0,0 +1,68 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitShellScript(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should set the first line of the script output as label", func(t *testing.T) {
+		// GIVEN
+		script := filepath.Join(t.TempDir(), "weather.sh")
+		require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho '12°C'\necho 'cloudy'\n"), 0o700))
+		item := NewShellScriptItem(logger, command.NewCommand(logger), settings.ShellScriptConfig{
+			Name:   "weather",
+			Script: script,
+		})
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionRight, &args.In{
+			Name:  "weather",
+			Event: events.Routine,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, Batches{{"--set", "weather", "label=12°C"}}, batches)
+	})
+
+	t.Run("should ignore the updates of other items", func(t *testing.T) {
+		// GIVEN
+		item := NewShellScriptItem(logger, command.NewCommand(logger), settings.ShellScriptConfig{
+			Name:   "weather",
+			Script: "/does/not/exist",
+		})
+
+		// WHEN
+		batches, err := item.Update(ctx, Batches{}, sketchybar.PositionRight, &args.In{
+			Name:  "battery",
+			Event: events.Routine,
+		})
+
+		// THEN
+		require.NoError(t, err)
+		require.Empty(t, batches)
+	})
+
+	t.Run("should default the update frequency", func(t *testing.T) {
+		// WHEN
+		item := NewShellScriptItem(logger, nil, settings.ShellScriptConfig{Name: "weather", Script: "weather.sh"})
+
+		// THEN
+		require.Equal(t, shellScriptDefaultUpdateFreq, item.config.UpdateFreq)
+	})
+}
@@ -0,0 +1,73 @@
+package items
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+//nolint:gochecknoglobals // ok
+var sparklineLevels = []rune("⡀⣀⣄⣤⣦⣶⣷⣿")
+
+// SparklineWidget is not an item on its own, it keeps the last values of an item and renders their trend.
+type SparklineWidget struct {
+	// Max is the value drawn as the highest level, 0 scales to the highest value kept.
+	Max     float64
+	mu      sync.Mutex
+	samples []float64
+	next    int
+	full    bool
+}
+
+// NewSparklineWidget keeps the last size values, one character each.
+func NewSparklineWidget(size int) *SparklineWidget {
+	return &SparklineWidget{samples: make([]float64, max(size, 1))}
+}
+
+// Append overwrites the oldest value once the widget is full.
+func (w *SparklineWidget) Append(v float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.samples[w.next] = v
+	w.next = (w.next + 1) % len(w.samples)
+
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+// Values are from the oldest to the newest.
+func (w *SparklineWidget) Values() []float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.full {
+		return slices.Clone(w.samples[:w.next])
+	}
+
+	return slices.Concat(w.samples[w.next:], w.samples[:w.next])
+}
+
+func (w *SparklineWidget) Render() string {
+	values := w.Values()
+
+	highest := w.Max
+	if highest <= 0 {
+		for _, value := range values {
+			highest = max(highest, value)
+		}
+	}
+
+	var sparkline strings.Builder
+	for _, value := range values {
+		level := 0
+		if highest > 0 {
+			level = int(min(max(value/highest, 0), 1) * float64(len(sparklineLevels)-1))
+		}
+
+		sparkline.WriteRune(sparklineLevels[level])
+	}
+
+	return sparkline.String()
+}
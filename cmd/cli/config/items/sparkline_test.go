@@ -0,0 +1,49 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitSparkline(t *testing.T) {
+	t.Run("should keep only the last values from the oldest", func(t *testing.T) {
+		// GIVEN
+		sparkline := NewSparklineWidget(3)
+
+		// WHEN
+		for _, value := range []float64{1, 2, 3, 4} {
+			sparkline.Append(value)
+		}
+
+		// THEN
+		require.Equal(t, []float64{2, 3, 4}, sparkline.Values())
+	})
+
+	t.Run("should scale to the highest value", func(t *testing.T) {
+		// GIVEN
+		sparkline := NewSparklineWidget(10)
+
+		// WHEN
+		for _, value := range []float64{0, 50, 100} {
+			sparkline.Append(value)
+		}
+
+		// THEN
+		require.Equal(t, "⡀⣤⣿", sparkline.Render())
+	})
+
+	t.Run("should scale to max when set", func(t *testing.T) {
+		// GIVEN
+		sparkline := NewSparklineWidget(10)
+		sparkline.Max = 100
+
+		// WHEN
+		sparkline.Append(10)
+		sparkline.Append(120)
+
+		// THEN
+		require.Equal(t, "⡀⣿", sparkline.Render())
+	})
+}
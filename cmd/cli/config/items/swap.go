@@ -0,0 +1,179 @@
+package items
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+)
+
+type SwapItem struct {
+	logger  *slog.Logger
+	command *command.Command
+}
+
+func NewSwapItem(logger *slog.Logger, command *command.Command) SwapItem {
+	return SwapItem{logger, command}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(swapItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewSwapItem(deps.Logger, deps.Command)
+	})
+}
+
+const swapItemName = "swap"
+
+// swapHighUsage colors the icon red above this ratio of used swap.
+const swapHighUsage = 0.75
+
+// vm.swapusage prints like "total = 2048.00M  used = 1228.25M  free = 819.75M  (encrypted)".
+//
+//nolint:gochecknoglobals // ok
+var swapUsageRegex = regexp.MustCompile(`(total|used) = ([\d.]+)([MG])`)
+
+func (i SwapItem) Init(
+	_ context.Context,
+	position sketchybar.Position,
+	batches Batches,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.Error("swap: recovered from panic in Init", slog.Any("panic", r))
+		}
+	}()
+	updateEvent, err := args.BuildEvent()
+
+	if err != nil {
+		i.logger.Error("swap: could not generate update event", slog.Any("error", err))
+		return batches, nil
+	}
+
+	swapItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Database, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(10).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		Build()
+
+	if err != nil {
+		i.logger.Error("swap: could not build item", slog.Any("error", err))
+		return batches, nil
+	}
+
+	batches = batch(batches, s("--add", "item", swapItemName, position))
+	batches = batch(batches, m(s("--set", swapItemName), swapItem.ToArgs()))
+	batches = batch(batches, s("--subscribe", swapItemName, events.SystemWoke))
+
+	return batches, nil
+}
+
+func (i SwapItem) Update(
+	ctx context.Context,
+	batches Batches,
+	_ sketchybar.Position,
+	args *args.In,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.ErrorContext(ctx, "swap: recovered from panic in Update", slog.Any("panic", r))
+		}
+	}()
+
+	if !isSwap(args.Name) {
+		return batches, nil
+	}
+
+	if args.Event == events.Routine || args.Event == events.Forced || args.Event == events.SystemWoke {
+		output, err := i.command.RunWithTimeout(ctx, time.Second, "sysctl", "-n", "vm.swapusage")
+		if err != nil {
+			i.logger.ErrorContext(ctx, "swap: could not get swap usage", slog.Any("error", err))
+			return batches, nil
+		}
+
+		used, total, err := parseSwapUsage(output)
+		if err != nil {
+			i.logger.ErrorContext(ctx, "swap: could not parse swap usage", slog.Any("error", err))
+			return batches, nil
+		}
+
+		usage := 0.0
+		if total > 0 {
+			usage = used / total
+		}
+
+		color := settings.Sketchybar.IconColor
+		if usage > swapHighUsage {
+			color = colors.Red
+		}
+
+		swapItem := sketchybar.ItemOptions{
+			Icon: sketchybar.ItemIconOptions{
+				Color: sketchybar.ColorOptions{
+					Color: color,
+				},
+			},
+			Label: sketchybar.ItemLabelOptions{
+				Value: fmt.Sprintf("%c %s / %s GB", DonutWidget{}.Render(usage*100), formatGigabytes(used), formatGigabytes(total)),
+			},
+		}
+
+		batches = batch(batches, m(s("--set", swapItemName), swapItem.ToArgs()))
+	}
+
+	return batches, nil
+}
+
+func isSwap(name string) bool {
+	return name == swapItemName
+}
+
+// parseSwapUsage returns used and total swap in gigabytes.
+func parseSwapUsage(output string) (float64, float64, error) {
+	values := make(map[string]float64)
+
+	for _, match := range swapUsageRegex.FindAllStringSubmatch(output, -1) {
+		value, err := strconv.ParseFloat(match[2], 64)
+
+		if err != nil {
+			return 0, 0, fmt.Errorf("swap: could not parse %s. %w", match[1], err)
+		}
+
+		if match[3] == "M" {
+			value /= 1024
+		}
+
+		values[match[1]] = value
+	}
+
+	used, hasUsed := values["used"]
+	total, hasTotal := values["total"]
+
+	if !hasUsed || !hasTotal {
+		return 0, 0, fmt.Errorf("swap: no used or total in %q", output)
+	}
+
+	return used, total, nil
+}
+
+func formatGigabytes(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
+
+var _ WentsketchyItem = (*SwapItem)(nil)
@@ -0,0 +1,38 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitSwap(t *testing.T) {
+	t.Run("should parse used and total in gigabytes", func(t *testing.T) {
+		// WHEN
+		used, total, err := parseSwapUsage("total = 4096.00M  used = 1228.25M  free = 2867.75M  (encrypted)\n")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "1.2", formatGigabytes(used))
+		require.Equal(t, "4", formatGigabytes(total))
+	})
+
+	t.Run("should parse values already in gigabytes", func(t *testing.T) {
+		// WHEN
+		used, total, err := parseSwapUsage("total = 8.00G  used = 6.50G  free = 1.50G  (encrypted)")
+
+		// THEN
+		require.NoError(t, err)
+		require.InDelta(t, 6.5, used, 0.001)
+		require.InDelta(t, 8.0, total, 0.001)
+	})
+
+	t.Run("should fail on unexpected output", func(t *testing.T) {
+		// WHEN
+		_, _, err := parseSwapUsage("unknown oid 'vm.swapusage'")
+
+		// THEN
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,341 @@
+package items
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/command"
+)
+
+const statsApp = "/Applications/Stats.app/Contents/Resources/smc"
+
+// ThermalBackend reads the CPU temperature in celsius and the speed of the first fan.
+type ThermalBackend interface {
+	Name() string
+	GetTemp(ctx context.Context) (float64, error)
+	// GetFanRPM is 0 when the fans are off or there are none.
+	GetFanRPM(ctx context.Context) (int, error)
+}
+
+// newThermalBackends are in the order they are tried, the ones not needing sudo first.
+func newThermalBackends(cmd *command.Command) []ThermalBackend {
+	return []ThermalBackend{
+		IStatsBackend{cmd},
+		OsxCPUTempBackend{cmd},
+		StatsBackend{cmd},
+		PowermetricsBackend{cmd},
+	}
+}
+
+// selectThermalBackend returns the first backend able to read the temperature, nil when none is.
+func selectThermalBackend(ctx context.Context, logger *slog.Logger, backends []ThermalBackend) ThermalBackend {
+	for _, backend := range backends {
+		if _, err := backend.GetTemp(ctx); err != nil {
+			logger.DebugContext(ctx, "sensors: thermal backend not available",
+				slog.String("backend", backend.Name()),
+				slog.Any("error", err))
+			continue
+		}
+
+		logger.InfoContext(ctx, "sensors: using thermal backend", slog.String("backend", backend.Name()))
+		return backend
+	}
+
+	logger.WarnContext(ctx, "sensors: no thermal backend available")
+	return nil
+}
+
+// IStatsBackend asks the iStats gem.
+type IStatsBackend struct {
+	command *command.Command
+}
+
+func (b IStatsBackend) Name() string {
+	return "istats"
+}
+
+func (b IStatsBackend) GetTemp(ctx context.Context) (float64, error) {
+	output, err := b.command.Run(ctx, "istats", "cpu", "temp", "--value-only")
+
+	if err != nil {
+		return 0, fmt.Errorf("sensors: could not run istats. %w", err)
+	}
+
+	return parseFirstNumber(output)
+}
+
+func (b IStatsBackend) GetFanRPM(ctx context.Context) (int, error) {
+	output, err := b.command.Run(ctx, "istats", "fan", "speed", "--value-only")
+
+	if err != nil {
+		return 0, fmt.Errorf("sensors: could not run istats. %w", err)
+	}
+
+	rpm, err := parseFirstNumber(output)
+	if err != nil {
+		// no fans, like on a macbook air
+		return 0, nil
+	}
+
+	return int(rpm), nil
+}
+
+// OsxCPUTempBackend asks osx-cpu-temp.
+type OsxCPUTempBackend struct {
+	command *command.Command
+}
+
+func (b OsxCPUTempBackend) Name() string {
+	return "osx-cpu-temp"
+}
+
+func (b OsxCPUTempBackend) GetTemp(ctx context.Context) (float64, error) {
+	output, err := b.command.Run(ctx, "osx-cpu-temp", "-c")
+
+	if err != nil {
+		return 0, fmt.Errorf("sensors: could not run osx-cpu-temp. %w", err)
+	}
+
+	return parseFirstNumber(output)
+}
+
+func (b OsxCPUTempBackend) GetFanRPM(ctx context.Context) (int, error) {
+	output, err := b.command.Run(ctx, "osx-cpu-temp", "-f")
+
+	if err != nil {
+		return 0, fmt.Errorf("sensors: could not run osx-cpu-temp. %w", err)
+	}
+
+	return parseOsxCPUTempFanRPM(output), nil
+}
+
+// StatsBackend asks the smc binary shipped with the Stats app.
+type StatsBackend struct {
+	command *command.Command
+}
+
+func (b StatsBackend) Name() string {
+	return "stats"
+}
+
+func (b StatsBackend) GetTemp(ctx context.Context) (float64, error) {
+	results, err := b.getTemperatures(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if results.averageCPUs > 0 {
+		return float64(results.averageCPUs), nil
+	}
+
+	return float64(results.highest), nil
+}
+
+func (b StatsBackend) GetFanRPM(ctx context.Context) (int, error) {
+	fanSpeeds, err := b.getFanSpeeds(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(fanSpeeds) == 0 {
+		return 0, nil
+	}
+
+	return int(fanSpeeds[0]), nil
+}
+
+func (b StatsBackend) getFanSpeeds(ctx context.Context) ([]float32, error) {
+	out, err := b.command.Run(ctx, statsApp, "fans")
+
+	if err != nil {
+		return make([]float32, 0), fmt.Errorf("sensors: could not get fan speed. %w", err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	results := make([]float32, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		speedFromLine, cut := strings.CutPrefix(line, "Actual speed: ")
+		if cut {
+			conv, err := strconv.ParseFloat(speedFromLine, 32)
+
+			if err != nil {
+				return make([]float32, 0), fmt.Errorf("sensors: could not parse fan speed from line %s. %w", line, err)
+			}
+
+			results = append(results, float32(conv))
+		}
+	}
+
+	return results, nil
+}
+
+type temperatures struct {
+	highest     float32
+	averageCPUs float32
+}
+
+func (b StatsBackend) getTemperatures(ctx context.Context) (temperatures, error) {
+	var results temperatures
+	out, err := b.command.Run(ctx, statsApp, "list", "-t")
+
+	if err != nil {
+		return results, fmt.Errorf("sensors: could not get temperatures. %w", err)
+	}
+
+	var cpuTemps []float32
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "[INFO]") {
+			continue
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+
+		temp, err := parseTemperature(line)
+
+		if err != nil {
+			continue
+		}
+
+		if temp <= 0 {
+			continue
+		}
+
+		if temp > results.highest {
+			results.highest = temp
+		}
+
+		if strings.HasPrefix(line, "[TC") {
+			cpuTemps = append(cpuTemps, temp)
+		}
+	}
+
+	if len(cpuTemps) > 0 {
+		sum := float32(0)
+		for _, temp := range cpuTemps {
+			sum += temp
+		}
+		results.averageCPUs = sum / float32(len(cpuTemps))
+	}
+
+	return results, nil
+}
+
+func parseTemperature(line string) (float32, error) {
+	parts := strings.Fields(line)
+
+	if len(parts) < 2 {
+		return 0, fmt.Errorf("sensors: invalid temperature line format with %s", line)
+	}
+
+	part := parts[len(parts)-1]
+
+	temp, err := strconv.ParseFloat(part, 32)
+	if err != nil {
+		return 0, fmt.Errorf("sensors: could not parse temperature from %s. %w", part, err)
+	}
+	return float32(temp), nil
+}
+
+// PowermetricsBackend is the last resort, powermetrics needs sudo without password to be allowed.
+type PowermetricsBackend struct {
+	command *command.Command
+}
+
+func (b PowermetricsBackend) Name() string {
+	return "powermetrics"
+}
+
+func (b PowermetricsBackend) GetTemp(ctx context.Context) (float64, error) {
+	output, err := b.sample(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return parsePowermetrics(output, powermetricsTempRegex)
+}
+
+func (b PowermetricsBackend) GetFanRPM(ctx context.Context) (int, error) {
+	output, err := b.sample(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	rpm, err := parsePowermetrics(output, powermetricsFanRegex)
+	if err != nil {
+		// no fans, like on a macbook air
+		return 0, nil
+	}
+
+	return int(rpm), nil
+}
+
+func (b PowermetricsBackend) sample(ctx context.Context) (string, error) {
+	// -n makes sudo fail instead of waiting for a password
+	output, err := b.command.RunWithTimeout(ctx, 3*time.Second,
+		"sudo", "-n", "powermetrics", "--samplers", "smc", "-i", "1", "-n", "1")
+
+	if err != nil {
+		return "", fmt.Errorf("sensors: could not run powermetrics. %w", err)
+	}
+
+	return output, nil
+}
+
+//nolint:gochecknoglobals // ok
+var (
+	numberRegex           = regexp.MustCompile(`\d+(\.\d+)?`)
+	osxCPUTempFanRegex    = regexp.MustCompile(`at (\d+) RPM`)
+	powermetricsTempRegex = regexp.MustCompile(`CPU die temperature: (\d+(\.\d+)?) C`)
+	powermetricsFanRegex  = regexp.MustCompile(`Fan: (\d+(\.\d+)?) rpm`)
+)
+
+// parseFirstNumber reads outputs like '52.38' or '61.8°C'.
+func parseFirstNumber(output string) (float64, error) {
+	match := numberRegex.FindString(output)
+	if match == "" {
+		return 0, fmt.Errorf("sensors: no number in %s", strings.TrimSpace(output))
+	}
+
+	return strconv.ParseFloat(match, 64)
+}
+
+// parseOsxCPUTempFanRPM reads the first 'Fan 0 - Left side   at 1200 RPM (20%)' line.
+func parseOsxCPUTempFanRPM(output string) int {
+	match := osxCPUTempFanRegex.FindStringSubmatch(output)
+	if len(match) < 2 {
+		return 0
+	}
+
+	rpm, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return rpm
+}
+
+func parsePowermetrics(output string, regex *regexp.Regexp) (float64, error) {
+	match := regex.FindStringSubmatch(output)
+	if len(match) < 2 {
+		return 0, errors.New("sensors: value not found in powermetrics output")
+	}
+
+	return strconv.ParseFloat(match[1], 64)
+}
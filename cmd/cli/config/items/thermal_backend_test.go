@@ -0,0 +1,94 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeThermalBackend struct {
+	name string
+	err  error
+}
+
+func (b fakeThermalBackend) Name() string {
+	return b.name
+}
+
+func (b fakeThermalBackend) GetTemp(_ context.Context) (float64, error) {
+	return 50, b.err
+}
+
+func (b fakeThermalBackend) GetFanRPM(_ context.Context) (int, error) {
+	return 0, b.err
+}
+
+func TestUnitThermalBackend(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should select the first working backend", func(t *testing.T) {
+		// GIVEN
+		backends := []ThermalBackend{
+			fakeThermalBackend{"istats", errors.New("not installed")},
+			fakeThermalBackend{"osx-cpu-temp", nil},
+			fakeThermalBackend{"powermetrics", nil},
+		}
+
+		// WHEN
+		backend := selectThermalBackend(ctx, logger, backends)
+
+		// THEN
+		require.Equal(t, "osx-cpu-temp", backend.Name())
+	})
+
+	t.Run("should select nothing when no backend works", func(t *testing.T) {
+		// WHEN
+		backend := selectThermalBackend(ctx, logger, []ThermalBackend{
+			fakeThermalBackend{"istats", errors.New("not installed")},
+		})
+
+		// THEN
+		require.Nil(t, backend)
+	})
+
+	t.Run("should parse the temperature of istats and osx-cpu-temp", func(t *testing.T) {
+		// WHEN
+		istats, err := parseFirstNumber("52.38\n")
+		require.NoError(t, err)
+		osxCPUTemp, err := parseFirstNumber("61.8°C\n")
+		require.NoError(t, err)
+
+		// THEN
+		require.InDelta(t, 52.38, istats, 0.001)
+		require.InDelta(t, 61.8, osxCPUTemp, 0.001)
+	})
+
+	t.Run("should parse the fan speed of osx-cpu-temp", func(t *testing.T) {
+		// GIVEN
+		output := "Num fans: 2\nFan 0 - Left side   at 1200 RPM (20%)\nFan 1 - Right side  at 1300 RPM (21%)\n"
+
+		// THEN
+		require.Equal(t, 1200, parseOsxCPUTempFanRPM(output))
+		require.Equal(t, 0, parseOsxCPUTempFanRPM("Num fans: 0\n"))
+	})
+
+	t.Run("should parse powermetrics", func(t *testing.T) {
+		// GIVEN
+		output := "**** SMC sensors ****\n\nCPU Thermal level: 0\nFan: 1834.45 rpm\nCPU die temperature: 45.50 C\n"
+
+		// WHEN
+		temperature, err := parsePowermetrics(output, powermetricsTempRegex)
+		require.NoError(t, err)
+		rpm, err := parsePowermetrics(output, powermetricsFanRegex)
+		require.NoError(t, err)
+
+		// THEN
+		require.InDelta(t, 45.5, temperature, 0.001)
+		require.InDelta(t, 1834.45, rpm, 0.001)
+	})
+}
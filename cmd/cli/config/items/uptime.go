@@ -0,0 +1,159 @@
+package items
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
+	"github.com/lucax88x/wentsketchy/internal/clock"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
+)
+
+type UptimeItem struct {
+	logger  *slog.Logger
+	command *command.Command
+	clock   clock.Clock
+}
+
+func NewUptimeItem(logger *slog.Logger, command *command.Command, clock clock.Clock) UptimeItem {
+	return UptimeItem{logger, command, clock}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(uptimeItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewUptimeItem(deps.Logger, deps.Command, deps.Clock)
+	})
+}
+
+const uptimeItemName = "uptime"
+
+// kern.boottime prints like "{ sec = 1700000000, usec = 0 } Tue Nov 14 22:13:20 2023".
+//
+//nolint:gochecknoglobals // ok
+var bootTimeRegex = regexp.MustCompile(`sec = (\d+)`)
+
+func (i UptimeItem) Init(
+	_ context.Context,
+	position sketchybar.Position,
+	batches Batches,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.Error("uptime: recovered from panic in Init", slog.Any("panic", r))
+		}
+	}()
+	updateEvent, err := args.BuildEvent()
+
+	if err != nil {
+		i.logger.Error("uptime: could not generate update event", slog.Any("error", err))
+		return batches, nil
+	}
+
+	uptimeItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Clock, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(60).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		Build()
+
+	if err != nil {
+		i.logger.Error("uptime: could not build item", slog.Any("error", err))
+		return batches, nil
+	}
+
+	batches = batch(batches, s("--add", "item", uptimeItemName, position))
+	batches = batch(batches, m(s("--set", uptimeItemName), uptimeItem.ToArgs()))
+	batches = batch(batches, s("--subscribe", uptimeItemName, events.SystemWoke))
+
+	return batches, nil
+}
+
+func (i UptimeItem) Update(
+	ctx context.Context,
+	batches Batches,
+	_ sketchybar.Position,
+	args *args.In,
+) (Batches, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			i.logger.ErrorContext(ctx, "uptime: recovered from panic in Update", slog.Any("panic", r))
+		}
+	}()
+
+	if !isUptime(args.Name) {
+		return batches, nil
+	}
+
+	if args.Event == events.Routine || args.Event == events.Forced || args.Event == events.SystemWoke {
+		output, err := i.command.Run(ctx, "sysctl", "-n", "kern.boottime")
+		if err != nil {
+			i.logger.ErrorContext(ctx, "uptime: could not get boot time", slog.Any("error", err))
+			return batches, nil
+		}
+
+		bootTime, err := parseBootTime(output)
+		if err != nil {
+			i.logger.ErrorContext(ctx, "uptime: could not parse boot time", slog.Any("error", err))
+			return batches, nil
+		}
+
+		uptimeItem := sketchybar.ItemOptions{
+			Label: sketchybar.ItemLabelOptions{
+				Value: formatUptime(i.clock.Now().Sub(bootTime)),
+			},
+		}
+
+		batches = batch(batches, m(s("--set", uptimeItemName), uptimeItem.ToArgs()))
+	}
+
+	return batches, nil
+}
+
+func isUptime(name string) bool {
+	return name == uptimeItemName
+}
+
+func parseBootTime(output string) (time.Time, error) {
+	matches := bootTimeRegex.FindStringSubmatch(output)
+
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("uptime: no sec field in %q", output)
+	}
+
+	sec, err := strconv.ParseInt(matches[1], 10, 64)
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("uptime: could not parse sec. %w", err)
+	}
+
+	return time.Unix(sec, 0), nil
+}
+
+// formatUptime shows days and hours, like "3d 4h", or hours and minutes below a day.
+func formatUptime(uptime time.Duration) string {
+	uptime = max(uptime, 0)
+
+	days := int(uptime.Hours()) / 24
+	hours := int(uptime.Hours()) % 24
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+
+	return fmt.Sprintf("%dh %dm", hours, int(uptime.Minutes())%60)
+}
+
+var _ WentsketchyItem = (*UptimeItem)(nil)
@@ -0,0 +1,36 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitUptime(t *testing.T) {
+	t.Run("should parse sec of kern.boottime", func(t *testing.T) {
+		// WHEN
+		bootTime, err := parseBootTime("{ sec = 1700000000, usec = 123456 } Tue Nov 14 22:13:20 2023\n")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, int64(1700000000), bootTime.Unix())
+	})
+
+	t.Run("should fail without sec", func(t *testing.T) {
+		// WHEN
+		_, err := parseBootTime("unknown oid 'kern.boottime'")
+
+		// THEN
+		require.Error(t, err)
+	})
+
+	t.Run("should format days and hours", func(t *testing.T) {
+		require.Equal(t, "3d 4h", formatUptime(3*24*time.Hour+4*time.Hour+59*time.Minute))
+	})
+
+	t.Run("should format hours and minutes below a day", func(t *testing.T) {
+		require.Equal(t, "5h 7m", formatUptime(5*time.Hour+7*time.Minute+30*time.Second))
+	})
+}
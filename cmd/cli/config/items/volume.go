@@ -7,6 +7,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
@@ -17,12 +18,20 @@ import (
 )
 
 type VolumeItem struct {
-	logger  *slog.Logger
-	command *command.Command
+	logger    *slog.Logger
+	command   *command.Command
+	popupOpen *atomic.Bool
 }
 
 func NewVolumeItem(logger *slog.Logger, command *command.Command) VolumeItem {
-	return VolumeItem{logger, command}
+	return VolumeItem{logger, command, &atomic.Bool{}}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(volumeItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewVolumeItem(deps.Logger, deps.Command)
+	})
 }
 
 const volumeItemName = "volume"
@@ -44,37 +53,28 @@ func (i VolumeItem) Init(
 		return batches, nil
 	}
 
-	volumeItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  settings.Sketchybar.ItemSpacing,
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.Volume100,
-			Font: sketchybar.FontOptions{
-				Font: settings.FontIcon,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.IconPadding,
-				Right: pointer(*settings.Sketchybar.IconPadding / 2),
-			},
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Padding: sketchybar.PaddingOptions{
-				Left:  pointer(0),
-				Right: settings.Sketchybar.IconPadding,
-			},
-		},
-		UpdateFreq: pointer(120),
-		Updates:    "on",
-		Script:     updateEvent,
-		ClickScript: `sh -c "osascript -e 'set volume output muted not (output muted of (get volume settings))' && sketchybar --trigger volume_change"`,
+	volumeItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Volume100, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(120).
+		WithUpdates("on").
+		WithScript(updateEvent).
+		WithClickScript(`sh -c "osascript -e 'set volume output muted not (output muted of (get volume settings))' && sketchybar --trigger volume_change"`).
+		WithRightClickScript(updateEvent).
+		WithScrollScript(`osascript -e "set volume output volume ((output volume of (get volume settings)) + $SCROLL_DELTA)" && sketchybar --trigger volume_change`).
+		Build()
+
+	if err != nil {
+		i.logger.Error("volume: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", volumeItemName, position))
-	batches = batch(batches, m(s("--set", volumeItemName), volumeItem.ToArgs()))
-	batches = batch(batches, s("--subscribe", volumeItemName, events.SystemWoke, "volume_change"))
+	batches = batch(batches, m(m(s("--set", volumeItemName), volumeItem.ToArgs()), popupArgs()))
+	batches = batch(batches, s("--subscribe", volumeItemName, events.SystemWoke, events.MouseScrolled, "volume_change"))
 
 	return batches, nil
 }
@@ -94,6 +94,10 @@ func (i VolumeItem) Update(
 		return batches, nil
 	}
 
+	if args.Event == events.MouseClicked {
+		return i.toggleVolumePopup(ctx, batches), nil
+	}
+
 	if args.Event == events.Routine || args.Event == events.Forced || args.Event == events.VolumeChange {
 		const script = `
 if output muted of (get volume settings) is true then
@@ -102,7 +106,7 @@ else
 	return "" & output volume of (get volume settings)
 end if
 `
-		output, err := i.command.Run(ctx, "osascript", "-e", script)
+		output, err := i.command.RunWithStdin(ctx, script, "osascript", "-")
 		if err != nil {
 			i.logger.ErrorContext(ctx, "volume: could not get volume info", slog.Any("error", err))
 			return batches, nil
@@ -167,4 +171,8 @@ func getVolumeIcon(percentage int) string {
 	}
 }
 
-var _ WentsketchyItem = (*VolumeItem)(nil)
\ No newline at end of file
+func (i VolumeItem) UpdateGroup() string {
+	return osascriptUpdateGroup
+}
+
+var _ WentsketchyItem = (*VolumeItem)(nil)
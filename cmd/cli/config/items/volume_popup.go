@@ -0,0 +1,127 @@
+package items
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+)
+
+const volumePopupParent = "popup." + volumeItemName
+const volumeAppItemPrefix = volumeItemName + ".app."
+
+// volumeDefaultAppVolume is the sound volume of a player which was never changed.
+const volumeDefaultAppVolume = 100
+
+// volumeApps are the players with a sound volume in applescript, macos has no volume for any other app.
+//
+//nolint:gochecknoglobals // ok
+var volumeApps = []string{"Spotify", "Music"}
+
+type appVolume struct {
+	app    string
+	volume int
+}
+
+// toggleVolumePopup lists the apps with a non default volume every time the popup is opened.
+func (i VolumeItem) toggleVolumePopup(ctx context.Context, batches Batches) Batches {
+	opening := !i.popupOpen.Load()
+	i.popupOpen.Store(opening)
+
+	if opening {
+		batches = batch(batches, s("--remove", "/"+strings.ReplaceAll(volumeAppItemPrefix, ".", `\.`)+".*/"))
+
+		appVolumes := i.getAppVolumes(ctx)
+
+		if len(appVolumes) == 0 {
+			batches = addVolumeAppRow(batches, volumeAppItemPrefix+"none", "All apps at 100%", "", "")
+		}
+
+		for _, appVolume := range appVolumes {
+			batches = addVolumeAppRow(
+				batches,
+				volumeAppItemPrefix+strings.ToLower(appVolume.app),
+				fmt.Sprintf("%s %d%%", appVolume.app, appVolume.volume),
+				changeAppVolumeScript(appVolume.app, 10),
+				changeAppVolumeScript(appVolume.app, -10),
+			)
+		}
+	}
+
+	return batch(batches, s("--set", volumeItemName, fmt.Sprintf("popup.drawing=%s", onOff(opening))))
+}
+
+func addVolumeAppRow(batches Batches, itemName string, label string, clickScript string, rightClickScript string) Batches {
+	rowItem := sketchybar.ItemOptions{
+		Padding: sketchybar.PaddingOptions{
+			Left:  settings.Sketchybar.ItemSpacing,
+			Right: settings.Sketchybar.ItemSpacing,
+		},
+		Icon: sketchybar.ItemIconOptions{
+			Drawing: "off",
+		},
+		Label: sketchybar.ItemLabelOptions{
+			Value: label,
+		},
+		ClickScript:      clickScript,
+		RightClickScript: rightClickScript,
+	}
+
+	batches = batch(batches, s("--add", "item", itemName, volumePopupParent))
+	return batch(batches, m(s("--set", itemName), rowItem.ToArgs()))
+}
+
+// changeAppVolumeScript changes the volume of app by delta and shows the new one on the row.
+func changeAppVolumeScript(app string, delta int) string {
+	return fmt.Sprintf(
+		`osascript -e 'tell application "%[1]s" to set sound volume to (sound volume + %[2]d)' && `+
+			`sketchybar --set "$NAME" label="%[1]s $(osascript -e 'tell application "%[1]s" to sound volume')%%"`,
+		app,
+		delta,
+	)
+}
+
+func (i VolumeItem) getAppVolumes(ctx context.Context) []appVolume {
+	var script strings.Builder
+	script.WriteString("set output to \"\"\n")
+	for _, app := range volumeApps {
+		fmt.Fprintf(&script,
+			"if application %[1]q is running then tell application %[1]q to set output to output & %[1]q & \":\" & (sound volume as string) & linefeed\n",
+			app)
+	}
+	script.WriteString("return output\n")
+
+	output, err := i.command.RunWithStdin(ctx, script.String(), "osascript", "-")
+
+	if err != nil {
+		i.logger.ErrorContext(ctx, "volume: could not get app volumes", slog.Any("error", err))
+		return nil
+	}
+
+	return parseAppVolumes(output)
+}
+
+// parseAppVolumes reads 'app:volume' lines, skipping the apps at the default volume.
+func parseAppVolumes(output string) []appVolume {
+	var appVolumes []appVolume
+
+	for _, line := range strings.Split(output, "\n") {
+		app, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+
+		volume, err := strconv.Atoi(value)
+		if err != nil || volume == volumeDefaultAppVolume {
+			continue
+		}
+
+		appVolumes = append(appVolumes, appVolume{app, volume})
+	}
+
+	return appVolumes
+}
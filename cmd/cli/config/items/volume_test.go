@@ -0,0 +1,29 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitVolume(t *testing.T) {
+	t.Run("should parse the app volumes which are not the default one", func(t *testing.T) {
+		// GIVEN
+		output := "Spotify:40\nMusic:100\n"
+
+		// WHEN
+		appVolumes := parseAppVolumes(output)
+
+		// THEN
+		require.Equal(t, []appVolume{{"Spotify", 40}}, appVolumes)
+	})
+
+	t.Run("should not parse app volumes when no player is running", func(t *testing.T) {
+		// WHEN
+		appVolumes := parseAppVolumes("\n")
+
+		// THEN
+		require.Empty(t, appVolumes)
+	})
+}
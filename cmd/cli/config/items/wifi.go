@@ -4,23 +4,33 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"sync/atomic"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/icons"
 	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/network"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar/events"
 )
 
 type WifiItem struct {
-	logger  *slog.Logger
-	command *command.Command
+	logger    *slog.Logger
+	command   *command.Command
+	popupOpen *atomic.Bool
 }
 
 func NewWifiItem(logger *slog.Logger, command *command.Command) WifiItem {
-	return WifiItem{logger, command}
+	return WifiItem{logger, command, &atomic.Bool{}}
+}
+
+//nolint:gochecknoinits // items register themselves
+func init() {
+	RegisterItem(wifiItemName, func(deps ItemDeps) WentsketchyItem {
+		return NewWifiItem(deps.Logger, deps.Command)
+	})
 }
 
 const wifiItemName = "wifi"
@@ -35,10 +45,12 @@ func (i WifiItem) Init(
 			i.logger.Error("wifi: recovered from panic in Init", slog.Any("panic", r))
 		}
 	}()
-	
+
+	wirelessInterface := getWirelessInterface(ctx, i.logger)
+
 	// Create inline script for WiFi status updates
 	updateScript := `#!/bin/bash
-POWER_OUTPUT=$(networksetup -getairportpower en0 2>/dev/null)
+POWER_OUTPUT=$(networksetup -getairportpower ` + wirelessInterface + ` 2>/dev/null)
 if [[ $? -ne 0 ]]; then
     sketchybar --set "$NAME" label="Error" icon="` + icons.WifiOff + `" icon.color="` + colors.Red + `"
     exit 0
@@ -46,7 +58,7 @@ fi
 
 if [[ "$POWER_OUTPUT" == *"On"* ]]; then
     # WiFi is on, try to get SSID
-    SSID_OUTPUT=$(networksetup -getairportnetwork en0 2>/dev/null)
+    SSID_OUTPUT=$(networksetup -getairportnetwork ` + wirelessInterface + ` 2>/dev/null)
     if [[ "$SSID_OUTPUT" == *"Current Wi-Fi Network: "* ]]; then
         SSID=$(echo "$SSID_OUTPUT" | sed 's/Current Wi-Fi Network: //')
         if [[ -n "$SSID" && "$SSID" != *"not associated"* ]]; then
@@ -62,48 +74,46 @@ else
     sketchybar --set "$NAME" label="Off" icon="` + icons.WifiOff + `" icon.color="` + colors.Red + `"
 fi`
 
-	clickScript := `#!/bin/bash
-current=$(networksetup -getairportpower en0 2>/dev/null | grep -o "On\|Off" || echo "Off")
+	updateEvent, err := args.BuildEvent()
+
+	if err != nil {
+		i.logger.Error("wifi: could not generate update event", slog.Any("error", err))
+		return batches, nil
+	}
+
+	// a left click toggles the wifi, a right click opens the popup in wentsketchy
+	clickScript := `current=$(networksetup -getairportpower ` + wirelessInterface + ` 2>/dev/null | grep -o "On\|Off" || echo "Off")
 if [ "$current" = "On" ]; then
-    networksetup -setairportpower en0 off 2>/dev/null
+    networksetup -setairportpower ` + wirelessInterface + ` off 2>/dev/null
     sketchybar --set "$NAME" label="Off" icon="` + icons.WifiOff + `" icon.color="` + colors.Red + `"
 else
-    networksetup -setairportpower en0 on 2>/dev/null
+    networksetup -setairportpower ` + wirelessInterface + ` on 2>/dev/null
     sketchybar --set "$NAME" label="On" icon="` + icons.Wifi + `" icon.color="` + colors.White + `"
 fi
 sleep 1 && sketchybar --trigger wifi_change &`
 
-	wifiItem := sketchybar.ItemOptions{
-		Display: "active",
-		Padding: sketchybar.PaddingOptions{
-			Left:  settings.Sketchybar.ItemSpacing,
-			Right: settings.Sketchybar.ItemSpacing,
-		},
-		Icon: sketchybar.ItemIconOptions{
-			Value: icons.Wifi,
-			Font: sketchybar.FontOptions{
-				Font: settings.FontIcon,
-			},
-			Padding: sketchybar.PaddingOptions{
-				Left:  settings.Sketchybar.IconPadding,
-				Right: pointer(*settings.Sketchybar.IconPadding / 2),
-			},
-		},
-		Label: sketchybar.ItemLabelOptions{
-			Value: "Loading...",
-			Padding: sketchybar.PaddingOptions{
-				Left:  pointer(0),
-				Right: settings.Sketchybar.IconPadding,
-			},
-		},
-		UpdateFreq:  pointer(5), // Check every 5 seconds  
-		Updates:     "on",
-		Script:      updateScript, // Use inline script
-		ClickScript: clickScript,
+	wifiItem, err := sketchybar.NewItem().
+		WithDisplay("active").
+		WithPadding(settings.Sketchybar.ItemSpacing, settings.Sketchybar.ItemSpacing).
+		WithIcon(icons.Wifi, sketchybar.FontOptions{Font: settings.FontIcon}).
+		WithIconPadding(settings.Sketchybar.IconPadding, pointer(*settings.Sketchybar.IconPadding/2)).
+		WithLabel("Loading...").
+		WithLabelPadding(pointer(0), settings.Sketchybar.IconPadding).
+		WithUpdateFreq(5). // Check every 5 seconds
+		WithUpdates("on").
+		WithScript(updateScript). // Use inline script
+		WithClickScript(clickScript).
+		WithRightClickScript(updateEvent).
+		Build()
+
+	if err != nil {
+		i.logger.Error("wifi: could not build item", slog.Any("error", err))
+		return batches, nil
 	}
 
 	batches = batch(batches, s("--add", "item", wifiItemName, position))
-	batches = batch(batches, m(s("--set", wifiItemName), wifiItem.ToArgs()))
+	batches = batch(batches, m(m(s("--set", wifiItemName), wifiItem.ToArgs()), popupArgs()))
+	batches = addWifiPopup(batches)
 	batches = batch(batches, s("--add", "event", "wifi_change"))
 	batches = batch(batches, s("--subscribe", wifiItemName, events.SystemWoke, "wifi_change"))
 
@@ -122,18 +132,24 @@ func (i WifiItem) Update(
 			i.logger.ErrorContext(ctx, "wifi: recovered from panic in Update", slog.Any("panic", r))
 		}
 	}()
-	
+
 	if !isWifi(args.Name) {
 		return batches, nil
 	}
 
+	wirelessInterface := getWirelessInterface(ctx, i.logger)
+
+	if args.Event == events.MouseClicked {
+		return i.toggleWifiPopup(ctx, batches, wirelessInterface), nil
+	}
+
 	if args.Event == "wifi_change" || args.Event == events.SystemWoke {
 		// Run the same logic as the inline script
 		var label, color string
 		icon := icons.Wifi
 
-		powerOutput, err := i.command.Run(ctx, "/usr/sbin/networksetup", "-getairportpower", "en0")
-		
+		powerOutput, err := i.command.Run(ctx, "/usr/sbin/networksetup", "-getairportpower", wirelessInterface)
+
 		if err != nil {
 			label = "Error"
 			color = colors.Red
@@ -144,9 +160,9 @@ func (i WifiItem) Update(
 			icon = icons.WifiOff
 		} else {
 			color = colors.White
-			
-			ssidOutput, ssidErr := i.command.Run(ctx, "/usr/sbin/networksetup", "-getairportnetwork", "en0")
-			
+
+			ssidOutput, ssidErr := i.command.Run(ctx, "/usr/sbin/networksetup", "-getairportnetwork", wirelessInterface)
+
 			if ssidErr != nil {
 				label = "On"
 				color = colors.Yellow
@@ -189,6 +205,20 @@ func (i WifiItem) Update(
 	return batches, nil
 }
 
+// getWirelessInterface falls back to the default interface when networksetup cannot tell the wireless one.
+func getWirelessInterface(ctx context.Context, logger *slog.Logger) string {
+	wirelessInterface, err := network.GetWirelessInterface()
+
+	if err != nil {
+		logger.WarnContext(ctx, "wifi: could not detect wireless interface, using default",
+			slog.String("interface", network.DefaultWirelessInterface),
+			slog.Any("error", err))
+		return network.DefaultWirelessInterface
+	}
+
+	return wirelessInterface
+}
+
 func isWifi(name string) bool {
 	return name == wifiItemName
 }
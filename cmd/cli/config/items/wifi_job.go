@@ -15,60 +15,70 @@ type WifiJob struct {
 	logger     *slog.Logger
 	command    *command.Command
 	sketchybar sketchybar.API
+	// lastInterval is the current poll interval, it grows while networksetup fails.
+	lastInterval time.Duration
 }
 
+const wifiJobInterval = 2 * time.Second
+const wifiJobMaxInterval = 60 * time.Second
+
 func NewWifiJob(logger *slog.Logger, command *command.Command, sketchybar sketchybar.API) *WifiJob {
-	return &WifiJob{logger, command, sketchybar}
+	return &WifiJob{logger, command, sketchybar, wifiJobInterval}
+}
+
+func (j *WifiJob) Name() string {
+	return "wifi"
 }
 
 func (j *WifiJob) Start(ctx context.Context) {
-	go func() {
-		defer func() {
-			if r := recover(); r != nil {
-				j.logger.ErrorContext(ctx, "wifi job: recovered from panic", slog.Any("panic", r))
-				time.Sleep(time.Second * 5)
-				j.logger.InfoContext(ctx, "wifi job: restarting after panic")
-				j.Start(ctx)
-			}
-		}()
-		var lastStatus string
-		ticker := time.NewTicker(2 * time.Second) // Check every 2 seconds
-		defer ticker.Stop()
+	var lastStatus string
+	wirelessInterface := getWirelessInterface(ctx, j.logger)
+	j.lastInterval = wifiJobInterval
+	timer := time.NewTimer(j.lastInterval)
+	defer timer.Stop()
 
-		// Initial check
-		output, err := j.command.Run(ctx, "networksetup", "-getairportpower", "en0")
-		if err != nil {
-			j.logger.Error("wifi job: could not get initial wifi status", "error", err)
-		}
-		lastStatus = strings.TrimSpace(output)
-		// Trigger a refresh on start, so the label is correct
-		err = j.sketchybar.Run(ctx, []string{"--trigger", "wifi_change"})
-		if err != nil {
-			j.logger.Error("wifi job: could not trigger initial event", "error", err)
-		}
+	// Initial check
+	output, err := j.command.Run(ctx, "networksetup", "-getairportpower", wirelessInterface)
+	if err != nil {
+		j.logger.Error("wifi job: could not get initial wifi status", "error", err)
+	}
+	lastStatus = strings.TrimSpace(output)
+	// Trigger a refresh on start, so the label is correct
+	j.sketchybar.RunAsync(ctx, []string{"--trigger", "wifi_change"})
 
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				output, err := j.command.Run(ctx, "networksetup", "-getairportpower", "en0")
-				if err != nil {
-					j.logger.Error("wifi job: could not get wifi status", "error", err)
-					continue
-				}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			output, err := j.command.Run(ctx, "networksetup", "-getairportpower", wirelessInterface)
+			j.lastInterval = nextWifiJobInterval(j.lastInterval, err != nil)
+			timer.Reset(j.lastInterval)
 
-				currentStatus := strings.TrimSpace(output)
-				if currentStatus != lastStatus {
-					err := j.sketchybar.Run(ctx, []string{"--trigger", "wifi_change"})
-					if err != nil {
-						j.logger.Error("wifi job: could not trigger event", "error", err)
-					}
-				}
-				lastStatus = currentStatus
+			if err != nil {
+				j.logger.Error("wifi job: could not get wifi status",
+					"error", err,
+					"retry", j.lastInterval)
+				continue
 			}
+
+			currentStatus := strings.TrimSpace(output)
+			if currentStatus != lastStatus {
+				// not waiting for sketchybar, the next poll is not delayed by the render
+				j.sketchybar.RunAsync(ctx, []string{"--trigger", "wifi_change"})
+			}
+			lastStatus = currentStatus
 		}
-	}()
+	}
+}
+
+// nextWifiJobInterval doubles the interval on every failure up to wifiJobMaxInterval, a success resets it.
+func nextWifiJobInterval(lastInterval time.Duration, failed bool) time.Duration {
+	if !failed {
+		return wifiJobInterval
+	}
+
+	return min(lastInterval*2, wifiJobMaxInterval)
 }
 
-var _ jobs.Job = (*WifiJob)(nil)
\ No newline at end of file
+var _ jobs.Job = (*WifiJob)(nil)
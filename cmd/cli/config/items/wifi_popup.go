@@ -0,0 +1,135 @@
+package items
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+)
+
+const wifiPopupIPItemName = "wifi.ip"
+const wifiPopupGatewayItemName = "wifi.gateway"
+const wifiPopupSubnetItemName = "wifi.subnet"
+const wifiPopupDNSItemName = "wifi.dns"
+
+// wifiPopupUnknown is shown when a command fails, like when wifi is not connected.
+const wifiPopupUnknown = "-"
+
+type wifiPopupRow struct {
+	itemName string
+	title    string
+}
+
+//nolint:gochecknoglobals // ok
+var wifiPopupRows = []wifiPopupRow{
+	{wifiPopupIPItemName, "IP"},
+	{wifiPopupGatewayItemName, "Gateway"},
+	{wifiPopupSubnetItemName, "Subnet"},
+	{wifiPopupDNSItemName, "DNS"},
+}
+
+func addWifiPopup(batches Batches) Batches {
+	for _, row := range wifiPopupRows {
+		rowItem := sketchybar.ItemOptions{
+			Padding: sketchybar.PaddingOptions{
+				Left:  settings.Sketchybar.ItemSpacing,
+				Right: settings.Sketchybar.ItemSpacing,
+			},
+			Icon: sketchybar.ItemIconOptions{
+				Drawing: "off",
+			},
+			Label: sketchybar.ItemLabelOptions{
+				Value: fmt.Sprintf("%s: %s", row.title, wifiPopupUnknown),
+			},
+		}
+
+		batches = batch(batches, s("--add", "item", row.itemName, "popup."+wifiItemName))
+		batches = batch(batches, m(s("--set", row.itemName), rowItem.ToArgs()))
+	}
+
+	return batches
+}
+
+// toggleWifiPopup refreshes the rows only when the popup is being opened.
+func (i WifiItem) toggleWifiPopup(ctx context.Context, batches Batches, wirelessInterface string) Batches {
+	opening := !i.popupOpen.Load()
+	i.popupOpen.Store(opening)
+
+	if opening {
+		values := map[string]string{
+			wifiPopupIPItemName:      i.runWifiPopupCommand(ctx, nil, "/usr/sbin/ipconfig", "getifaddr", wirelessInterface),
+			wifiPopupGatewayItemName: i.runWifiPopupCommand(ctx, parseGateway, "/sbin/route", "-n", "get", "default"),
+			wifiPopupSubnetItemName:  i.runWifiPopupCommand(ctx, nil, "/usr/sbin/ipconfig", "getoption", wirelessInterface, "subnet_mask"),
+			wifiPopupDNSItemName:     i.runWifiPopupCommand(ctx, parseDNSServers, "/usr/sbin/scutil", "--dns"),
+		}
+
+		for _, row := range wifiPopupRows {
+			batches = batch(batches, s("--set", row.itemName, fmt.Sprintf("label=%s: %s", row.title, values[row.itemName])))
+		}
+	}
+
+	return batch(batches, s("--set", wifiItemName, fmt.Sprintf("popup.drawing=%s", onOff(opening))))
+}
+
+// runWifiPopupCommand returns the trimmed output, parsed when parse is not nil.
+func (i WifiItem) runWifiPopupCommand(
+	ctx context.Context,
+	parse func(output string) string,
+	name string,
+	arg ...string,
+) string {
+	output, err := i.command.Run(ctx, name, arg...)
+	if err != nil {
+		i.logger.DebugContext(ctx, "wifi: could not run popup command",
+			slog.String("command", name),
+			slog.Any("error", err))
+		return wifiPopupUnknown
+	}
+
+	value := strings.TrimSpace(output)
+	if parse != nil {
+		value = parse(output)
+	}
+
+	if value == "" {
+		return wifiPopupUnknown
+	}
+	return value
+}
+
+// parseGateway reads the gateway of `route -n get default`.
+func parseGateway(output string) string {
+	// Example: '    gateway: 192.168.1.1'
+	gatewayMatch := regexp.MustCompile(`gateway:\s*(\S+)`).FindStringSubmatch(output)
+	if len(gatewayMatch) < 2 {
+		return ""
+	}
+	return gatewayMatch[1]
+}
+
+// parseDNSServers reads the nameservers of `scutil --dns`, each one once and in order.
+func parseDNSServers(output string) string {
+	// Example: '  nameserver[0] : 1.1.1.1'
+	matches := regexp.MustCompile(`nameserver\[\d+\]\s*:\s*(\S+)`).FindAllStringSubmatch(output, -1)
+
+	servers := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if !slices.Contains(servers, match[1]) {
+			servers = append(servers, match[1])
+		}
+	}
+
+	return strings.Join(servers, ", ")
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,70 @@
+//nolint:testpackage // want to test internals
+package items
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitWifi(t *testing.T) {
+	t.Run("should parse the default gateway", func(t *testing.T) {
+		// GIVEN
+		output := "   route to: default\n" +
+			"destination: default\n" +
+			"       mask: default\n" +
+			"    gateway: 192.168.1.1\n" +
+			"  interface: en0\n"
+
+		// WHEN
+		gateway := parseGateway(output)
+
+		// THEN
+		require.Equal(t, "192.168.1.1", gateway)
+	})
+
+	t.Run("should not parse a gateway without default route", func(t *testing.T) {
+		// WHEN
+		gateway := parseGateway("route: writing to routing socket: not in table\n")
+
+		// THEN
+		require.Empty(t, gateway)
+	})
+
+	t.Run("should parse every dns server once", func(t *testing.T) {
+		// GIVEN
+		output := "DNS configuration\n\n" +
+			"resolver #1\n" +
+			"  nameserver[0] : 1.1.1.1\n" +
+			"  nameserver[1] : 8.8.8.8\n" +
+			"  flags    : Request A records\n\n" +
+			"DNS configuration (for scoped queries)\n\n" +
+			"resolver #1\n" +
+			"  nameserver[0] : 1.1.1.1\n"
+
+		// WHEN
+		servers := parseDNSServers(output)
+
+		// THEN
+		require.Equal(t, "1.1.1.1, 8.8.8.8", servers)
+	})
+
+	t.Run("should double the job interval on failure up to a minute", func(t *testing.T) {
+		// WHEN
+		interval := nextWifiJobInterval(wifiJobInterval, true)
+		maxInterval := nextWifiJobInterval(50*time.Second, true)
+
+		// THEN
+		require.Equal(t, 4*time.Second, interval)
+		require.Equal(t, time.Minute, maxInterval)
+	})
+
+	t.Run("should reset the job interval on success", func(t *testing.T) {
+		// WHEN
+		interval := nextWifiJobInterval(time.Minute, false)
+
+		// THEN
+		require.Equal(t, 2*time.Second, interval)
+	})
+}
@@ -0,0 +1,32 @@
+package settings
+
+import "github.com/lucax88x/wentsketchy/internal/sketchybar"
+
+// AnimationConfig overrides the animation of an item, empty fields keep the item default.
+type AnimationConfig struct {
+	Easing sketchybar.AnimationEasing `yaml:"transition_easing"`
+	Time   string                     `yaml:"transition_time"`
+}
+
+// Animation returns the animation configured for itemName, completed with fallback.
+func (s Settings) Animation(itemName string, fallback AnimationConfig) AnimationConfig {
+	animation, found := s.Animations[itemName]
+
+	if !found {
+		return fallback
+	}
+
+	if animation.Easing == "" {
+		animation.Easing = fallback.Easing
+	}
+	if animation.Time == "" {
+		animation.Time = fallback.Time
+	}
+
+	return animation
+}
+
+// Animate prefixes args with the --animate of the animation.
+func (animation AnimationConfig) Animate(args ...string) []string {
+	return sketchybar.Animate(animation.Easing, animation.Time, args...)
+}
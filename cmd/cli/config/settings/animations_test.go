@@ -0,0 +1,39 @@
+package settings_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitAnimation(t *testing.T) {
+	fallback := settings.AnimationConfig{Easing: sketchybar.AnimationTanh, Time: "15"}
+
+	t.Run("should use the fallback when the item is not configured", func(t *testing.T) {
+		// GIVEN
+		s := settings.Settings{}
+
+		// WHEN
+		animation := s.Animation("media", fallback)
+
+		// THEN
+		require.Equal(t, fallback, animation)
+	})
+
+	t.Run("should complete the configured animation with the fallback", func(t *testing.T) {
+		// GIVEN
+		s := settings.Settings{
+			Animations: map[string]settings.AnimationConfig{
+				"media": {Easing: sketchybar.AnimationSin},
+			},
+		}
+
+		// WHEN
+		animation := s.Animation("media", fallback)
+
+		// THEN
+		require.Equal(t, []string{"--animate", "sin", "15", "--set", "media"}, animation.Animate("--set", "media"))
+	})
+}
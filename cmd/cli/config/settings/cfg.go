@@ -0,0 +1,17 @@
+package settings
+
+// Cfg are the settings overridden by config.yaml, empty fields keep the defaults.
+type Cfg struct {
+	LabelFontSize string
+	IconFontSize  string
+}
+
+// ApplyCfg overrides Sketchybar with cfg.
+func ApplyCfg(cfg *Cfg) {
+	if cfg.LabelFontSize != "" {
+		Sketchybar.LabelFontSize = cfg.LabelFontSize
+	}
+	if cfg.IconFontSize != "" {
+		Sketchybar.IconFontSize = cfg.IconFontSize
+	}
+}
@@ -0,0 +1,23 @@
+package settings_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitApplyCfg(t *testing.T) {
+	t.Run("should override only the configured font sizes", func(t *testing.T) {
+		// GIVEN
+		defaults := settings.Sketchybar
+		t.Cleanup(func() { settings.Sketchybar = defaults })
+
+		// WHEN
+		settings.ApplyCfg(&settings.Cfg{LabelFontSize: "12.0"})
+
+		// THEN
+		require.Equal(t, "12.0", settings.Sketchybar.LabelFontSize)
+		require.Equal(t, defaults.IconFontSize, settings.Sketchybar.IconFontSize)
+	})
+}
@@ -0,0 +1,13 @@
+package colors
+
+import "github.com/lucax88x/wentsketchy/internal/color"
+
+// HexToRGBA is color.HexToRGBA, for the items which already use the colors of this package.
+func HexToRGBA(hex string) (r, g, b, a uint8, err error) {
+	return color.HexToRGBA(hex)
+}
+
+// RGBAToHex is color.RGBAToHex, for the items which already use the colors of this package.
+func RGBAToHex(r, g, b, a uint8) string {
+	return color.RGBAToHex(r, g, b, a)
+}
@@ -0,0 +1,34 @@
+package colors
+
+import (
+	"math"
+
+	"github.com/lucax88x/wentsketchy/internal/color"
+)
+
+// Lerp interpolates each channel of the colors, a at t=0 and b at t=1.
+// t outside of 0 and 1 is clamped.
+func Lerp(a, b string, t float64) (string, error) {
+	fromR, fromG, fromB, fromA, err := color.HexToRGBA(a)
+	if err != nil {
+		return "", err
+	}
+
+	toR, toG, toB, toA, err := color.HexToRGBA(b)
+	if err != nil {
+		return "", err
+	}
+
+	t = math.Max(0, math.Min(1, t))
+
+	return color.RGBAToHex(
+		lerpChannel(fromR, toR, t),
+		lerpChannel(fromG, toG, t),
+		lerpChannel(fromB, toB, t),
+		lerpChannel(fromA, toA, t),
+	), nil
+}
+
+func lerpChannel(from, to uint8, t float64) uint8 {
+	return uint8(math.Round(float64(from) + (float64(to)-float64(from))*t))
+}
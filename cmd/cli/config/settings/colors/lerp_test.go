@@ -0,0 +1,39 @@
+package colors_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitLerp(t *testing.T) {
+	t.Run("should interpolate every channel", func(t *testing.T) {
+		// WHEN
+		color, err := colors.Lerp("0x00000000", "0xff80ff40", 0.5)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "0x80408020", color)
+	})
+
+	t.Run("should return the bounds at 0 and 1", func(t *testing.T) {
+		// WHEN
+		from, err := colors.Lerp(colors.Battery5, colors.Battery1, 0)
+		require.NoError(t, err)
+		to, err := colors.Lerp(colors.Battery5, colors.Battery1, 2)
+		require.NoError(t, err)
+
+		// THEN
+		require.Equal(t, colors.Battery5, from)
+		require.Equal(t, colors.Battery1, to)
+	})
+
+	t.Run("should refuse invalid colors", func(t *testing.T) {
+		// WHEN
+		_, err := colors.Lerp("4caf50", colors.Battery1, 0.5)
+
+		// THEN
+		require.Error(t, err)
+	})
+}
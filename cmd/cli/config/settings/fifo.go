@@ -1,6 +1,9 @@
 package settings
 
-const (
-	FifoPath    = "/tmp/wentsketchy"
-	PidFilePath = "/tmp/wentsketchy.pid"
+import "github.com/lucax88x/wentsketchy/internal/homedir"
+
+//nolint:gochecknoglobals // ok
+var (
+	FifoPath    = homedir.FifoPath()
+	PidFilePath = homedir.PidFilePath()
 )
@@ -1,5 +1,91 @@
 package settings
 
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
 const FontLabel = "SF Pro"
 const FontIcon = "Hack Nerd Font"
 const FontAppIcon = "sketchybar-app-font"
+
+//nolint:gochecknoglobals // ok
+var fontSuggestions = map[string]string{
+	FontLabel:   "brew install --cask font-sf-pro",
+	FontIcon:    "brew install --cask font-hack-nerd-font",
+	FontAppIcon: "download sketchybar-app-font.ttf from github.com/kvndrsslr/sketchybar-app-font/releases",
+}
+
+// WarnMissingFonts warns for every configured font which is not installed,
+// sketchybar would render blank icons and labels instead.
+func WarnMissingFonts(ctx context.Context, logger *slog.Logger) {
+	seen := make(map[string]bool)
+
+	for _, font := range []string{Sketchybar.LabelFont, Sketchybar.IconFont, Sketchybar.IconStripFont} {
+		if font == "" || seen[font] {
+			continue
+		}
+		seen[font] = true
+
+		if checkFontAvailable(font) {
+			continue
+		}
+
+		suggestion, found := fontSuggestions[font]
+		if !found {
+			suggestion = "install it or configure another font"
+		}
+
+		logger.WarnContext(ctx, "settings: font is not installed",
+			slog.String("font", font),
+			slog.String("suggestion", suggestion))
+	}
+}
+
+func checkFontAvailable(name string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// fc-list is only there when fontconfig is installed
+	out, err := exec.CommandContext(ctx, "fc-list", ":", "family").Output()
+	if err == nil && strings.Contains(strings.ToLower(string(out)), strings.ToLower(name)) {
+		return true
+	}
+
+	dirs := []string{"/Library/Fonts", "/System/Library/Fonts", "/System/Library/Fonts/Supplemental"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append([]string{filepath.Join(home, "Library", "Fonts")}, dirs...)
+	}
+
+	return fontInDirs(name, dirs)
+}
+
+// fontInDirs matches font files by name, ignoring case, spaces, dashes and underscores,
+// so that "Hack Nerd Font" matches HackNerdFont-Regular.ttf.
+func fontInDirs(name string, dirs []string) bool {
+	wanted := normalizeFontName(name)
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if strings.Contains(normalizeFontName(entry.Name()), wanted) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func normalizeFontName(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,28 @@
+//nolint:testpackage // want to test internals
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitFonts(t *testing.T) {
+	t.Run("should find font files ignoring spaces and dashes", func(t *testing.T) {
+		// GIVEN
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "HackNerdFont-Regular.ttf"), nil, 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "sketchybar-app-font.ttf"), nil, 0o600))
+
+		// THEN
+		require.True(t, fontInDirs(FontIcon, []string{dir}))
+		require.True(t, fontInDirs(FontAppIcon, []string{dir}))
+		require.False(t, fontInDirs(FontLabel, []string{dir}))
+	})
+
+	t.Run("should skip missing dirs", func(t *testing.T) {
+		require.False(t, fontInDirs(FontIcon, []string{filepath.Join(t.TempDir(), "missing")}))
+	})
+}
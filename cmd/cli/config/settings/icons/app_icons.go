@@ -0,0 +1,78 @@
+package icons
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/command"
+)
+
+const appIconLookupTimeout = 2 * time.Second
+
+// appIconPaths are the paths found by app name, the lookup runs two processes on every front app switch.
+//
+//nolint:gochecknoglobals // ok
+var appIconPaths = make(map[string]string)
+
+//nolint:gochecknoglobals // ok
+var appIconPathsMutex sync.Mutex
+
+// AppIconPath finds the .icns of the app in /Applications, for the apps whose icon "app.<name>" does not find.
+func AppIconPath(ctx context.Context, command *command.Command, appName string) (string, error) {
+	appIconPathsMutex.Lock()
+	path, found := appIconPaths[appName]
+	appIconPathsMutex.Unlock()
+
+	if found {
+		return path, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, appIconLookupTimeout)
+	defer cancel()
+
+	query := fmt.Sprintf("kMDItemDisplayName == '%s.app'", strings.ReplaceAll(appName, "'", `\'`))
+	out, err := command.Run(ctx, "mdfind", "-onlyin", "/Applications", query)
+	if err != nil {
+		return "", fmt.Errorf("icons: could not run mdfind for %s. %w", appName, err)
+	}
+
+	bundle, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	if bundle == "" {
+		return "", fmt.Errorf("icons: %s.app not found in /Applications", appName)
+	}
+
+	infoPlist := filepath.Join(bundle, "Contents", "Info.plist")
+	out, err = command.Run(ctx, "plutil", "-extract", "CFBundleIconFile", "raw", "-o", "-", infoPlist)
+	if err != nil {
+		return "", fmt.Errorf("icons: could not read CFBundleIconFile of %s. %w", bundle, err)
+	}
+
+	path, err = iconPathInBundle(bundle, strings.TrimSpace(out))
+	if err != nil {
+		return "", err
+	}
+
+	appIconPathsMutex.Lock()
+	appIconPaths[appName] = path
+	appIconPathsMutex.Unlock()
+
+	return path, nil
+}
+
+// iconPathInBundle resolves CFBundleIconFile, which can be given without its .icns extension.
+func iconPathInBundle(bundle string, iconFile string) (string, error) {
+	if iconFile == "" {
+		return "", errors.New("icons: CFBundleIconFile is empty")
+	}
+
+	if filepath.Ext(iconFile) == "" {
+		iconFile += ".icns"
+	}
+
+	return filepath.Join(bundle, "Contents", "Resources", iconFile), nil
+}
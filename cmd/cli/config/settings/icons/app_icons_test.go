@@ -0,0 +1,53 @@
+//nolint:testpackage // want to test internals
+package icons
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitAppIcons(t *testing.T) {
+	t.Run("should add the icns extension when missing", func(t *testing.T) {
+		// WHEN
+		path, err := iconPathInBundle("/Applications/Ghostty.app", "Ghostty")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "/Applications/Ghostty.app/Contents/Resources/Ghostty.icns", path)
+	})
+
+	t.Run("should keep the extension when present", func(t *testing.T) {
+		// WHEN
+		path, err := iconPathInBundle("/Applications/Zed.app", "Zed.icns")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "/Applications/Zed.app/Contents/Resources/Zed.icns", path)
+	})
+
+	t.Run("should fail without icon file", func(t *testing.T) {
+		// WHEN
+		_, err := iconPathInBundle("/Applications/Zed.app", "")
+
+		// THEN
+		require.Error(t, err)
+	})
+
+	t.Run("should return the path found before without looking it up", func(t *testing.T) {
+		// GIVEN
+		appIconPaths["Ghostty"] = "/Applications/Ghostty.app/Contents/Resources/Ghostty.icns"
+		t.Cleanup(func() { delete(appIconPaths, "Ghostty") })
+
+		// WHEN
+		// Ghostty is not installed on the build machines, only the cache can find it
+		path, err := AppIconPath(context.Background(), command.NewCommand(testutils.CreateTestLogger()), "Ghostty")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "/Applications/Ghostty.app/Contents/Resources/Ghostty.icns", path)
+	})
+}
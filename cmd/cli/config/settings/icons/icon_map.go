@@ -127,6 +127,7 @@ var App = map[string]IconInfo{
 	"Keynote":                          {Icon: ":keynote:", Font: settings.FontAppIcon},
 	"Keynote 讲演":                       {Icon: ":keynote:", Font: settings.FontAppIcon},
 	"kitty":                            {Icon: ":kitty:", Font: settings.FontAppIcon},
+	"Kitty":                            {Icon: ":kitty:", Font: settings.FontAppIcon},
 	"League of Legends":                {Icon: ":league_of_legends:", Font: settings.FontAppIcon},
 	"LibreWolf":                        {Icon: ":libre_wolf:", Font: settings.FontAppIcon},
 	"Adobe Lightroom":                  {Icon: ":lightroom:", Font: settings.FontAppIcon},
@@ -163,6 +164,8 @@ var App = map[string]IconInfo{
 	"Microsoft Remote Desktop":         {Icon: ":microsoft_remote_desktop:", Font: settings.FontAppIcon},
 	"Microsoft Teams":                  {Icon: ":microsoft_teams:", Font: settings.FontAppIcon},
 	"Microsoft Teams (work or school)": {Icon: ":microsoft_teams:", Font: settings.FontAppIcon},
+	"Microsoft Teams classic":          {Icon: ":microsoft_teams:", Font: settings.FontAppIcon},
+	"Teams":                            {Icon: ":microsoft_teams:", Font: settings.FontAppIcon},
 	"Microsoft Word":                   {Icon: ":microsoft_word:", Font: settings.FontAppIcon},
 	"Min":                              {Icon: ":min_browser:", Font: settings.FontAppIcon},
 	"Miro":                             {Icon: ":miro:", Font: settings.FontAppIcon},
@@ -219,6 +222,7 @@ var App = map[string]IconInfo{
 	"Postman":                          {Icon: ":postman:", Font: settings.FontAppIcon},
 	"Proton Mail":                      {Icon: ":proton_mail:", Font: settings.FontAppIcon},
 	"Proton Mail Bridge":               {Icon: ":proton_mail:", Font: settings.FontAppIcon},
+	"Proxyman":                         {Icon: ":default:", Font: settings.FontAppIcon},
 	"PrusaSlicer":                      {Icon: ":prusaslicer:", Font: settings.FontAppIcon},
 	"SuperSlicer":                      {Icon: ":prusaslicer:", Font: settings.FontAppIcon},
 	"PyCharm":                          {Icon: ":pycharm:", Font: settings.FontAppIcon},
@@ -256,6 +260,7 @@ var App = map[string]IconInfo{
 	"Sublime Text":                     {Icon: ":sublime_text:", Font: settings.FontAppIcon},
 	"superProductivity":                {Icon: ":superproductivity:", Font: settings.FontAppIcon},
 	"Tana":                             {Icon: ":tana:", Font: settings.FontAppIcon},
+	"TablePlus":                        {Icon: ":tableplus:", Font: settings.FontAppIcon},
 	"TeamSpeak 3":                      {Icon: ":team_speak:", Font: settings.FontAppIcon},
 	"Telegram":                         {Icon: ":telegram:", Font: settings.FontAppIcon},
 	"Terminal":                         {Icon: ":terminal:", Font: settings.FontAppIcon},
@@ -283,6 +288,7 @@ var App = map[string]IconInfo{
 	"VLC":                              {Icon: ":vlc:", Font: settings.FontAppIcon},
 	"VMware Fusion":                    {Icon: ":vmware_fusion:", Font: settings.FontAppIcon},
 	"VSCodium":                         {Icon: ":vscodium:", Font: settings.FontAppIcon},
+	"Visual Studio Code":               {Icon: ":code:", Font: settings.FontAppIcon},
 	"Antigravity":                      {Icon: ":code:", Font: settings.FontAppIcon},
 	"Warp":                             {Icon: ":warp:", Font: settings.FontAppIcon},
 	"WebStorm":                         {Icon: ":web_storm:", Font: settings.FontAppIcon},
@@ -24,6 +24,7 @@ const (
 	Video           = "􀍉"
 	Tools           = ""
 	CPU             = "􀫥"
+	Memory          = "􀫦"
 	ThermoMedium    = "􀇬"
 	Documents       = "􀉁"
 	Battery100      = "􀛨"
@@ -39,6 +40,7 @@ const (
 	Bluetooth       = "󰂯"
 	BluetoothOff    = "󰂲"
 	Book            = "􀤞"
+	Database        = ""
 	Power           = "􀷄"
 	None            = ""
 	Unknown         = "󰀧"
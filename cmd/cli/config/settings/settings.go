@@ -1,17 +1,66 @@
 package settings
 
-import "github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+import (
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings/colors"
+)
 
 type AerospaceSettings struct {
 	Padding *int
 
-	WorkspaceBackgroundColor        string
-	WorkspaceColor                  string
+	WorkspaceBackgroundColor string
+	WorkspaceColor           string
+	// WorkspaceAlpha dims WorkspaceColor for the workspaces which are not focused.
+	WorkspaceAlpha                  float64
 	WorkspaceFocusedBackgroundColor string
-	WorkspaceFocusedColor           string
-	WindowColor                     string
-	WindowFocusedColor              string
-	TransitionTime                  string
+	// WorkspaceFocusedBackgroundGradient is the second color of the focused workspace background, empty to disable it.
+	WorkspaceFocusedBackgroundGradient string
+	WorkspaceFocusedColor              string
+	WorkspaceRecentColor               string
+	WindowColor                        string
+	WindowFocusedColor                 string
+	WindowFloatingColor                string
+	TransitionTime                     string
+	ShowMonitorLabels                  bool
+	// FallbackToFirstAppIcon shows the icon of the first app of the workspaces without an icon, instead of hiding them.
+	FallbackToFirstAppIcon bool
+	MonitorLabelColor      string
+	RefreshTimeout         time.Duration
+	// WorkspaceWidth is reached by the entrance animation of new workspaces and pins their width,
+	// nil to only show the icon and keep the dynamic width.
+	WorkspaceWidth *int
+	// WorkspaceStaggerDelay delays the entrance of each workspace after the previous one.
+	WorkspaceStaggerDelay time.Duration
+	WorkspaceHiddenApps   []string
+	// WorkspaceBorderWidth and WorkspaceFocusedBorderWidth are the widths of the workspace bracket border.
+	WorkspaceBorderWidth        *int
+	WorkspaceFocusedBorderWidth *int
+	// WorkspaceSort orders the workspaces of each monitor, the ones not listed follow in their natural order.
+	WorkspaceSort []string
+}
+
+// BatterySettings colors the battery label by level, from the full battery to the empty one.
+// An empty color keeps LabelColor.
+type BatterySettings struct {
+	Level1Color string
+	Level2Color string
+	Level3Color string
+	Level4Color string
+	Level5Color string
+	// Style is how the label shows the percentage, BatteryStyleProgressBar or empty for the number only.
+	Style string
+}
+
+const BatteryStyleProgressBar = "progress_bar"
+
+type FrontAppSettings struct {
+	// IconLookupApps are the apps whose icon is looked up in their bundle, sketchybar does not find every one.
+	IconLookupApps []string
+}
+
+type CalendarSettings struct {
+	Use24Hour bool
 }
 
 type Settings struct {
@@ -19,24 +68,44 @@ type Settings struct {
 	BarHeight           *int
 	BarMargin           *int
 	BarTransitionTime   string
+	BarBlurRadius       *float64
+	BarOpacity          *float64
+	BarRadius           *int
 	ItemHeight          *int
 	ItemSpacing         *int
 	ItemRadius          *int
 	ItemBackgroundColor string
-	ItemBorderColor     string
-	ItemBorderWidth     *int
-	IconPadding         *int
-	LabelColor          string
-	LabelFont           string
-	LabelFontKind       string
-	LabelFontSize       string
-	IconColor           string
-	IconFont            string
-	IconFontKind        string
-	IconFontSize        string
-	IconStripFont       string
-	BarBorderWidth      *int
-	Aerospace           AerospaceSettings
+	// ItemBackgroundColor2 is the second color of the item background gradient, empty to disable it.
+	ItemBackgroundColor2 string
+	ItemBorderColor      string
+	ItemBorderWidth      *int
+	IconPadding          *int
+	LabelColor           string
+	LabelFont            string
+	LabelFontKind        string
+	LabelFontSize        string
+	IconColor            string
+	IconFont             string
+	IconFontKind         string
+	IconFontSize         string
+	IconStripFont        string
+	BarBorderWidth       *int
+	// ItemInitTimeout skips an item whose Init is slower, so it does not keep the whole bar blank.
+	ItemInitTimeout time.Duration
+	Aerospace       AerospaceSettings
+	Battery         BatterySettings
+	Calendar        CalendarSettings
+	FrontApp        FrontAppSettings
+	// Animations are the per item overrides from config.yaml, by item name.
+	Animations map[string]AnimationConfig
+	// Visibility are the per item time windows from config.yaml, by item name.
+	Visibility map[string]VisibilityConfig
+	// Badges are the apps whose dock badge is shown on an item, by sketchybar item name.
+	Badges map[string]string
+	// SparklineSize is how many values the sparklines of the items keep, one character each.
+	SparklineSize int
+	// ShellScripts are the items running a user script, from config.yaml.
+	ShellScripts []ShellScriptConfig
 }
 
 //nolint:gochecknoglobals // ok
@@ -45,6 +114,7 @@ var Sketchybar = Settings{
 	BarHeight:           pointer(40),
 	BarMargin:           pointer(0),
 	BarTransitionTime:   "0",
+	BarRadius:           pointer(0),
 	ItemHeight:          pointer(30),
 	ItemSpacing:         pointer(2),
 	ItemRadius:          pointer(45),
@@ -62,15 +132,30 @@ var Sketchybar = Settings{
 	IconFontSize:        "18.0",
 	IconStripFont:       FontAppIcon,
 	BarBorderWidth:      pointer(0),
+	ItemInitTimeout:     5 * time.Second,
+	SparklineSize:       10,
 	Aerospace: AerospaceSettings{
 		Padding:                         pointer(8),
 		WorkspaceBackgroundColor:        colors.Transparent,
-		WorkspaceColor:                  colors.WhiteA05,
+		WorkspaceColor:                  colors.White,
+		WorkspaceAlpha:                  0.585,
 		WorkspaceFocusedBackgroundColor: colors.White,
 		WorkspaceFocusedColor:           colors.Black,
+		WorkspaceRecentColor:            colors.Blue,
 		WindowColor:                     colors.WhiteA05,
 		WindowFocusedColor:              colors.White,
+		WindowFloatingColor:             colors.Yellow,
 		TransitionTime:                  "5",
+		ShowMonitorLabels:               false,
+		FallbackToFirstAppIcon:          false,
+		MonitorLabelColor:               colors.Background1,
+		RefreshTimeout:                  3 * time.Second,
+		WorkspaceStaggerDelay:           20 * time.Millisecond,
+		WorkspaceBorderWidth:            pointer(2),
+		WorkspaceFocusedBorderWidth:     pointer(3),
+	},
+	Calendar: CalendarSettings{
+		Use24Hour: false,
 	},
 }
 
@@ -0,0 +1,10 @@
+package settings
+
+// ShellScriptConfig is an item showing the stdout of Script as label, its Name is used in the positions.
+type ShellScriptConfig struct {
+	Name   string `yaml:"name"`
+	Script string `yaml:"script"`
+	// UpdateFreq is in seconds, defaults to 5.
+	UpdateFreq int    `yaml:"update_freq"`
+	Icon       string `yaml:"icon"`
+}
@@ -0,0 +1,8 @@
+package settings
+
+// VisibilityConfig shows an item only between From and Until, as "15:04".
+// An empty bound is midnight, a window with Until before From spans midnight.
+type VisibilityConfig struct {
+	From  string `yaml:"visible_from"`
+	Until string `yaml:"visible_until"`
+}
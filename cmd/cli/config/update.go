@@ -2,78 +2,164 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 )
 
+// Update keeps going when an item fails, every failure is part of the returned error.
 func (cfg *Config) Update(
 	ctx context.Context,
 	args *args.In,
 ) error {
 	var batches = make(items.Batches, 0)
+	var errs []error
+
+	lists := []struct {
+		position sketchybar.Position
+		list     []string
+	}{
+		{sketchybar.PositionLeft, cfg.Cfg.Left},
+		{sketchybar.PositionLeftNotch, cfg.Cfg.LeftNotch},
+		{sketchybar.PositionCenter, cfg.Cfg.Center},
+		{sketchybar.PositionRight, reverse(cfg.Cfg.Right)},
+		{sketchybar.PositionRightNotch, reverse(cfg.Cfg.RightNotch)},
+	}
 
-	batches, err := cfg.updateList(ctx, batches, sketchybar.PositionLeft, args, cfg.Cfg.Left)
+	if cfg.Cfg.Parallel {
+		var entries []updateEntry
+		for _, list := range lists {
+			for _, itemName := range list.list {
+				entries = append(entries, updateEntry{list.position, itemName})
+			}
+		}
 
-	if err != nil {
-		return fmt.Errorf("update: left %w", err)
+		batches, errs = cfg.updateParallel(ctx, batches, args, entries)
+	} else {
+		for _, list := range lists {
+			var listErrs []error
+			batches, listErrs = cfg.updateList(ctx, batches, list.position, args, list.list)
+			errs = append(errs, listErrs...)
+		}
 	}
 
-	batches, err = cfg.updateList(ctx, batches, sketchybar.PositionLeftNotch, args, cfg.Cfg.LeftNotch)
+	err := cfg.sketchybar.Run(ctx, items.Flatten(batches.Deduplicate()...))
 
 	if err != nil {
-		return fmt.Errorf("update: left notch %w", err)
+		errs = append(errs, fmt.Errorf("update: apply to sketchybar %w", err))
 	}
 
-	batches, err = cfg.updateList(ctx, batches, sketchybar.PositionCenter, args, cfg.Cfg.Center)
+	return errors.Join(errs...)
+}
 
-	if err != nil {
-		return fmt.Errorf("update: center %w", err)
+func (cfg *Config) updateList(
+	ctx context.Context,
+	batches items.Batches,
+	position sketchybar.Position,
+	args *args.In,
+	list []string,
+) (items.Batches, []error) {
+	var errs []error
+	for _, itemName := range list {
+		var err error
+		batches, err = cfg.updateItem(ctx, batches, position, args, itemName)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return batches, errs
+}
 
-	batches, err = cfg.updateList(ctx, batches, sketchybar.PositionRight, args, reverse(cfg.Cfg.Right))
+type updateEntry struct {
+	position sketchybar.Position
+	itemName string
+}
 
-	if err != nil {
-		return fmt.Errorf("update: right %w", err)
+type updateResult struct {
+	batches items.Batches
+	err     error
+}
+
+// updateParallel runs every update group in its own goroutine,
+// the batches are still appended in the order of the entries.
+func (cfg *Config) updateParallel(
+	ctx context.Context,
+	batches items.Batches,
+	args *args.In,
+	entries []updateEntry,
+) (items.Batches, []error) {
+	groups := make(map[string][]int)
+	for i, entry := range entries {
+		group := cfg.updateGroup(entry.itemName)
+		groups[group] = append(groups[group], i)
 	}
 
-	batches, err = cfg.updateList(ctx, batches, sketchybar.PositionRightNotch, args, reverse(cfg.Cfg.RightNotch))
+	results := make([]updateResult, len(entries))
 
-	if err != nil {
-		return fmt.Errorf("update: right notch %w", err)
+	var wg sync.WaitGroup
+	for _, indexes := range groups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for _, i := range indexes {
+				itemBatches, err := cfg.updateItem(ctx, items.Batches{}, entries[i].position, args, entries[i].itemName)
+				results[i] = updateResult{itemBatches, err}
+			}
+		}()
 	}
+	wg.Wait()
 
-	err = cfg.sketchybar.Run(ctx, items.Flatten(batches...))
+	var errs []error
+	for _, result := range results {
+		batches = append(batches, result.batches...)
 
-	if err != nil {
-		return fmt.Errorf("update: apply to sketchybar %w", err)
+		if result.err != nil {
+			errs = append(errs, result.err)
+		}
 	}
+	return batches, errs
+}
 
-	return nil
+// updateGroup is the group of the item when it has one, else the item is only serialized with itself.
+func (cfg *Config) updateGroup(itemName string) string {
+	if grouper, ok := cfg.IndexedItems[itemName].(items.UpdateGrouper); ok {
+		return "group:" + grouper.UpdateGroup()
+	}
+
+	return "item:" + itemName
 }
 
-func (cfg *Config) updateList(
+func (cfg *Config) updateItem(
 	ctx context.Context,
 	batches items.Batches,
 	position sketchybar.Position,
 	args *args.In,
-	list []string,
+	itemName string,
 ) (items.Batches, error) {
-	var err error
-	for _, itemName := range list {
-		item, found := cfg.IndexedItems[itemName]
+	if cfg.timedOut[itemName] {
+		return batches, nil
+	}
 
-		if found {
-			batches, err = item.Update(ctx, batches, position, args)
+	item, found := cfg.IndexedItems[itemName]
 
-			if err != nil {
-				return batches, fmt.Errorf("init: error while init %s. %w", itemName, err)
-			}
-		} else {
-			return batches, fmt.Errorf("init: did not find %s", itemName)
-		}
+	if !found {
+		return batches, fmt.Errorf("update: did not find %s", itemName)
 	}
+
+	item = items.WithLogging(item, cfg.logger.With(slog.String("item", itemName)))
+
+	batches, err := item.Update(ctx, batches, position, args)
+
+	if err != nil {
+		return batches, fmt.Errorf("update: error while updating %s at %s. %w", itemName, position, err)
+	}
+
 	return batches, nil
 }
@@ -0,0 +1,106 @@
+//nolint:testpackage // want to test internals
+package config
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/args"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type updateItem struct {
+	name  string
+	group string
+	err   error
+	// running counts the items of the group updating right now.
+	running *atomic.Int32
+	overlap *atomic.Bool
+}
+
+func (i updateItem) Init(
+	_ context.Context,
+	_ sketchybar.Position,
+	batches items.Batches,
+) (items.Batches, error) {
+	return batches, nil
+}
+
+func (i updateItem) Update(
+	_ context.Context,
+	batches items.Batches,
+	_ sketchybar.Position,
+	_ *args.In,
+) (items.Batches, error) {
+	if i.running != nil {
+		if i.running.Add(1) > 1 {
+			i.overlap.Store(true)
+		}
+		time.Sleep(5 * time.Millisecond)
+		i.running.Add(-1)
+	}
+
+	return append(batches, []string{"--set", i.name}), i.err
+}
+
+type groupedUpdateItem struct {
+	updateItem
+}
+
+func (i groupedUpdateItem) UpdateGroup() string {
+	return i.group
+}
+
+func TestUnitConfigUpdate(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should keep the order of the items and collect errors when parallel", func(t *testing.T) {
+		// GIVEN
+		cfg := NewConfig(&Cfg{Parallel: true}, logger, nil, items.IndexedWentsketchyItems{
+			"battery": updateItem{name: "battery"},
+			"volume":  updateItem{name: "volume", err: errors.New("no output device")},
+			"wifi":    updateItem{name: "wifi"},
+		}, items.ItemDeps{})
+
+		// WHEN
+		batches, errs := cfg.updateParallel(ctx, items.Batches{}, &args.In{}, []updateEntry{
+			{sketchybar.PositionRight, "battery"},
+			{sketchybar.PositionRight, "volume"},
+			{sketchybar.PositionRight, "wifi"},
+		})
+
+		// THEN
+		require.Equal(t, items.Batches{{"--set", "battery"}, {"--set", "volume"}, {"--set", "wifi"}}, batches)
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "volume")
+	})
+
+	t.Run("should not update items of the same group concurrently", func(t *testing.T) {
+		// GIVEN
+		running, overlap := &atomic.Int32{}, &atomic.Bool{}
+		grouped := func(name string) groupedUpdateItem {
+			return groupedUpdateItem{updateItem{name: name, group: "osascript", running: running, overlap: overlap}}
+		}
+		cfg := NewConfig(&Cfg{Parallel: true}, logger, nil, items.IndexedWentsketchyItems{
+			"volume": grouped("volume"),
+			"media":  grouped("media"),
+		}, items.ItemDeps{})
+
+		// WHEN
+		_, errs := cfg.updateParallel(ctx, items.Batches{}, &args.In{}, []updateEntry{
+			{sketchybar.PositionRight, "volume"},
+			{sketchybar.PositionRight, "media"},
+		})
+
+		// THEN
+		require.Empty(t, errs)
+		require.False(t, overlap.Load())
+	})
+}
@@ -2,9 +2,22 @@ package console
 
 import (
 	"io"
+	"os"
+
+	"golang.org/x/term"
 )
 
 type Console struct {
 	Stdout io.Writer
 	Stderr io.Writer
 }
+
+// IsTerminal is false when Stderr is redirected, like to the log file of a LaunchAgent.
+func (c *Console) IsTerminal() bool {
+	file, ok := c.Stderr.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return term.IsTerminal(int(file.Fd()))
+}
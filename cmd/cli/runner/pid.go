@@ -1,48 +1,108 @@
 package runner
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 )
 
+// pidFile stays open for the lifetime of the process, closing it releases the lock.
+//
+//nolint:gochecknoglobals // ok
+var pidFile *os.File
+
+// CreatePidFile writes the pid of the process and holds an advisory lock on it until RemovePidFile.
+// An existing pid file left by a crash is removed when its process is gone, or taken over when nobody holds its lock.
 func CreatePidFile(path string) error {
-	if _, err := os.Stat(path); err == nil {
-		pidBytes, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("pidfile: could not read pid file: %w", err)
-		}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("pidfile: could not create pid file directory: %w", err)
+	}
 
-		pid, err := strconv.Atoi(string(pidBytes))
-		if err != nil {
-			return fmt.Errorf("pidfile: could not parse pid: %w", err)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if errors.Is(err, os.ErrExist) && isStalePidFile(path) {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("pidfile: could not remove stale pid file: %w", err)
 		}
 
-		process, err := os.FindProcess(pid)
-		if err != nil {
-			// On Unix systems, FindProcess always succeeds and returns a Process
-			// for the given PID, regardless of whether the process exists.
-			// So, this error check is mostly for non-Unix systems.
-		} else {
-			// Sending signal 0 to a process checks if it exists without killing it.
-			if err := process.Signal(syscall.Signal(0)); err == nil {
-				return fmt.Errorf("pidfile: process with pid %d already exists", pid)
-			}
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	}
+	if errors.Is(err, os.ErrExist) {
+		file, err = os.OpenFile(path, os.O_WRONLY, 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("pidfile: could not open pid file: %w", err)
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return fmt.Errorf("pidfile: another process holds %s", path)
 		}
+		return fmt.Errorf("pidfile: could not lock pid file: %w", err)
+	}
+
+	if err := writePid(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	pidFile = file
+	return nil
+}
+
+// isStalePidFile is true when the process of the pid file does not exist anymore,
+// the lock still decides for the ones which do.
+func isStalePidFile(path string) bool {
+	pidBytes, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(pidBytes)))
+	if err != nil {
+		return false
 	}
 
-	pid := os.Getpid()
-	if err := os.WriteFile(path, []byte(strconv.Itoa(pid)), 0644); err != nil {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	// sending signal 0 checks if the process exists without killing it, ESRCH is reported as done
+	err = process.Signal(syscall.Signal(0))
+	return errors.Is(err, os.ErrProcessDone) || errors.Is(err, syscall.ESRCH)
+}
+
+func writePid(file *os.File) error {
+	// a pid file taken over can hold a longer pid
+	if err := file.Truncate(0); err != nil {
+		return fmt.Errorf("pidfile: could not truncate pid file: %w", err)
+	}
+
+	if _, err := file.WriteString(strconv.Itoa(os.Getpid())); err != nil {
 		return fmt.Errorf("pidfile: could not write pid file: %w", err)
 	}
 
 	return nil
 }
 
+// RemovePidFile leaves alone the pid file of another process.
 func RemovePidFile(path string) error {
+	if pidFile == nil {
+		return nil
+	}
+
+	// removed before unlocking, so nobody locks a file which is going away
 	if err := os.Remove(path); err != nil {
 		return fmt.Errorf("pidfile: could not remove pid file: %w", err)
 	}
+
+	pidFile.Close()
+	pidFile = nil
 	return nil
 }
@@ -0,0 +1,64 @@
+package runner_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/runner"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitPidFile(t *testing.T) {
+	t.Run("should write the pid and refuse a second lock", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy", "wentsketchy.pid")
+
+		// WHEN
+		err := runner.CreatePidFile(path)
+		t.Cleanup(func() { _ = runner.RemovePidFile(path) })
+
+		// THEN
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(os.Getpid()), string(content))
+		require.Error(t, runner.CreatePidFile(path))
+	})
+
+	t.Run("should remove a pid file of a process which does not exist", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy.pid")
+		// above the highest pid of linux and macOS
+		require.NoError(t, os.WriteFile(path, []byte("99999999"), 0600))
+
+		// WHEN
+		err := runner.CreatePidFile(path)
+		t.Cleanup(func() { _ = runner.RemovePidFile(path) })
+
+		// THEN
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(os.Getpid()), string(content))
+	})
+
+	t.Run("should take over an unlocked pid file", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy.pid")
+		require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(os.Getppid())), 0600))
+
+		// WHEN
+		err := runner.CreatePidFile(path)
+
+		// THEN
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(os.Getpid()), string(content))
+
+		require.NoError(t, runner.RemovePidFile(path))
+		require.NoFileExists(t, path)
+	})
+}
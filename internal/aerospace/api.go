@@ -2,11 +2,9 @@ package aerospace
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
-	"strings"
 
 	"github.com/lucax88x/wentsketchy/internal/command"
 	"github.com/lucax88x/wentsketchy/internal/utils"
@@ -18,6 +16,7 @@ type WindowID = int
 
 type API interface {
 	Monitors(ctx context.Context) ([]MonitorID, error)
+	ListMonitors(ctx context.Context) ([]Monitor, error)
 	FocusedMonitor(ctx context.Context) (MonitorID, error)
 	FullWorkspaces(ctx context.Context) ([]*FullWorkspace, error)
 	WorkspacesOfMonitor(ctx context.Context, monitorID MonitorID) ([]WorkspaceID, error)
@@ -28,6 +27,8 @@ type API interface {
 	FocusedWorkspaceWindows(ctx context.Context) ([]*Window, error)
 	FocusedMonitorWindows(ctx context.Context) ([]*Window, error)
 	FocusedWindow(ctx context.Context) (WindowID, error)
+	RunFocusWorkspace(ctx context.Context, workspaceID WorkspaceID) error
+	RunMoveWindowToWorkspace(ctx context.Context, windowID WindowID, workspaceID WorkspaceID) error
 }
 
 type realAPI struct {
@@ -58,6 +59,40 @@ func (api realAPI) Monitors(ctx context.Context) ([]MonitorID, error) {
 	return splitAndMapMonitors(output)
 }
 
+func (api realAPI) ListMonitors(ctx context.Context) ([]Monitor, error) {
+	output, err := api.command.Run(
+		ctx,
+		"aerospace",
+		"list-monitors",
+		"--json",
+		"--format",
+		monitorJSONOutputFormat(),
+	)
+
+	if err != nil {
+		return make([]Monitor, 0), fmt.Errorf("aerospace: could not list monitors. %w", err)
+	}
+
+	monitors, err := unmarshalMonitors(output)
+
+	if err != nil {
+		return monitors, err
+	}
+
+	// the json has no focus, it is asked apart
+	focusedMonitorID, err := api.FocusedMonitor(ctx)
+
+	if err != nil {
+		return monitors, fmt.Errorf("aerospace: could not get focused monitor of monitors. %w", err)
+	}
+
+	for i := range monitors {
+		monitors[i].IsFocused = monitors[i].ID == focusedMonitorID
+	}
+
+	return monitors, nil
+}
+
 func (api realAPI) FocusedMonitor(ctx context.Context) (MonitorID, error) {
 	output, err := api.command.Run(
 		ctx,
@@ -199,8 +234,9 @@ func (api realAPI) FullWindows(ctx context.Context) ([]*FullWindow, error) {
 		"aerospace",
 		"list-windows",
 		"--all",
+		"--json",
 		"--format",
-		fullWindowOutputFormat(),
+		fullWindowJSONOutputFormat(),
 	)
 
 	if err != nil {
@@ -210,7 +246,7 @@ func (api realAPI) FullWindows(ctx context.Context) ([]*FullWindow, error) {
 		)
 	}
 
-	return splitAndMapFullWindows(output)
+	return unmarshalFullWindows(output)
 }
 
 func (api realAPI) FocusedWorkspaceWindows(ctx context.Context) ([]*Window, error) {
@@ -259,101 +295,34 @@ func (api realAPI) FocusedWindow(ctx context.Context) (WindowID, error) {
 	return windowIDs[0], nil
 }
 
-func splitAndMap[T any](output string, mapTo func([]string) (T, error)) ([]T, error) {
-	lines := strings.Split(output, "\n")
-
-	var aggregatedErr error
-	var result = make([]T, len(lines)-1)
-	for i, line := range lines {
-		if line == "" {
-			continue
-		}
-
-		mapped, err := mapTo(strings.Split(line, outputFormatSeparator))
-
-		if err != nil {
-			aggregatedErr = errors.Join(aggregatedErr, fmt.Errorf(
-				"aerospace: could not parse line %s. %w",
-				line,
-				err,
-			))
-
-			continue
-		}
+func (api realAPI) RunFocusWorkspace(ctx context.Context, workspaceID WorkspaceID) error {
+	_, err := api.command.Run(
+		ctx,
+		"aerospace",
+		"workspace",
+		workspaceID,
+	)
 
-		result[i] = mapped
+	if err != nil {
+		return fmt.Errorf("aerospace: could not focus workspace %s. %w", workspaceID, err)
 	}
 
-	return result, aggregatedErr
-}
-
-func splitAndMapWindows(output string) ([]*Window, error) {
-	return splitAndMap(output, func(splitted []string) (*Window, error) {
-		id, err := strconv.Atoi(utils.Sanitize(splitted[0]))
-
-		if err != nil {
-			return nil, err
-		}
-
-		return &Window{
-			ID:  id,
-			App: utils.Sanitize(splitted[1]),
-		}, nil
-	})
-}
-
-func splitAndMapFullWindows(output string) ([]*FullWindow, error) {
-	return splitAndMap(output, func(splitted []string) (*FullWindow, error) {
-		id, err := strconv.Atoi(utils.Sanitize(splitted[0]))
-
-		if err != nil {
-			return nil, err
-		}
-
-		monitorID, err := strconv.Atoi(utils.Sanitize(splitted[3]))
-
-		if err != nil {
-			return nil, err
-		}
-
-		return &FullWindow{
-			ID:          id,
-			App:         utils.Sanitize(splitted[1]),
-			WorkspaceID: utils.Sanitize(splitted[2]),
-			MonitorID:   monitorID,
-		}, nil
-	})
-}
-
-func splitAndMapMonitors(output string) ([]MonitorID, error) {
-	return splitAndMap(output, func(splitted []string) (MonitorID, error) {
-		id, err := strconv.Atoi(utils.Sanitize(splitted[0]))
-
-		if err != nil {
-			return 0, err
-		}
-
-		return id, nil
-	})
-}
-
-func splitAndMapWorkspaces(output string) ([]WorkspaceID, error) {
-	return splitAndMap(output, func(splitted []string) (WorkspaceID, error) {
-		return utils.Sanitize(splitted[0]), nil
-	})
+	return nil
 }
 
-func splitAndMapFullWorkspaces(output string) ([]*FullWorkspace, error) {
-	return splitAndMap(output, func(splitted []string) (*FullWorkspace, error) {
-		monitorID, err := strconv.Atoi(utils.Sanitize(splitted[1]))
+func (api realAPI) RunMoveWindowToWorkspace(ctx context.Context, windowID WindowID, workspaceID WorkspaceID) error {
+	_, err := api.command.Run(
+		ctx,
+		"aerospace",
+		"move-node-to-workspace",
+		"--window-id",
+		strconv.Itoa(windowID),
+		workspaceID,
+	)
 
-		if err != nil {
-			return nil, err
-		}
+	if err != nil {
+		return fmt.Errorf("aerospace: could not move window %d to workspace %s. %w", windowID, workspaceID, err)
+	}
 
-		return &FullWorkspace{
-			ID:        utils.Sanitize(splitted[0]),
-			MonitorID: monitorID,
-		}, nil
-	})
+	return nil
 }
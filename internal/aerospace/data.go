@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/singleflight"
@@ -13,34 +14,57 @@ type Aerospace interface {
 	GetTree() *Tree
 	GetPrevWorkspaceID() string
 	SetPrevWorkspaceID(workspaceID string)
+	// GetWorkspaceHistory returns the last focused workspaces, the most recent first.
+	GetWorkspaceHistory() []string
 	GetFocusedWorkspaceID(ctx context.Context) string
 	SetFocusedWorkspaceID(workspaceID string)
+	// ClearFocusedWorkspaceID forgets the focused workspace, the next GetFocusedWorkspaceID asks aerospace.
+	ClearFocusedWorkspaceID()
 	GetFocusedMonitorID(ctx context.Context) int
 	SetFocusedMonitorID(monitorID int)
 	GetFocusedApp() string
 	SetFocusedApp(app string)
 
 	SingleFlightRefreshTree()
+	// AddWindow places a window in the tree without a refresh, false when the workspace is unknown.
+	AddWindow(workspaceID WorkspaceID, window *Window) bool
+	// RemoveWindow drops a window from the tree without a refresh, false when the window is unknown.
+	RemoveWindow(windowID WindowID) bool
+	// MoveWindow moves a known window to another workspace without a refresh.
+	MoveWindow(windowID WindowID, workspaceID WorkspaceID) bool
 
 	FocusedMonitor(ctx context.Context) (MonitorID, error)
+	// ListMonitors asks aerospace, it does not read the tree.
+	ListMonitors(ctx context.Context) ([]Monitor, error)
 	WindowsOfWorkspace(workspaceID string) []*Window
 	WindowsOfFocusedWorkspace(ctx context.Context) (IndexedWindows, error)
 	WindowsOfFocusedMonitor(ctx context.Context) (IndexedWindows, error)
 	FocusedWindow(ctx context.Context) (WindowID, error)
 	AllFullWindows(ctx context.Context) (IndexedFullWindows, error)
+	// FocusWorkspace is meant for a click handler of the workspaces, in place of their click script.
+	FocusWorkspace(ctx context.Context, workspaceID WorkspaceID) error
+	// MoveWindowToWorkspace asks aerospace to move the window, then moves it in the tree too.
+	MoveWindowToWorkspace(ctx context.Context, windowID WindowID, workspaceID WorkspaceID) error
 }
 
+// DefaultRefreshTimeout is how long a refresh of the tree may take before the previous tree is kept.
+const DefaultRefreshTimeout = 3 * time.Second
+
 type Data struct {
 	logger      *slog.Logger
 	api         API
 	treeBuilder TreeBuilder
 
+	RefreshTimeout time.Duration
+
 	prevWorkspaceID    string
 	focusedWorkspaceID string
 	prevMonitorID      int
 	focusedMonitorID   int
 	focusedApp         string
 	tree               *Tree
+	treeMu             sync.RWMutex
+	workspaceHistory   workspaceHistory
 
 	refreshTree *singleflight.Group
 }
@@ -52,27 +76,85 @@ func New(
 ) *Data {
 	var g singleflight.Group
 	return &Data{
-		logger:      logger,
-		api:         api,
-		treeBuilder: treeBuilder,
-		refreshTree: &g,
+		logger:         logger,
+		api:            api,
+		treeBuilder:    treeBuilder,
+		RefreshTimeout: DefaultRefreshTimeout,
+		tree:           EmptyTree(),
+		refreshTree:    &g,
 	}
 }
 
+// SingleFlightRefreshTree waits at most RefreshTimeout, when aerospace hangs the previous tree is kept.
 func (data *Data) SingleFlightRefreshTree() {
 	data.logger.Info("aerospace: refreshing..")
-	_, err, shared := data.refreshTree.Do("refresh-aerospace-tree", data.refreshAerospaceData)
-	data.logger.Info("aerospace: refreshed", slog.Bool("shared", shared))
+	ch := data.refreshTree.DoChan("refresh-aerospace-tree", data.refreshAerospaceData)
 
-	if err != nil {
-		data.logger.Error("aerospace: error while refreshing tree", slog.Any("err", err))
+	select {
+	case result := <-ch:
+		data.logger.Info("aerospace: refreshed", slog.Bool("shared", result.Shared))
+
+		if result.Err != nil {
+			data.logger.Error("aerospace: error while refreshing tree", slog.Any("err", result.Err))
+		}
+	case <-time.After(data.RefreshTimeout):
+		data.logger.Warn(
+			"aerospace: refresh timed out, keeping previous tree",
+			slog.Duration("timeout", data.RefreshTimeout),
+		)
 	}
 }
 
 func (data *Data) GetTree() *Tree {
+	data.treeMu.RLock()
+	defer data.treeMu.RUnlock()
+
 	return data.tree
 }
 
+func (data *Data) AddWindow(workspaceID WorkspaceID, window *Window) bool {
+	data.treeMu.Lock()
+	defer data.treeMu.Unlock()
+
+	tree, found := data.tree.WithWindow(workspaceID, window)
+	if !found {
+		return false
+	}
+
+	data.tree = tree
+	return true
+}
+
+func (data *Data) RemoveWindow(windowID WindowID) bool {
+	data.treeMu.Lock()
+	defer data.treeMu.Unlock()
+
+	if _, found := data.tree.IndexedWindows[windowID]; !found {
+		return false
+	}
+
+	data.tree = data.tree.WithoutWindow(windowID)
+	return true
+}
+
+func (data *Data) MoveWindow(windowID WindowID, workspaceID WorkspaceID) bool {
+	data.treeMu.Lock()
+	defer data.treeMu.Unlock()
+
+	window, found := data.tree.IndexedWindows[windowID]
+	if !found {
+		return false
+	}
+
+	tree, found := data.tree.WithWindow(workspaceID, window)
+	if !found {
+		return false
+	}
+
+	data.tree = tree
+	return true
+}
+
 func (data *Data) GetPrevWorkspaceID() string {
 	return data.prevWorkspaceID
 }
@@ -99,6 +181,15 @@ func (data *Data) SetPrevWorkspaceID(workspaceID string) {
 
 func (data *Data) SetFocusedWorkspaceID(workspaceID string) {
 	data.focusedWorkspaceID = workspaceID
+	data.workspaceHistory.push(workspaceID)
+}
+
+func (data *Data) ClearFocusedWorkspaceID() {
+	data.focusedWorkspaceID = ""
+}
+
+func (data *Data) GetWorkspaceHistory() []string {
+	return data.workspaceHistory.list()
 }
 
 func (data *Data) SetFocusedMonitorID(monitorID int) {
@@ -142,6 +233,42 @@ func (data *Data) FocusedMonitor(ctx context.Context) (MonitorID, error) {
 	return monitorID, nil
 }
 
+// FocusWorkspace gives up after RefreshTimeout, like a refresh.
+func (data *Data) FocusWorkspace(ctx context.Context, workspaceID WorkspaceID) error {
+	ctx, cancel := context.WithTimeout(ctx, data.RefreshTimeout)
+	defer cancel()
+
+	if err := data.api.RunFocusWorkspace(ctx, workspaceID); err != nil {
+		return fmt.Errorf("aerospace: could not focus workspace. %w", err)
+	}
+
+	return nil
+}
+
+// MoveWindowToWorkspace gives up after RefreshTimeout, like a refresh.
+func (data *Data) MoveWindowToWorkspace(ctx context.Context, windowID WindowID, workspaceID WorkspaceID) error {
+	ctx, cancel := context.WithTimeout(ctx, data.RefreshTimeout)
+	defer cancel()
+
+	if err := data.api.RunMoveWindowToWorkspace(ctx, windowID, workspaceID); err != nil {
+		return fmt.Errorf("aerospace: could not move window to workspace. %w", err)
+	}
+
+	// an unknown window is picked up by the next refresh
+	data.MoveWindow(windowID, workspaceID)
+	return nil
+}
+
+func (data *Data) ListMonitors(ctx context.Context) ([]Monitor, error) {
+	monitors, err := data.api.ListMonitors(ctx)
+
+	if err != nil {
+		return monitors, fmt.Errorf("aerospace: could not list monitors. %w", err)
+	}
+
+	return monitors, nil
+}
+
 func (data *Data) WindowsOfFocusedWorkspace(ctx context.Context) (IndexedWindows, error) {
 	windows, err := data.api.FocusedWorkspaceWindows(ctx)
 
@@ -173,14 +300,15 @@ func (data *Data) AllFullWindows(ctx context.Context) (IndexedFullWindows, error
 }
 
 func (data *Data) WindowsOfWorkspace(workspaceID string) []*Window {
-	workspace, found := data.tree.IndexedWorkspaces[workspaceID]
+	tree := data.GetTree()
+	workspace, found := tree.IndexedWorkspaces[workspaceID]
 	if !found {
 		return make([]*Window, 0)
 	}
 
 	windows := make([]*Window, 0, len(workspace.Windows))
 	for _, windowID := range workspace.Windows {
-		window, foundWindow := data.tree.IndexedWindows[windowID]
+		window, foundWindow := tree.IndexedWindows[windowID]
 
 		if !foundWindow {
 			// log
@@ -203,7 +331,8 @@ func (data *Data) FocusedWindow(ctx context.Context) (WindowID, error) {
 }
 
 func (data *Data) refreshAerospaceData() (interface{}, error) {
-	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(context.Background(), data.RefreshTimeout)
+	defer cancel()
 
 	start := time.Now()
 	defer func() {
@@ -217,6 +346,9 @@ func (data *Data) refreshAerospaceData() (interface{}, error) {
 		return false, fmt.Errorf("aerospace: could not refresh tree. %w", err)
 	}
 
+	data.treeMu.Lock()
 	data.tree = tree
+	data.treeMu.Unlock()
+
 	return true, nil
 }
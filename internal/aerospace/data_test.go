@@ -0,0 +1,84 @@
+//nolint:testpackage // want to test internals
+package aerospace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingTreeBuilder struct {
+	release chan struct{}
+}
+
+// Build ignores the context, like an aerospace which does not answer.
+func (b blockingTreeBuilder) Build(_ context.Context) (*Tree, error) {
+	<-b.release
+
+	return &Tree{}, nil
+}
+
+func TestUnitData(t *testing.T) {
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should keep previous tree when refresh times out", func(t *testing.T) {
+		// GIVEN
+		builder := blockingTreeBuilder{release: make(chan struct{})}
+		t.Cleanup(func() { close(builder.release) })
+
+		data := New(logger, nil, builder)
+		data.RefreshTimeout = 10 * time.Millisecond
+		previous := &Tree{}
+		data.tree = previous
+
+		// WHEN
+		start := time.Now()
+		data.SingleFlightRefreshTree()
+
+		// THEN
+		require.Less(t, time.Since(start), time.Second)
+		require.Same(t, previous, data.GetTree())
+	})
+
+	t.Run("should move a window to another workspace without touching the previous tree", func(t *testing.T) {
+		// GIVEN
+		data := New(logger, nil, nil)
+		previous := &Tree{
+			Monitors: []*Branch{{Monitor: 1, Workspaces: []*WorkspaceWithWindowIDs{
+				{Workspace: "1", Windows: []WindowID{10}},
+				{Workspace: "2", Windows: []WindowID{}},
+			}}},
+			IndexedMonitors: IndexedMonitors{1: {Monitor: 1, Workspaces: []WorkspaceID{"1", "2"}}},
+			IndexedWorkspaces: IndexedWorkspaces{
+				"1": {Workspace: "1", Windows: []WindowID{10}},
+				"2": {Workspace: "2", Windows: []WindowID{}},
+			},
+			IndexedWindows: IndexedWindows{10: {ID: 10, App: "Safari"}},
+		}
+		data.tree = previous
+
+		// WHEN
+		added := data.AddWindow("2", &Window{ID: 10, App: "Safari"})
+
+		// THEN
+		require.True(t, added)
+		require.Equal(t, []WindowID{10}, previous.IndexedWorkspaces["1"].Windows)
+		require.Empty(t, data.GetTree().IndexedWorkspaces["1"].Windows)
+		require.Equal(t, []WindowID{10}, data.GetTree().IndexedWorkspaces["2"].Windows)
+		require.Equal(t, []WindowID{10}, data.GetTree().Monitors[0].Workspaces[1].Windows)
+	})
+
+	t.Run("should not remove an unknown window", func(t *testing.T) {
+		// GIVEN
+		data := New(logger, nil, nil)
+
+		// WHEN
+		removed := data.RemoveWindow(10)
+
+		// THEN
+		require.False(t, removed)
+	})
+}
@@ -0,0 +1,119 @@
+package aerospace
+
+import (
+	"slices"
+)
+
+// TreeIDs are ids of workspaces and windows, sorted.
+type TreeIDs struct {
+	Workspaces []WorkspaceID
+	Windows    []WindowID
+}
+
+func (ids TreeIDs) IsEmpty() bool {
+	return len(ids.Workspaces) == 0 && len(ids.Windows) == 0
+}
+
+type TreeDiff struct {
+	Added   TreeIDs
+	Removed TreeIDs
+	// Changed are in both trees: workspaces with other windows or on another monitor,
+	// windows with other properties or in another workspace.
+	Changed TreeIDs
+}
+
+func (diff TreeDiff) IsEmpty() bool {
+	return diff.Added.IsEmpty() && diff.Removed.IsEmpty() && diff.Changed.IsEmpty()
+}
+
+// DiffTree compares the workspaces and windows of two trees, a nil tree is an empty one.
+func DiffTree(oldTree, newTree *Tree) TreeDiff {
+	if oldTree == nil {
+		oldTree = EmptyTree()
+	}
+	if newTree == nil {
+		newTree = EmptyTree()
+	}
+
+	var diff TreeDiff
+
+	oldMonitorOfWorkspace := monitorOfWorkspaces(oldTree)
+	newMonitorOfWorkspace := monitorOfWorkspaces(newTree)
+
+	for workspaceID, newWorkspace := range newTree.IndexedWorkspaces {
+		oldWorkspace, found := oldTree.IndexedWorkspaces[workspaceID]
+
+		switch {
+		case !found:
+			diff.Added.Workspaces = append(diff.Added.Workspaces, workspaceID)
+		case !slices.Equal(oldWorkspace.Windows, newWorkspace.Windows) ||
+			oldMonitorOfWorkspace[workspaceID] != newMonitorOfWorkspace[workspaceID]:
+			diff.Changed.Workspaces = append(diff.Changed.Workspaces, workspaceID)
+		}
+	}
+
+	for workspaceID := range oldTree.IndexedWorkspaces {
+		if _, found := newTree.IndexedWorkspaces[workspaceID]; !found {
+			diff.Removed.Workspaces = append(diff.Removed.Workspaces, workspaceID)
+		}
+	}
+
+	oldWorkspaceOfWindow := workspaceOfWindows(oldTree)
+	newWorkspaceOfWindow := workspaceOfWindows(newTree)
+
+	for windowID, newWindow := range newTree.IndexedWindows {
+		oldWindow, found := oldTree.IndexedWindows[windowID]
+
+		switch {
+		case !found:
+			diff.Added.Windows = append(diff.Added.Windows, windowID)
+		case !sameWindow(oldWindow, newWindow) || oldWorkspaceOfWindow[windowID] != newWorkspaceOfWindow[windowID]:
+			diff.Changed.Windows = append(diff.Changed.Windows, windowID)
+		}
+	}
+
+	for windowID := range oldTree.IndexedWindows {
+		if _, found := newTree.IndexedWindows[windowID]; !found {
+			diff.Removed.Windows = append(diff.Removed.Windows, windowID)
+		}
+	}
+
+	for _, ids := range []*TreeIDs{&diff.Added, &diff.Removed, &diff.Changed} {
+		slices.Sort(ids.Workspaces)
+		slices.Sort(ids.Windows)
+	}
+
+	return diff
+}
+
+func sameWindow(left, right *Window) bool {
+	if left == nil || right == nil {
+		return left == right
+	}
+
+	return *left == *right
+}
+
+func monitorOfWorkspaces(tree *Tree) map[WorkspaceID]MonitorID {
+	result := make(map[WorkspaceID]MonitorID, len(tree.IndexedWorkspaces))
+
+	for _, monitor := range tree.IndexedMonitors {
+		for _, workspaceID := range monitor.Workspaces {
+			result[workspaceID] = monitor.Monitor
+		}
+	}
+
+	return result
+}
+
+func workspaceOfWindows(tree *Tree) map[WindowID]WorkspaceID {
+	result := make(map[WindowID]WorkspaceID, len(tree.IndexedWindows))
+
+	for _, workspace := range tree.IndexedWorkspaces {
+		for _, windowID := range workspace.Windows {
+			result[windowID] = workspace.Workspace
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,199 @@
+//nolint:testpackage // want to test internals
+package aerospace
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitDiffTree(t *testing.T) {
+	// workspaces 1 and 2 are on monitor 1, 3 on monitor 2
+	base := func() *Tree {
+		return createDiffTestTree(
+			map[MonitorID][]WorkspaceID{1: {"1", "2"}, 2: {"3"}},
+			map[WorkspaceID][]WindowID{"1": {10, 11}, "2": {20}, "3": {}},
+		)
+	}
+
+	tests := []struct {
+		name    string
+		oldTree *Tree
+		newTree *Tree
+		diff    TreeDiff
+	}{
+		{
+			name:    "should find no changes between equal trees",
+			oldTree: base(),
+			newTree: base(),
+		},
+		{
+			name: "should find no changes between nil trees",
+		},
+		{
+			name:    "should add everything of a new tree",
+			newTree: base(),
+			diff: TreeDiff{
+				Added: TreeIDs{Workspaces: []WorkspaceID{"1", "2", "3"}, Windows: []WindowID{10, 11, 20}},
+			},
+		},
+		{
+			name:    "should remove everything of a previous tree",
+			oldTree: base(),
+			diff: TreeDiff{
+				Removed: TreeIDs{Workspaces: []WorkspaceID{"1", "2", "3"}, Windows: []WindowID{10, 11, 20}},
+			},
+		},
+		{
+			name:    "should add a window and change its workspace",
+			oldTree: base(),
+			newTree: withWindow(base(), "3", &Window{ID: 30, App: "Slack"}),
+			diff: TreeDiff{
+				Added:   TreeIDs{Windows: []WindowID{30}},
+				Changed: TreeIDs{Workspaces: []WorkspaceID{"3"}},
+			},
+		},
+		{
+			name:    "should remove a window and change its workspace",
+			oldTree: base(),
+			newTree: base().WithoutWindow(11),
+			diff: TreeDiff{
+				Removed: TreeIDs{Windows: []WindowID{11}},
+				Changed: TreeIDs{Workspaces: []WorkspaceID{"1"}},
+			},
+		},
+		{
+			name:    "should change a window moved to another workspace and both workspaces",
+			oldTree: base(),
+			newTree: withWindow(base(), "2", &Window{ID: 10, App: "app 10"}),
+			diff: TreeDiff{
+				Changed: TreeIDs{Workspaces: []WorkspaceID{"1", "2"}, Windows: []WindowID{10}},
+			},
+		},
+		{
+			name:    "should change a window with another title",
+			oldTree: base(),
+			newTree: withWindowProperties(base(), &Window{ID: 20, App: "app 20", Title: "README.md"}),
+			diff: TreeDiff{
+				Changed: TreeIDs{Windows: []WindowID{20}},
+			},
+		},
+		{
+			name:    "should change a window which became floating",
+			oldTree: base(),
+			newTree: withWindowProperties(base(), &Window{ID: 20, App: "app 20", IsFloating: true}),
+			diff: TreeDiff{
+				Changed: TreeIDs{Windows: []WindowID{20}},
+			},
+		},
+		{
+			name:    "should change a workspace with windows in another order",
+			oldTree: base(),
+			newTree: createDiffTestTree(
+				map[MonitorID][]WorkspaceID{1: {"1", "2"}, 2: {"3"}},
+				map[WorkspaceID][]WindowID{"1": {11, 10}, "2": {20}, "3": {}},
+			),
+			diff: TreeDiff{
+				Changed: TreeIDs{Workspaces: []WorkspaceID{"1"}},
+			},
+		},
+		{
+			name:    "should change a workspace moved to another monitor",
+			oldTree: base(),
+			newTree: createDiffTestTree(
+				map[MonitorID][]WorkspaceID{1: {"1"}, 2: {"2", "3"}},
+				map[WorkspaceID][]WindowID{"1": {10, 11}, "2": {20}, "3": {}},
+			),
+			diff: TreeDiff{
+				Changed: TreeIDs{Workspaces: []WorkspaceID{"2"}},
+			},
+		},
+		{
+			name:    "should add a workspace",
+			oldTree: base(),
+			newTree: createDiffTestTree(
+				map[MonitorID][]WorkspaceID{1: {"1", "2"}, 2: {"3", "4"}},
+				map[WorkspaceID][]WindowID{"1": {10, 11}, "2": {20}, "3": {}, "4": {}},
+			),
+			diff: TreeDiff{
+				Added: TreeIDs{Workspaces: []WorkspaceID{"4"}},
+			},
+		},
+		{
+			name:    "should remove a workspace with its windows",
+			oldTree: base(),
+			newTree: createDiffTestTree(
+				map[MonitorID][]WorkspaceID{1: {"1"}, 2: {"3"}},
+				map[WorkspaceID][]WindowID{"1": {10, 11}, "3": {}},
+			),
+			diff: TreeDiff{
+				Removed: TreeIDs{Workspaces: []WorkspaceID{"2"}, Windows: []WindowID{20}},
+			},
+		},
+		{
+			name:    "should add, remove and change at once",
+			oldTree: base(),
+			newTree: createDiffTestTree(
+				map[MonitorID][]WorkspaceID{1: {"1", "2"}, 2: {"4"}},
+				map[WorkspaceID][]WindowID{"1": {10}, "2": {20, 21}, "4": {40}},
+			),
+			diff: TreeDiff{
+				Added:   TreeIDs{Workspaces: []WorkspaceID{"4"}, Windows: []WindowID{21, 40}},
+				Removed: TreeIDs{Workspaces: []WorkspaceID{"3"}, Windows: []WindowID{11}},
+				Changed: TreeIDs{Workspaces: []WorkspaceID{"1", "2"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// WHEN
+			diff := DiffTree(test.oldTree, test.newTree)
+
+			// THEN
+			require.Equal(t, test.diff, diff)
+			require.Equal(t, test.diff.IsEmpty(), diff.IsEmpty())
+		})
+	}
+}
+
+// createDiffTestTree names the app of every window after its id.
+func createDiffTestTree(
+	monitorWorkspaces map[MonitorID][]WorkspaceID,
+	workspaceWindows map[WorkspaceID][]WindowID,
+) *Tree {
+	tree := EmptyTree()
+
+	for monitorID := 1; monitorID <= len(monitorWorkspaces); monitorID++ {
+		branch := &Branch{Monitor: monitorID}
+
+		for _, workspaceID := range monitorWorkspaces[monitorID] {
+			windows := workspaceWindows[workspaceID]
+			for _, windowID := range windows {
+				tree.IndexedWindows[windowID] = &Window{ID: windowID, App: "app " + strconv.Itoa(windowID)}
+			}
+
+			tree.IndexedWorkspaces[workspaceID] = &WorkspaceWithWindowIDs{Workspace: workspaceID, Windows: windows}
+			branch.Workspaces = append(branch.Workspaces, &WorkspaceWithWindowIDs{Workspace: workspaceID, Windows: windows})
+		}
+
+		tree.IndexedMonitors[monitorID] = &MonitorWithWorkspaceIDs{
+			Monitor:    monitorID,
+			Workspaces: monitorWorkspaces[monitorID],
+		}
+		tree.Monitors = append(tree.Monitors, branch)
+	}
+
+	return tree
+}
+
+func withWindow(tree *Tree, workspaceID WorkspaceID, window *Window) *Tree {
+	result, _ := tree.WithWindow(workspaceID, window)
+	return result
+}
+
+func withWindowProperties(tree *Tree, window *Window) *Tree {
+	tree.IndexedWindows[window.ID] = window
+	return tree
+}
@@ -14,3 +14,11 @@ type WorkspaceChangeEventInfo struct {
 	Focused string `json:"focused"`
 	Prev    string `json:"prev"`
 }
+
+// WindowEventInfo is sent with window created, moved and destroyed, destroyed only needs the window id.
+// For moved the workspace is the destination one.
+type WindowEventInfo struct {
+	WindowID  int    `json:"window-id"`
+	Workspace string `json:"workspace"`
+	App       string `json:"app-name"`
+}
@@ -0,0 +1,38 @@
+package aerospace
+
+const workspaceHistorySize = 10
+
+// workspaceHistory is a ring buffer of the last focused workspaces.
+type workspaceHistory struct {
+	ids  [workspaceHistorySize]WorkspaceID
+	next int
+	size int
+}
+
+func (history *workspaceHistory) push(workspaceID WorkspaceID) {
+	if workspaceID == "" || (history.size > 0 && history.latest() == workspaceID) {
+		return
+	}
+
+	history.ids[history.next] = workspaceID
+	history.next = (history.next + 1) % workspaceHistorySize
+
+	if history.size < workspaceHistorySize {
+		history.size++
+	}
+}
+
+func (history *workspaceHistory) latest() WorkspaceID {
+	return history.ids[(history.next-1+workspaceHistorySize)%workspaceHistorySize]
+}
+
+// list returns the history from the most recent workspace to the oldest one.
+func (history *workspaceHistory) list() []WorkspaceID {
+	result := make([]WorkspaceID, 0, history.size)
+
+	for i := 1; i <= history.size; i++ {
+		result = append(result, history.ids[(history.next-i+workspaceHistorySize)%workspaceHistorySize])
+	}
+
+	return result
+}
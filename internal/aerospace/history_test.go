@@ -0,0 +1,41 @@
+//nolint:testpackage // want to test internals
+package aerospace
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitWorkspaceHistory(t *testing.T) {
+	t.Run("should list the most recent workspace first", func(t *testing.T) {
+		// GIVEN
+		var history workspaceHistory
+
+		// WHEN
+		history.push("1")
+		history.push("2")
+		history.push("2")
+		history.push("3")
+
+		// THEN
+		require.Equal(t, []WorkspaceID{"3", "2", "1"}, history.list())
+	})
+
+	t.Run("should keep only the last workspaces", func(t *testing.T) {
+		// GIVEN
+		var history workspaceHistory
+
+		// WHEN
+		for i := range workspaceHistorySize + 2 {
+			history.push(strconv.Itoa(i))
+		}
+
+		// THEN
+		list := history.list()
+		require.Len(t, list, workspaceHistorySize)
+		require.Equal(t, "11", list[0])
+		require.Equal(t, "2", list[workspaceHistorySize-1])
+	})
+}
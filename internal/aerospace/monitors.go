@@ -0,0 +1,16 @@
+package aerospace
+
+type Monitor struct {
+	ID        MonitorID
+	Name      string
+	IsFocused bool
+	// ScreenID is the display of the monitor for sketchybar, 1-based.
+	ScreenID int
+}
+
+// jsonMonitor is a monitor as printed by `aerospace list-monitors --json`.
+type jsonMonitor struct {
+	ID       MonitorID `json:"monitor-id"`
+	Name     string    `json:"monitor-name"`
+	ScreenID int       `json:"monitor-appkit-nsscreen-screens-id"`
+}
@@ -11,11 +11,15 @@ const (
 	outputFormatTab          = "%{tab}"
 	outputFormatWindowID     = "%{window-id}"
 	outputFormatWindowTitle  = "%{window-title}"
+	outputFormatIsFullscreen = "%{window-is-fullscreen}"
+	outputFormatWindowLayout = "%{window-layout}"
 	outputFormatWorkspace    = "%{workspace}"
 	outputFormatMonitorID    = "%{monitor-id}"
 	outputFormatMonitorName  = "%{monitor-name}"
-	outputFormatRightPadding = "%{right-padding}"
-	outputFormatNewline      = "%{newline}"
+	// outputFormatMonitorScreenID is the index of the monitor in NSScreen.screens, stable and the display of sketchybar.
+	outputFormatMonitorScreenID = "%{monitor-appkit-nsscreen-screens-id}"
+	outputFormatRightPadding    = "%{right-padding}"
+	outputFormatNewline         = "%{newline}"
 )
 
 const outputFormatDefaultApp = "%{app-pid}%{right-padding} | %{app-bundle-id}%{right-padding} | %{app-name}"
@@ -36,17 +40,29 @@ func windowOutputFormat() string {
 	)
 }
 
-func fullWindowOutputFormat() string {
+// fullWindowJSONOutputFormat is used together with --json, every variable becomes a key.
+func fullWindowJSONOutputFormat() string {
 	return strings.Join(
 		[]string{
 			outputFormatWindowID,
-			outputFormatSeparator,
 			outputFormatAppName,
-			outputFormatSeparator,
+			outputFormatWindowTitle,
 			outputFormatWorkspace,
-			outputFormatSeparator,
 			outputFormatMonitorID,
-		}, "",
+			outputFormatIsFullscreen,
+			outputFormatWindowLayout,
+		}, " ",
+	)
+}
+
+// monitorJSONOutputFormat is used together with --json, every variable becomes a key.
+func monitorJSONOutputFormat() string {
+	return strings.Join(
+		[]string{
+			outputFormatMonitorID,
+			outputFormatMonitorName,
+			outputFormatMonitorScreenID,
+		}, " ",
 	)
 }
 
@@ -56,6 +72,8 @@ func fullWorkspaceOutputFormat() string {
 			outputFormatWorkspace,
 			outputFormatSeparator,
 			outputFormatMonitorID,
+			outputFormatSeparator,
+			outputFormatMonitorName,
 		}, "",
 	)
 }
@@ -0,0 +1,152 @@
+package aerospace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lucax88x/wentsketchy/internal/utils"
+)
+
+func splitAndMap[T any](output string, mapTo func([]string) (T, error)) ([]T, error) {
+	lines := strings.Split(output, "\n")
+
+	var aggregatedErr error
+	var result = make([]T, 0, len(lines))
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		mapped, err := mapTo(strings.Split(line, outputFormatSeparator))
+
+		if err != nil {
+			aggregatedErr = errors.Join(aggregatedErr, fmt.Errorf(
+				"aerospace: could not parse line %s. %w",
+				line,
+				err,
+			))
+
+			continue
+		}
+
+		result = append(result, mapped)
+	}
+
+	return result, aggregatedErr
+}
+
+func splitAndMapWindows(output string) ([]*Window, error) {
+	return splitAndMap(output, func(splitted []string) (*Window, error) {
+		if err := requireFields(splitted, 2); err != nil {
+			return nil, err
+		}
+
+		id, err := strconv.Atoi(utils.Sanitize(splitted[0]))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Window{
+			ID:  id,
+			App: utils.Sanitize(splitted[1]),
+		}, nil
+	})
+}
+
+func unmarshalFullWindows(output string) ([]*FullWindow, error) {
+	var jsonWindows []jsonWindow
+
+	if err := json.Unmarshal([]byte(output), &jsonWindows); err != nil {
+		return make([]*FullWindow, 0), fmt.Errorf("aerospace: could not deserialize windows. %w", err)
+	}
+
+	windows := make([]*FullWindow, 0, len(jsonWindows))
+	for _, jsonWindow := range jsonWindows {
+		windows = append(windows, &FullWindow{
+			ID:           jsonWindow.ID,
+			App:          jsonWindow.App,
+			Title:        jsonWindow.Title,
+			WorkspaceID:  jsonWindow.WorkspaceID,
+			MonitorID:    jsonWindow.MonitorID,
+			IsFloating:   jsonWindow.Layout == "floating",
+			IsFullscreen: jsonWindow.IsFullscreen,
+		})
+	}
+
+	return windows, nil
+}
+
+func unmarshalMonitors(output string) ([]Monitor, error) {
+	var jsonMonitors []jsonMonitor
+
+	if err := json.Unmarshal([]byte(output), &jsonMonitors); err != nil {
+		return make([]Monitor, 0), fmt.Errorf("aerospace: could not deserialize monitors. %w", err)
+	}
+
+	monitors := make([]Monitor, 0, len(jsonMonitors))
+	for _, jsonMonitor := range jsonMonitors {
+		monitors = append(monitors, Monitor{
+			ID:       jsonMonitor.ID,
+			Name:     jsonMonitor.Name,
+			ScreenID: jsonMonitor.ScreenID,
+		})
+	}
+
+	return monitors, nil
+}
+
+func splitAndMapMonitors(output string) ([]MonitorID, error) {
+	return splitAndMap(output, func(splitted []string) (MonitorID, error) {
+		id, err := strconv.Atoi(utils.Sanitize(splitted[0]))
+
+		if err != nil {
+			return 0, err
+		}
+
+		return id, nil
+	})
+}
+
+func splitAndMapWorkspaces(output string) ([]WorkspaceID, error) {
+	return splitAndMap(output, func(splitted []string) (WorkspaceID, error) {
+		return utils.Sanitize(splitted[0]), nil
+	})
+}
+
+func splitAndMapFullWorkspaces(output string) ([]*FullWorkspace, error) {
+	return splitAndMap(output, func(splitted []string) (*FullWorkspace, error) {
+		if err := requireFields(splitted, 2); err != nil {
+			return nil, err
+		}
+
+		monitorID, err := strconv.Atoi(utils.Sanitize(splitted[1]))
+
+		if err != nil {
+			return nil, err
+		}
+
+		monitorName := ""
+		if len(splitted) > 2 {
+			monitorName = utils.Sanitize(splitted[2])
+		}
+
+		return &FullWorkspace{
+			ID:          utils.Sanitize(splitted[0]),
+			MonitorID:   monitorID,
+			MonitorName: monitorName,
+		}, nil
+	})
+}
+
+// requireFields fails on lines which are not in the output format, like an error message of aerospace.
+func requireFields(splitted []string, count int) error {
+	if len(splitted) < count {
+		return fmt.Errorf("aerospace: expected %d fields, got %d", count, len(splitted))
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+//nolint:testpackage // want to test internals
+package aerospace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// aerospaceError is what aerospace prints instead of the output when its server is not running.
+const aerospaceError = "Can't connect to AeroSpace server. Is AeroSpace.app running?\n"
+
+func TestUnitParseFullWorkspaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		workspaces []*FullWorkspace
+		err        bool
+	}{
+		{
+			name:   "should parse the workspaces of a single monitor",
+			output: "1¬1¬Built-in Retina Display\n2¬1¬Built-in Retina Display\n",
+			workspaces: []*FullWorkspace{
+				{ID: "1", MonitorID: 1, MonitorName: "Built-in Retina Display"},
+				{ID: "2", MonitorID: 1, MonitorName: "Built-in Retina Display"},
+			},
+		},
+		{
+			name:   "should parse the workspaces of multiple monitors",
+			output: "1¬1¬Built-in Retina Display\n2¬2¬LG HDR 4K\n3¬2¬LG HDR 4K\n",
+			workspaces: []*FullWorkspace{
+				{ID: "1", MonitorID: 1, MonitorName: "Built-in Retina Display"},
+				{ID: "2", MonitorID: 2, MonitorName: "LG HDR 4K"},
+				{ID: "3", MonitorID: 2, MonitorName: "LG HDR 4K"},
+			},
+		},
+		{
+			name:       "should parse a workspace without monitor name",
+			output:     "1¬1\n",
+			workspaces: []*FullWorkspace{{ID: "1", MonitorID: 1}},
+		},
+		{
+			name:       "should parse the last line without newline",
+			output:     "1¬1¬Built-in Retina Display",
+			workspaces: []*FullWorkspace{{ID: "1", MonitorID: 1, MonitorName: "Built-in Retina Display"}},
+		},
+		{
+			name:       "should parse no workspaces",
+			output:     "",
+			workspaces: []*FullWorkspace{},
+		},
+		{
+			name:   "should fail on a monitor id which is not a number",
+			output: "1¬main¬Built-in Retina Display\n",
+			err:    true,
+		},
+		{
+			name:   "should fail on an error message of aerospace",
+			output: aerospaceError,
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// WHEN
+			workspaces, err := splitAndMapFullWorkspaces(test.output)
+
+			// THEN
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.workspaces, workspaces)
+		})
+	}
+}
+
+func TestUnitParseWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		windows []*Window
+		err     bool
+	}{
+		{
+			name:    "should parse a workspace with zero windows",
+			output:  "",
+			windows: []*Window{},
+		},
+		{
+			name:   "should parse a workspace with many windows",
+			output: "10¬Safari¬\n11¬kitty¬\n12¬Code¬\n13¬Slack¬\n14¬Spotify¬\n",
+			windows: []*Window{
+				{ID: 10, App: "Safari"},
+				{ID: 11, App: "kitty"},
+				{ID: 12, App: "Code"},
+				{ID: 13, App: "Slack"},
+				{ID: 14, App: "Spotify"},
+			},
+		},
+		{
+			name:    "should parse an app name with special characters",
+			output:  "10¬Microsoft Teams (work or school)¬\n11¬Zoom.us | Meeting¬\n",
+			windows: []*Window{{ID: 10, App: "Microsoft Teams (work or school)"}, {ID: 11, App: "Zoom.us | Meeting"}},
+		},
+		{
+			name:   "should fail on an error message of aerospace",
+			output: aerospaceError,
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// WHEN
+			windows, err := splitAndMapWindows(test.output)
+
+			// THEN
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.windows, windows)
+		})
+	}
+}
+
+func TestUnitParseFullWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		windows []*FullWindow
+		err     bool
+	}{
+		{
+			name: "should deserialize windows of multiple monitors",
+			output: `[
+  {"window-id": 10, "app-name": "Safari", "window-title": "GitHub", "workspace": "1", "monitor-id": 1,
+   "window-is-fullscreen": false, "window-layout": "h_tiles"},
+  {"window-id": 20, "app-name": "kitty", "window-title": "", "workspace": "2", "monitor-id": 2,
+   "window-is-fullscreen": true, "window-layout": "floating"}
+]`,
+			windows: []*FullWindow{
+				{ID: 10, App: "Safari", Title: "GitHub", WorkspaceID: "1", MonitorID: 1},
+				{ID: 20, App: "kitty", WorkspaceID: "2", MonitorID: 2, IsFloating: true, IsFullscreen: true},
+			},
+		},
+		{
+			name:    "should deserialize zero windows",
+			output:  "[]",
+			windows: []*FullWindow{},
+		},
+		{
+			name: "should deserialize special characters in the app name",
+			output: `[
+  {"window-id": 10, "app-name": "Émoji \"Picker\" ✨", "window-title": "naïve ¬ title", "workspace": "1",
+   "monitor-id": 1, "window-is-fullscreen": false, "window-layout": "h_tiles"}
+]`,
+			windows: []*FullWindow{
+				{ID: 10, App: `Émoji "Picker" ✨`, Title: "naïve ¬ title", WorkspaceID: "1", MonitorID: 1},
+			},
+		},
+		{
+			name:   "should fail on the output format of a text list",
+			output: "10¬Safari¬1¬1",
+			err:    true,
+		},
+		{
+			name:   "should fail on an error message of aerospace",
+			output: aerospaceError,
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// WHEN
+			windows, err := unmarshalFullWindows(test.output)
+
+			// THEN
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.windows, windows)
+		})
+	}
+}
+
+func TestUnitParseMonitors(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		monitors []Monitor
+		err      bool
+	}{
+		{
+			name:     "should deserialize a single monitor",
+			output:   `[{"monitor-id": 1, "monitor-name": "Built-in Retina Display", "monitor-appkit-nsscreen-screens-id": 1}]`,
+			monitors: []Monitor{{ID: 1, Name: "Built-in Retina Display", ScreenID: 1}},
+		},
+		{
+			name: "should deserialize multiple monitors",
+			output: `[
+  {"monitor-id": 1, "monitor-name": "LG HDR 4K", "monitor-appkit-nsscreen-screens-id": 2},
+  {"monitor-id": 2, "monitor-name": "Built-in Retina Display", "monitor-appkit-nsscreen-screens-id": 1}
+]`,
+			monitors: []Monitor{
+				{ID: 1, Name: "LG HDR 4K", ScreenID: 2},
+				{ID: 2, Name: "Built-in Retina Display", ScreenID: 1},
+			},
+		},
+		{
+			name:     "should deserialize a monitor without screen id",
+			output:   `[{"monitor-id": 1, "monitor-name": "Built-in Retina Display"}]`,
+			monitors: []Monitor{{ID: 1, Name: "Built-in Retina Display"}},
+		},
+		{
+			name:   "should fail on an error message of aerospace",
+			output: aerospaceError,
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// WHEN
+			monitors, err := unmarshalMonitors(test.output)
+
+			// THEN
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.monitors, monitors)
+		})
+	}
+}
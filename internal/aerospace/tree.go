@@ -3,6 +3,8 @@ package aerospace
 import (
 	"context"
 	"log/slog"
+	"maps"
+	"slices"
 	"sort"
 	"strconv"
 )
@@ -28,13 +30,13 @@ func (t realTreeBuilder) Build(ctx context.Context) (*Tree, error) {
 	fullWorkspaces, err := t.api.FullWorkspaces(ctx)
 
 	if err != nil {
-		return nil, err
+		return EmptyTree(), err
 	}
 
 	fullWindows, err := t.api.FullWindows(ctx)
 
 	if err != nil {
-		return nil, err
+		return EmptyTree(), err
 	}
 
 	indexedMonitors := make(IndexedMonitors, 0)
@@ -53,8 +55,9 @@ func (t realTreeBuilder) Build(ctx context.Context) (*Tree, error) {
 		monitor, foundMonitor := indexedMonitors[fullWorkspace.MonitorID]
 		if !foundMonitor {
 			monitor = &MonitorWithWorkspaceIDs{
-				fullWorkspace.MonitorID,
-				make([]WorkspaceID, 0),
+				Monitor:    fullWorkspace.MonitorID,
+				Name:       fullWorkspace.MonitorName,
+				Workspaces: make([]WorkspaceID, 0),
 			}
 
 			indexedMonitors[fullWorkspace.MonitorID] = monitor
@@ -67,8 +70,11 @@ func (t realTreeBuilder) Build(ctx context.Context) (*Tree, error) {
 
 	for _, fullWindow := range fullWindows {
 		indexedWindows[fullWindow.ID] = &Window{
-			ID:  fullWindow.ID,
-			App: fullWindow.App,
+			ID:           fullWindow.ID,
+			App:          fullWindow.App,
+			Title:        fullWindow.Title,
+			IsFloating:   fullWindow.IsFloating,
+			IsFullscreen: fullWindow.IsFullscreen,
 		}
 
 		workspace, foundWorkspace := indexedWorkspaces[fullWindow.WorkspaceID]
@@ -100,8 +106,9 @@ func (t realTreeBuilder) Build(ctx context.Context) (*Tree, error) {
 		}
 
 		branch := &Branch{
-			Monitor:    monitor.Monitor,
-			Workspaces: branchWorkspaces,
+			Monitor:     monitor.Monitor,
+			MonitorName: monitor.Name,
+			Workspaces:  branchWorkspaces,
 		}
 
 		branches = append(branches, branch)
@@ -160,6 +167,16 @@ type IndexedWorkspaces = map[string]*WorkspaceWithWindowIDs
 type IndexedWindows = map[int]*Window
 type IndexedFullWindows = map[int]*FullWindow
 
+// EmptyTree is a valid tree without monitors, used until aerospace answers.
+func EmptyTree() *Tree {
+	return &Tree{
+		Monitors:          make([]*Branch, 0),
+		IndexedMonitors:   make(IndexedMonitors),
+		IndexedWorkspaces: make(IndexedWorkspaces),
+		IndexedWindows:    make(IndexedWindows),
+	}
+}
+
 type Tree struct {
 	Monitors []*Branch
 
@@ -169,12 +186,14 @@ type Tree struct {
 }
 
 type Branch struct {
-	Monitor    MonitorID
-	Workspaces []*WorkspaceWithWindowIDs
+	Monitor     MonitorID
+	MonitorName string
+	Workspaces  []*WorkspaceWithWindowIDs
 }
 
 type MonitorWithWorkspaceIDs struct {
 	Monitor    MonitorID
+	Name       string
 	Workspaces []WorkspaceID
 }
 
@@ -182,3 +201,90 @@ type WorkspaceWithWindowIDs struct {
 	Workspace WorkspaceID
 	Windows   []WindowID
 }
+
+// WithWindow returns a copy of the tree where the window belongs to the workspace,
+// false when the workspace is not part of the tree.
+func (tree *Tree) WithWindow(workspaceID WorkspaceID, window *Window) (*Tree, bool) {
+	if _, found := tree.IndexedWorkspaces[workspaceID]; !found {
+		return tree, false
+	}
+
+	result := tree.WithoutWindow(window.ID)
+	result.IndexedWindows[window.ID] = window
+
+	workspace := result.IndexedWorkspaces[workspaceID]
+	workspace.Windows = append(workspace.Windows, window.ID)
+
+	for _, branch := range result.Monitors {
+		for _, branchWorkspace := range branch.Workspaces {
+			if branchWorkspace.Workspace == workspaceID {
+				branchWorkspace.Windows = append(branchWorkspace.Windows, window.ID)
+			}
+		}
+	}
+
+	return result, true
+}
+
+// WithoutWindow returns a copy of the tree without the window, the tree itself is never modified.
+func (tree *Tree) WithoutWindow(windowID WindowID) *Tree {
+	result := tree.clone()
+	delete(result.IndexedWindows, windowID)
+
+	for _, workspace := range result.IndexedWorkspaces {
+		workspace.Windows = removeWindowID(workspace.Windows, windowID)
+	}
+
+	for _, branch := range result.Monitors {
+		for _, workspace := range branch.Workspaces {
+			workspace.Windows = removeWindowID(workspace.Windows, windowID)
+		}
+	}
+
+	return result
+}
+
+// clone copies workspaces and windows, monitors are shared because they are never modified.
+func (tree *Tree) clone() *Tree {
+	indexedWorkspaces := make(IndexedWorkspaces, len(tree.IndexedWorkspaces))
+	for workspaceID, workspace := range tree.IndexedWorkspaces {
+		indexedWorkspaces[workspaceID] = &WorkspaceWithWindowIDs{
+			Workspace: workspace.Workspace,
+			Windows:   slices.Clone(workspace.Windows),
+		}
+	}
+
+	branches := make([]*Branch, 0, len(tree.Monitors))
+	for _, branch := range tree.Monitors {
+		branchWorkspaces := make([]*WorkspaceWithWindowIDs, 0, len(branch.Workspaces))
+
+		for _, workspace := range branch.Workspaces {
+			branchWorkspaces = append(branchWorkspaces, &WorkspaceWithWindowIDs{
+				Workspace: workspace.Workspace,
+				Windows:   slices.Clone(workspace.Windows),
+			})
+		}
+
+		branches = append(branches, &Branch{
+			Monitor:     branch.Monitor,
+			MonitorName: branch.MonitorName,
+			Workspaces:  branchWorkspaces,
+		})
+	}
+
+	indexedWindows := make(IndexedWindows, len(tree.IndexedWindows))
+	maps.Copy(indexedWindows, tree.IndexedWindows)
+
+	return &Tree{
+		Monitors:          branches,
+		IndexedMonitors:   tree.IndexedMonitors,
+		IndexedWorkspaces: indexedWorkspaces,
+		IndexedWindows:    indexedWindows,
+	}
+}
+
+func removeWindowID(windows []WindowID, windowID WindowID) []WindowID {
+	return slices.DeleteFunc(windows, func(id WindowID) bool {
+		return id == windowID
+	})
+}
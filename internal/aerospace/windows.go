@@ -1,13 +1,30 @@
 package aerospace
 
 type Window struct {
-	ID  WindowID
-	App string
+	ID           WindowID
+	App          string
+	Title        string
+	IsFloating   bool
+	IsFullscreen bool
 }
 
 type FullWindow struct {
-	ID          WindowID
-	App         string
-	WorkspaceID WorkspaceID
-	MonitorID   MonitorID
+	ID           WindowID
+	App          string
+	Title        string
+	WorkspaceID  WorkspaceID
+	MonitorID    MonitorID
+	IsFloating   bool
+	IsFullscreen bool
+}
+
+// jsonWindow is a window as printed by `aerospace list-windows --json`.
+type jsonWindow struct {
+	ID           WindowID    `json:"window-id"`
+	App          string      `json:"app-name"`
+	Title        string      `json:"window-title"`
+	WorkspaceID  WorkspaceID `json:"workspace"`
+	MonitorID    MonitorID   `json:"monitor-id"`
+	IsFullscreen bool        `json:"window-is-fullscreen"`
+	Layout       string      `json:"window-layout"`
 }
@@ -3,4 +3,6 @@ package aerospace
 type FullWorkspace struct {
 	ID        WorkspaceID
 	MonitorID MonitorID
+	// MonitorName is empty when aerospace did not print it.
+	MonitorName string
 }
@@ -4,6 +4,8 @@ import "time"
 
 type Clock interface {
 	Now() time.Time
+	// Ticker ticks every d until stop is called.
+	Ticker(d time.Duration) (ticks <-chan time.Time, stop func())
 }
 
 const Date = "2006-01-02"
@@ -21,3 +23,8 @@ func NewSystemCock() Clock {
 func (r *SystemCock) Now() time.Time {
 	return time.Now()
 }
+
+func (r *SystemCock) Ticker(d time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
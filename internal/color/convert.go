@@ -0,0 +1,73 @@
+package color
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HexToRGBA parses the sketchybar 0xAARRGGBB and the css #RRGGBBAA, #RRGGBB, #RGBA and #RGB,
+// a css color without alpha is opaque.
+func HexToRGBA(hex string) (r, g, b, a uint8, err error) {
+	if argb, found := strings.CutPrefix(hex, "0x"); found {
+		if len(argb) != len("AARRGGBB") {
+			return 0, 0, 0, 0, fmt.Errorf("color: %q is not 0xAARRGGBB", hex)
+		}
+
+		channels, err := parseChannels(argb)
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("color: could not parse %q. %w", hex, err)
+		}
+
+		return channels[1], channels[2], channels[3], channels[0], nil
+	}
+
+	css, found := strings.CutPrefix(hex, "#")
+	if !found {
+		return 0, 0, 0, 0, fmt.Errorf("color: %q starts with neither 0x nor #", hex)
+	}
+
+	switch len(css) {
+	case len("RGB"), len("RGBA"):
+		var expanded strings.Builder
+		for _, digit := range css {
+			expanded.WriteRune(digit)
+			expanded.WriteRune(digit)
+		}
+		css = expanded.String()
+	case len("RRGGBB"), len("RRGGBBAA"):
+	default:
+		return 0, 0, 0, 0, fmt.Errorf("color: %q is not #RGB, #RGBA, #RRGGBB or #RRGGBBAA", hex)
+	}
+
+	if len(css) == len("RRGGBB") {
+		css += "ff"
+	}
+
+	channels, err := parseChannels(css)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("color: could not parse %q. %w", hex, err)
+	}
+
+	return channels[0], channels[1], channels[2], channels[3], nil
+}
+
+// RGBAToHex formats as sketchybar does, 0xAARRGGBB.
+func RGBAToHex(r, g, b, a uint8) string {
+	return fmt.Sprintf("0x%02x%02x%02x%02x", a, r, g, b)
+}
+
+func parseChannels(hex string) ([4]uint8, error) {
+	var channels [4]uint8
+
+	for i := range channels {
+		channel, err := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return channels, err
+		}
+
+		channels[i] = uint8(channel)
+	}
+
+	return channels, nil
+}
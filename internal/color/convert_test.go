@@ -0,0 +1,45 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/color"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+		want string
+		err  bool
+	}{
+		{name: "should parse sketchybar color", hex: "0xffffffff", want: "0xffffffff"},
+		{name: "should keep sketchybar alpha", hex: "0x80cad3f5", want: "0x80cad3f5"},
+		{name: "should parse short css color as opaque", hex: "#fff", want: "0xffffffff"},
+		{name: "should parse short css color with alpha", hex: "#f008", want: "0x88ff0000"},
+		{name: "should parse css color as opaque", hex: "#4caf50", want: "0xff4caf50"},
+		{name: "should move css alpha first", hex: "#4caf5080", want: "0x804caf50"},
+		{name: "should refuse color without prefix", hex: "ffffff", err: true},
+		{name: "should refuse short sketchybar color", hex: "0xfff", err: true},
+		{name: "should refuse css color of wrong length", hex: "#fffff", err: true},
+		{name: "should refuse non hex digits", hex: "#ggg", err: true},
+		{name: "should refuse empty color", hex: "", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// WHEN
+			r, g, b, a, err := color.HexToRGBA(test.hex)
+
+			// THEN
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.want, color.RGBAToHex(r, g, b, a))
+		})
+	}
+}
@@ -3,10 +3,17 @@ package command
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"syscall"
 	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/platform"
 )
 
 type Command struct {
@@ -19,6 +26,33 @@ func NewCommand(logger *slog.Logger) *Command {
 	}
 }
 
+// ResolveExecutable finds name in the homebrew directories first, since launchd does not have them in PATH,
+// then in PATH. It returns name when nothing is found, so that running it reports the error.
+func ResolveExecutable(name string) string {
+	if path, found := LookupExecutable(name); found {
+		return path
+	}
+
+	return name
+}
+
+// LookupExecutable is ResolveExecutable, false when the executable is not installed.
+func LookupExecutable(name string) (string, bool) {
+	for _, dir := range platform.HomebrewBinDirs() {
+		path := filepath.Join(dir, name)
+
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+			return path, true
+		}
+	}
+
+	if path, err := exec.LookPath(name); err == nil {
+		return path, true
+	}
+
+	return "", false
+}
+
 func (c Command) Run(ctx context.Context, name string, arg ...string) (string, error) {
 	start := time.Now()
 	defer func() {
@@ -42,6 +76,87 @@ func (c Command) Run(ctx context.Context, name string, arg ...string) (string, e
 	return string(out), nil
 }
 
+// RunWithStdin writes stdin to the process, useful for multi-line scripts like "osascript -".
+func (c Command) RunWithStdin(ctx context.Context, stdin string, name string, arg ...string) (string, error) {
+	start := time.Now()
+	defer func() {
+		elapsed := time.Since(start)
+		c.logger.DebugContext(ctx, "command: took", slog.String("name", name), slog.Duration("elapsed", elapsed))
+	}()
+
+	cmd := exec.CommandContext(ctx, name, arg...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	pipe, err := cmd.StdinPipe()
+
+	if err != nil {
+		return "", fmt.Errorf("could not open stdin of '%s'. %w", name, err)
+	}
+
+	err = cmd.Start()
+
+	if err != nil {
+		//nolint:errorlint // no wrap
+		return "", fmt.Errorf("could not run command '%s'. %v", name, err)
+	}
+
+	_, writeErr := io.WriteString(pipe, stdin)
+	if errors.Is(writeErr, syscall.EPIPE) {
+		// the process exited without reading all of stdin, its exit code tells if that is an error
+		writeErr = nil
+	}
+	closeErr := pipe.Close()
+
+	err = cmd.Wait()
+
+	if err != nil {
+		//nolint:errorlint // no wrap
+		return "", fmt.Errorf("could not run command '%s'. %v", name, err)
+	}
+
+	if err = errors.Join(writeErr, closeErr); err != nil {
+		return "", fmt.Errorf("could not write stdin of '%s'. %w", name, err)
+	}
+
+	return out.String(), nil
+}
+
+// RunWithTimeout is like Run, but gives up after timeout.
+func (c Command) RunWithTimeout(
+	ctx context.Context,
+	timeout time.Duration,
+	name string,
+	arg ...string,
+) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return c.Run(ctx, name, arg...)
+}
+
+// RunAsync starts the process and returns, its exit code is only logged.
+// It is not stopped when ctx is done, timeout still kills it.
+func (c Command) RunAsync(ctx context.Context, timeout time.Duration, name string, arg ...string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	cmd := exec.CommandContext(ctx, name, arg...)
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return fmt.Errorf("could not start command '%s'. %w", name, err)
+	}
+
+	go func() {
+		defer cancel()
+
+		if err := cmd.Wait(); err != nil {
+			c.logger.DebugContext(ctx, "command: async command failed", slog.String("name", name), slog.Any("error", err))
+		}
+	}()
+
+	return nil
+}
+
 func (c Command) RunBufferized(ctx context.Context, name string, arg ...string) (bytes.Buffer, error) {
 	cmd := exec.CommandContext(ctx, name, arg...)
 	var out bytes.Buffer
@@ -54,3 +169,37 @@ func (c Command) RunBufferized(ctx context.Context, name string, arg ...string)
 
 	return out, nil
 }
+
+// RunBufferizedCombined is RunBufferized with stderr written to the same buffer, interleaved like in a terminal.
+func (c Command) RunBufferizedCombined(ctx context.Context, name string, arg ...string) (bytes.Buffer, error) {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+
+	if err != nil {
+		return out, fmt.Errorf("could not run command %w", err)
+	}
+
+	return out, nil
+}
+
+// RunBufferizedSplit is RunBufferized keeping stderr apart, the buffers are returned on error too
+// so that stderr can explain it.
+func (c Command) RunBufferizedSplit(
+	ctx context.Context,
+	name string,
+	arg ...string,
+) (stdout bytes.Buffer, stderr bytes.Buffer, err error) {
+	cmd := exec.CommandContext(ctx, name, arg...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+
+	if err != nil {
+		return stdout, stderr, fmt.Errorf("could not run command %w", err)
+	}
+
+	return stdout, stderr, nil
+}
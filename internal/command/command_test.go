@@ -0,0 +1,133 @@
+package command_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCommand(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass stdin to the process", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+		script := "first line\nsecond line\n"
+
+		// WHEN
+		out, err := cmd.RunWithStdin(ctx, script, "cat")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, script, out)
+	})
+
+	t.Run("should fail when the process fails", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+
+		// WHEN
+		_, err := cmd.RunWithStdin(ctx, "", "sh", "-c", "exit 1")
+
+		// THEN
+		require.Error(t, err)
+	})
+
+	t.Run("should not fail when the process ignores stdin", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+
+		// WHEN
+		out, err := cmd.RunWithStdin(ctx, "ignored", "echo", "hello")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "hello\n", out)
+	})
+
+	t.Run("should capture stdout and stderr in the same buffer", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+
+		// WHEN
+		out, err := cmd.RunBufferizedCombined(ctx, "sh", "-c", "echo out; echo err >&2")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "out\nerr\n", out.String())
+	})
+
+	t.Run("should keep stderr apart when the process fails", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+
+		// WHEN
+		stdout, stderr, err := cmd.RunBufferizedSplit(ctx, "sh", "-c", "echo out; echo err >&2; exit 1")
+
+		// THEN
+		require.Error(t, err)
+		require.Equal(t, "out\n", stdout.String())
+		require.Equal(t, "err\n", stderr.String())
+	})
+
+	t.Run("should not wait for async processes", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+		start := time.Now()
+
+		// WHEN
+		err := cmd.RunAsync(ctx, 5*time.Second, "sleep", "1")
+
+		// THEN
+		require.NoError(t, err)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should fail when the async process cannot start", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+
+		// WHEN
+		err := cmd.RunAsync(ctx, time.Second, "wentsketchy-does-not-exist")
+
+		// THEN
+		require.Error(t, err)
+	})
+
+	t.Run("should resolve executables from path", func(t *testing.T) {
+		// WHEN
+		path := command.ResolveExecutable("sh")
+
+		// THEN
+		require.True(t, filepath.IsAbs(path))
+		require.Equal(t, "sh", filepath.Base(path))
+	})
+
+	t.Run("should return the name of missing executables", func(t *testing.T) {
+		require.Equal(t, "wentsketchy-missing", command.ResolveExecutable("wentsketchy-missing"))
+	})
+
+	t.Run("should not find missing executables", func(t *testing.T) {
+		// WHEN
+		_, found := command.LookupExecutable("wentsketchy-missing")
+
+		// THEN
+		require.False(t, found)
+	})
+
+	t.Run("should give up after the timeout", func(t *testing.T) {
+		// GIVEN
+		cmd := command.NewCommand(testutils.CreateTestLogger())
+
+		// WHEN
+		_, err := cmd.RunWithTimeout(ctx, 50*time.Millisecond, "sleep", "5")
+
+		// THEN
+		require.Error(t, err)
+	})
+}
@@ -2,6 +2,7 @@ package encoding
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"strings"
 	"unicode/utf8"
@@ -28,11 +29,11 @@ func DecodeAppleScriptOutput(input []byte) (string, error) {
 		var output []byte
 		output, err = io.ReadAll(reader)
 		if err != nil {
-			return "", err
+			return "", fmt.Errorf("decoding %d bytes of AppleScript output: %w", len(input), err)
 		}
 		decoded = string(output)
 	}
 
 	// Sanitize the string to remove any invalid UTF-8 characters as a final safety measure.
 	return strings.ToValidUTF8(decoded, ""), nil
-}
\ No newline at end of file
+}
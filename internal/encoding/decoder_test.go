@@ -0,0 +1,46 @@
+package encoding_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/encoding"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitDecodeAppleScriptOutput(t *testing.T) {
+	t.Run("should decode empty input", func(t *testing.T) {
+		// WHEN
+		result, err := encoding.DecodeAppleScriptOutput([]byte{})
+
+		// THEN
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("should trim and keep valid utf-8", func(t *testing.T) {
+		// WHEN
+		result, err := encoding.DecodeAppleScriptOutput([]byte("  Café – Señor\n"))
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "Café – Señor", result)
+	})
+
+	t.Run("should fall back to mac roman for long input", func(t *testing.T) {
+		// GIVEN
+		// 0x8E is é and 0x96 is ñ in MacRoman, both invalid as standalone utf-8 bytes
+		chunk := []byte{'C', 'a', 'f', 0x8E, ' ', 'S', 'e', 0x96, 'o', 'r', ' '}
+		input := make([]byte, 0, len(chunk)*10000)
+		for range 10000 {
+			input = append(input, chunk...)
+		}
+
+		// WHEN
+		result, err := encoding.DecodeAppleScriptOutput(input)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, strings.TrimSpace(strings.Repeat("Café Señor ", 10000)), result)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -28,6 +29,9 @@ func NewFifoReader(logger *slog.Logger) *Reader {
 func (f *Reader) makeSureFifoExists(path string) error {
 	stat, err := os.Stat(path)
 	if err == nil {
+		if stat.IsDir() {
+			return fmt.Errorf("fifo: path %s is a directory, not a named pipe", path)
+		}
 		if stat.Mode()&os.ModeNamedPipe == 0 {
 			f.logger.WarnContext(
 				context.Background(),
@@ -36,14 +40,19 @@ func (f *Reader) makeSureFifoExists(path string) error {
 			)
 		}
 		if err := os.Remove(path); err != nil {
-			return fmt.Errorf("fifo: could not remove existing file: %w", err)
+			return fmt.Errorf("fifo: could not remove existing file %s: %w", path, err)
 		}
 	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("fifo: could not stat file: %w", err)
+		return fmt.Errorf("fifo: could not stat file %s: %w", path, err)
+	}
+
+	// the config dir may not exist yet when it is overridden
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("fifo: could not create directory of %s: %w", path, err)
 	}
 
 	if err := syscall.Mkfifo(path, 0640); err != nil {
-		return fmt.Errorf("fifo: could not create fifo file: %w", err)
+		return fmt.Errorf("fifo: could not create fifo file %s: %w", path, err)
 	}
 	f.logger.InfoContext(context.Background(), "fifo: successfully created fifo file", slog.String("path", path))
 	return nil
@@ -327,4 +336,4 @@ func (f *Reader) ensureClose(path string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
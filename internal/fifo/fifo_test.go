@@ -0,0 +1,101 @@
+//nolint:testpackage // want to test internals
+package fifo
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitFifo(t *testing.T) {
+	logger := testutils.CreateTestLogger()
+	reader := NewFifoReader(logger)
+
+	t.Run("should create fifo when path does not exist", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy")
+
+		// WHEN
+		err := reader.makeSureFifoExists(path)
+
+		// THEN
+		require.NoError(t, err)
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NotZero(t, stat.Mode()&os.ModeNamedPipe)
+	})
+
+	t.Run("should recreate fifo when path is a regular file", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy")
+		require.NoError(t, os.WriteFile(path, []byte("not a fifo"), 0600))
+
+		// WHEN
+		err := reader.makeSureFifoExists(path)
+
+		// THEN
+		require.NoError(t, err)
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NotZero(t, stat.Mode()&os.ModeNamedPipe)
+	})
+
+	t.Run("should fail when path is a directory", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy")
+		require.NoError(t, os.MkdirAll(path, 0750))
+
+		// WHEN
+		err := reader.makeSureFifoExists(path)
+
+		// THEN
+		require.Error(t, err)
+		require.Contains(t, err.Error(), path)
+		stat, err := os.Stat(path)
+		require.NoError(t, err)
+		require.True(t, stat.IsDir())
+	})
+}
+
+func TestUnitFifoWriter(t *testing.T) {
+	logger := testutils.CreateTestLogger()
+	reader := NewFifoReader(logger)
+	writer := NewFifoWriter(logger)
+
+	t.Run("should write message with separator", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy")
+		require.NoError(t, reader.makeSureFifoExists(path))
+
+		file, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+		require.NoError(t, err)
+		defer file.Close()
+
+		// WHEN
+		err = writer.Write(path, "aerospace_refresh")
+
+		// THEN
+		require.NoError(t, err)
+		data, err := io.ReadAll(bufio.NewReader(file))
+		require.NoError(t, err)
+		require.Equal(t, "aerospace_refresh"+string(Separator), string(data))
+	})
+
+	t.Run("should fail when nobody is listening", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "wentsketchy")
+		require.NoError(t, reader.makeSureFifoExists(path))
+
+		// WHEN
+		err := writer.Write(path, "aerospace_refresh")
+
+		// THEN
+		require.ErrorContains(t, err, "nobody is listening")
+	})
+}
@@ -0,0 +1,44 @@
+package fifo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"syscall"
+)
+
+type Writer struct {
+	logger *slog.Logger
+}
+
+func NewFifoWriter(logger *slog.Logger) *Writer {
+	return &Writer{
+		logger,
+	}
+}
+
+// Write sends msg terminated by the Separator, it fails instead of blocking when nobody reads the fifo.
+func (f *Writer) Write(path string, msg string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+
+	if errors.Is(err, syscall.ENXIO) {
+		return fmt.Errorf("fifo: nobody is listening on %s, is wentsketchy started?", path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("fifo: could not open %s: %w", path, err)
+	}
+
+	defer func() {
+		if err := file.Close(); err != nil {
+			f.logger.Error("fifo: could not close writer", slog.Any("error", err))
+		}
+	}()
+
+	if _, err := file.WriteString(msg + string(Separator)); err != nil {
+		return fmt.Errorf("fifo: could not write to %s: %w", path, err)
+	}
+
+	return nil
+}
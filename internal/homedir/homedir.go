@@ -2,9 +2,17 @@ package homedir
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 )
 
+const configDirEnvKey = "WENTSKETCHY_CONFIG_DIR"
+const xdgConfigHomeEnvKey = "XDG_CONFIG_HOME"
+
+// fallbackDir is used for the fifo and the pid file when there is no config dir.
+const fallbackDir = "/tmp"
+
 //nolint:gochecknoglobals //ok
 var envKeys = []string{
 	"HOME",
@@ -23,6 +31,60 @@ func Get() (string, error) {
 	return "", errors.New("homedir: could not provide homedir. %w")
 }
 
+// ConfigDir is WENTSKETCHY_CONFIG_DIR when set, else $XDG_CONFIG_HOME/wentsketchy, else ~/.wentsketchy.
+func ConfigDir() (string, error) {
+	if dir, exists := os.LookupEnv(configDirEnvKey); exists && dir != "" {
+		return dir, nil
+	}
+
+	if dir, exists := os.LookupEnv(xdgConfigHomeEnvKey); exists && dir != "" {
+		return filepath.Join(dir, "wentsketchy"), nil
+	}
+
+	dir, err := Get()
+
+	if err != nil {
+		return "", fmt.Errorf("homedir: could not provide config dir. %w", err)
+	}
+
+	return filepath.Join(dir, ".wentsketchy"), nil
+}
+
+// FifoPath is the fifo in the config dir, sketchybar and aerospace write the events there.
+func FifoPath() string {
+	return inConfigDir("wentsketchy.fifo")
+}
+
+func PidFilePath() string {
+	return inConfigDir("wentsketchy.pid")
+}
+
+// ReplayLogPath keeps the last messages of the fifo between runs.
+func ReplayLogPath() string {
+	return inConfigDir("replay.log")
+}
+
+// PluginsDir holds the items built with -buildmode=plugin, there is no fallback for it.
+func PluginsDir() (string, error) {
+	dir, err := ConfigDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "plugins"), nil
+}
+
+func inConfigDir(name string) string {
+	dir, err := ConfigDir()
+
+	if err != nil {
+		return filepath.Join(fallbackDir, name)
+	}
+
+	return filepath.Join(dir, name)
+}
+
 func tryEnvs(envKeys []string) (string, bool) {
 	for _, envKey := range envKeys {
 		pathToTry, exists := os.LookupEnv(envKey)
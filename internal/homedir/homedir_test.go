@@ -0,0 +1,67 @@
+package homedir_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/homedir"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitConfigDir(t *testing.T) {
+	t.Run("should use WENTSKETCHY_CONFIG_DIR over XDG_CONFIG_HOME", func(t *testing.T) {
+		// GIVEN
+		t.Setenv("WENTSKETCHY_CONFIG_DIR", "/ci/wentsketchy")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg")
+
+		// WHEN
+		dir, err := homedir.ConfigDir()
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "/ci/wentsketchy", dir)
+	})
+
+	t.Run("should use XDG_CONFIG_HOME", func(t *testing.T) {
+		// GIVEN
+		t.Setenv("WENTSKETCHY_CONFIG_DIR", "")
+		t.Setenv("XDG_CONFIG_HOME", "/xdg")
+
+		// WHEN
+		dir, err := homedir.ConfigDir()
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join("/xdg", "wentsketchy"), dir)
+	})
+
+	t.Run("should fall back to .wentsketchy in the home dir", func(t *testing.T) {
+		// GIVEN
+		home := t.TempDir()
+		t.Setenv("WENTSKETCHY_CONFIG_DIR", "")
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("HOME", home)
+
+		// WHEN
+		dir, err := homedir.ConfigDir()
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(home, ".wentsketchy"), dir)
+	})
+}
+
+func TestUnitFifoPath(t *testing.T) {
+	t.Run("should put the fifo and the pid file in the config dir", func(t *testing.T) {
+		// GIVEN
+		t.Setenv("WENTSKETCHY_CONFIG_DIR", "/ci/wentsketchy")
+
+		// WHEN
+		fifoPath := homedir.FifoPath()
+		pidFilePath := homedir.PidFilePath()
+
+		// THEN
+		require.Equal(t, filepath.Join("/ci/wentsketchy", "wentsketchy.fifo"), fifoPath)
+		require.Equal(t, filepath.Join("/ci/wentsketchy", "wentsketchy.pid"), pidFilePath)
+	})
+}
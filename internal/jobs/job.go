@@ -3,5 +3,9 @@ package jobs
 import "context"
 
 type Job interface {
+	// Name identifies the job in logs.
+	Name() string
+	// Start blocks until ctx is done, the Manager runs it in its own goroutine.
+	// A job with nothing to do returns early and is not restarted.
 	Start(ctx context.Context)
 }
@@ -0,0 +1,192 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusStopped Status = "stopped"
+	StatusRunning Status = "running"
+	StatusFailed  Status = "failed"
+)
+
+const (
+	restartBaseDelay = time.Second
+	restartMaxDelay  = time.Minute
+)
+
+// Manager runs every job in its own goroutine, a job which panics is restarted with backoff
+// while a job which returns is done.
+type Manager struct {
+	logger *slog.Logger
+	jobs   []Job
+
+	// restartBaseDelay is the first delay before restarting a job, doubled on consecutive failures.
+	restartBaseDelay time.Duration
+
+	mutex    sync.Mutex
+	statuses []Status
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewManager(logger *slog.Logger, jobs []Job) *Manager {
+	statuses := make([]Status, len(jobs))
+	for i := range statuses {
+		statuses[i] = StatusStopped
+	}
+
+	return &Manager{
+		logger:           logger,
+		jobs:             jobs,
+		restartBaseDelay: restartBaseDelay,
+		statuses:         statuses,
+	}
+}
+
+// Validate returns an error for every job which has not been wired.
+func (m *Manager) Validate() error {
+	if len(m.jobs) == 0 {
+		return errors.New("jobs: no jobs")
+	}
+
+	var errs []error
+	for i, job := range m.jobs {
+		if job == nil {
+			errs = append(errs, fmt.Errorf("jobs: job %d is nil", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m *Manager) Start(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.cancel != nil {
+		return errors.New("jobs: already started")
+	}
+
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	for i, job := range m.jobs {
+		m.logger.InfoContext(ctx, "jobs: starting job", slog.String("job", job.Name()))
+		m.statuses[i] = StatusRunning
+		m.wg.Add(1)
+		go m.run(ctx, i)
+	}
+
+	m.logger.InfoContext(ctx, "jobs: started", slog.Int("jobs", len(m.jobs)))
+
+	return nil
+}
+
+// Stop cancels every job and waits for them to return.
+func (m *Manager) Stop() error {
+	m.mutex.Lock()
+	cancel := m.cancel
+	m.cancel = nil
+	m.mutex.Unlock()
+
+	if cancel == nil {
+		return errors.New("jobs: not started")
+	}
+
+	cancel()
+	m.wg.Wait()
+
+	return nil
+}
+
+// Statuses are in the order of the jobs given to NewManager.
+func (m *Manager) Statuses() []Status {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	statuses := make([]Status, len(m.statuses))
+	copy(statuses, m.statuses)
+
+	return statuses
+}
+
+func (m *Manager) run(ctx context.Context, index int) {
+	defer m.wg.Done()
+
+	failures := 0
+	for {
+		if !m.runSafely(ctx, index) {
+			if ctx.Err() == nil {
+				m.logger.InfoContext(ctx, "jobs: job done", slog.String("job", m.jobs[index].Name()))
+			}
+
+			m.setStatus(index, StatusStopped)
+			return
+		}
+
+		failures++
+
+		if ctx.Err() != nil {
+			m.setStatus(index, StatusStopped)
+			return
+		}
+
+		delay := restartDelay(m.restartBaseDelay, failures)
+		m.logger.WarnContext(ctx, "jobs: restarting job",
+			slog.String("job", m.jobs[index].Name()),
+			slog.Int("failures", failures),
+			slog.Duration("delay", delay))
+
+		select {
+		case <-ctx.Done():
+			m.setStatus(index, StatusStopped)
+			return
+		case <-time.After(delay):
+		}
+
+		m.setStatus(index, StatusRunning)
+	}
+}
+
+// runSafely returns true when the job panicked.
+func (m *Manager) runSafely(ctx context.Context, index int) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.ErrorContext(ctx, "jobs: recovered from panic", slog.String("job", m.jobs[index].Name()), slog.Any("panic", r))
+			m.setStatus(index, StatusFailed)
+			panicked = true
+		}
+	}()
+
+	m.jobs[index].Start(ctx)
+
+	return false
+}
+
+func (m *Manager) setStatus(index int, status Status) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.statuses[index] = status
+}
+
+// restartDelay doubles on every consecutive failure, up to restartMaxDelay.
+func restartDelay(baseDelay time.Duration, failures int) time.Duration {
+	delay := baseDelay
+	for i := 1; i < failures && delay < restartMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, restartMaxDelay)
+}
@@ -0,0 +1,129 @@
+//nolint:testpackage // want to test internals
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingJob struct {
+	starts atomic.Int32
+	panics int32
+}
+
+func (j *blockingJob) Name() string {
+	return "blocking"
+}
+
+// Start panics the first panics times, then blocks like a real job.
+func (j *blockingJob) Start(ctx context.Context) {
+	if j.starts.Add(1) <= j.panics {
+		panic("job failed")
+	}
+
+	<-ctx.Done()
+}
+
+type doneJob struct {
+	starts atomic.Int32
+}
+
+func (j *doneJob) Name() string {
+	return "done"
+}
+
+// Start returns at once like a job with nothing to do.
+func (j *doneJob) Start(_ context.Context) {
+	j.starts.Add(1)
+}
+
+func TestUnitManager(t *testing.T) {
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should start and stop every job", func(t *testing.T) {
+		// GIVEN
+		first, second := &blockingJob{}, &blockingJob{}
+		manager := NewManager(logger, []Job{first, second})
+
+		// WHEN
+		require.NoError(t, manager.Start(context.Background()))
+		require.Eventually(t, func() bool {
+			return first.starts.Load() == 1 && second.starts.Load() == 1
+		}, time.Second, time.Millisecond)
+		require.Equal(t, []Status{StatusRunning, StatusRunning}, manager.Statuses())
+
+		// THEN
+		require.NoError(t, manager.Stop())
+		require.Equal(t, []Status{StatusStopped, StatusStopped}, manager.Statuses())
+	})
+
+	t.Run("should restart a job which panics", func(t *testing.T) {
+		// GIVEN
+		job := &blockingJob{panics: 2}
+		manager := NewManager(logger, []Job{job})
+		manager.restartBaseDelay = time.Millisecond
+
+		// WHEN
+		require.NoError(t, manager.Start(context.Background()))
+		t.Cleanup(func() { _ = manager.Stop() })
+
+		// THEN
+		require.Eventually(t, func() bool {
+			return job.starts.Load() == 3 && manager.Statuses()[0] == StatusRunning
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should not restart a job which returns", func(t *testing.T) {
+		// GIVEN
+		job := &doneJob{}
+		manager := NewManager(logger, []Job{job})
+		manager.restartBaseDelay = time.Millisecond
+
+		// WHEN
+		require.NoError(t, manager.Start(context.Background()))
+		t.Cleanup(func() { _ = manager.Stop() })
+
+		// THEN
+		require.Eventually(t, func() bool {
+			return manager.Statuses()[0] == StatusStopped
+		}, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, int32(1), job.starts.Load())
+	})
+
+	t.Run("should not start twice", func(t *testing.T) {
+		// GIVEN
+		manager := NewManager(logger, []Job{&blockingJob{}})
+		require.NoError(t, manager.Start(context.Background()))
+		t.Cleanup(func() { _ = manager.Stop() })
+
+		// WHEN
+		err := manager.Start(context.Background())
+
+		// THEN
+		require.Error(t, err)
+	})
+
+	t.Run("should not start nil jobs", func(t *testing.T) {
+		// GIVEN
+		manager := NewManager(logger, []Job{&blockingJob{}, nil})
+
+		// WHEN
+		err := manager.Start(context.Background())
+
+		// THEN
+		require.ErrorContains(t, err, "job 1 is nil")
+	})
+
+	t.Run("should double the restart delay up to a minute", func(t *testing.T) {
+		require.Equal(t, time.Second, restartDelay(time.Second, 0))
+		require.Equal(t, time.Second, restartDelay(time.Second, 1))
+		require.Equal(t, 4*time.Second, restartDelay(time.Second, 3))
+		require.Equal(t, time.Minute, restartDelay(time.Second, 20))
+	})
+}
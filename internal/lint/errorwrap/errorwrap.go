@@ -0,0 +1,113 @@
+// Package errorwrap flags fmt.Errorf calls formatting an error with %v instead of wrapping it with %w.
+package errorwrap
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+//nolint:gochecknoglobals // ok
+var Analyzer = &analysis.Analyzer{
+	Name: "errorwrap",
+	Doc:  "reports fmt.Errorf calls formatting an error with %v instead of %w",
+	Run:  run,
+}
+
+//nolint:gochecknoglobals // ok
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || !isErrorf(pass, call) || len(call.Args) == 0 {
+				return true
+			}
+
+			format := pass.TypesInfo.Types[call.Args[0]].Value
+			if format == nil || format.Kind() != constant.String {
+				return true
+			}
+
+			args := call.Args[1:]
+			for _, verb := range verbs(constant.StringVal(format)) {
+				if verb.verb != 'v' || verb.arg >= len(args) {
+					continue
+				}
+
+				if types.Implements(pass.TypesInfo.TypeOf(args[verb.arg]), errorType) {
+					pass.Reportf(args[verb.arg].Pos(), "fmt.Errorf formats an error with %%v, wrap it with %%w")
+				}
+			}
+
+			return true
+		})
+	}
+
+	return nil, nil
+}
+
+func isErrorf(pass *analysis.Pass, call *ast.CallExpr) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	function, ok := pass.TypesInfo.Uses[selector.Sel].(*types.Func)
+	return ok && function.Pkg() != nil && function.Pkg().Path() == "fmt" && function.Name() == "Errorf"
+}
+
+type formatVerb struct {
+	verb rune
+	// arg is the index of the argument consumed, after the format.
+	arg int
+}
+
+// verbs are the verbs of format with the argument they consume, a * width consumes one too and is a '*'.
+// An explicit argument index [n] moves to the n-th argument like fmt does, an invalid one gives no verbs.
+func verbs(format string) []formatVerb {
+	var result []formatVerb
+
+	arg := 0
+	runes := []rune(format)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			continue
+		}
+
+		i++
+		for i < len(runes) && strings.ContainsRune("+-# 0123456789.*[", runes[i]) {
+			switch runes[i] {
+			case '*':
+				result = append(result, formatVerb{'*', arg})
+				arg++
+			case '[':
+				end := i + 1
+				for end < len(runes) && runes[end] != ']' {
+					end++
+				}
+
+				index, err := strconv.Atoi(string(runes[i+1 : min(end, len(runes))]))
+				if end == len(runes) || err != nil || index < 1 {
+					return nil
+				}
+
+				arg = index - 1
+				i = end
+			}
+			i++
+		}
+
+		if i < len(runes) && runes[i] != '%' {
+			result = append(result, formatVerb{runes[i], arg})
+			arg++
+		}
+	}
+
+	return result
+}
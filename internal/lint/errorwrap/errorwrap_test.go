@@ -0,0 +1,14 @@
+package errorwrap_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/lint/errorwrap"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestUnitAnalyzer(t *testing.T) {
+	t.Run("should flag errors formatted with %v", func(t *testing.T) {
+		analysistest.Run(t, analysistest.TestData(), errorwrap.Analyzer, "a")
+	})
+}
@@ -0,0 +1,21 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+)
+
+func errs() []error {
+	err := errors.New("a")
+
+	return []error{
+		fmt.Errorf("a: could not do it. %v", err),       // want `fmt.Errorf formats an error with %v, wrap it with %w`
+		fmt.Errorf("a: could not do %s. %v", "it", err), // want `fmt.Errorf formats an error with %v, wrap it with %w`
+		fmt.Errorf("a: could not do it. %w", err),
+		fmt.Errorf("a: could not do %v, 100%%", "it"),
+		fmt.Errorf("a: %*d %v", 2, 1, "it"),
+		fmt.Errorf("a: could not do %[2]s. %[1]v", err, "it"), // want `fmt.Errorf formats an error with %v, wrap it with %w`
+		fmt.Errorf("a: could not do %[2]v. %[1]w", err, "it"),
+		fmt.Errorf("a: could not do %[1]s twice, %[1]v", "it"),
+	}
+}
@@ -0,0 +1,56 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWirelessInterface is the wireless interface of most macs, used when it cannot be detected.
+const DefaultWirelessInterface = "en0"
+
+//nolint:gochecknoglobals // ok
+var wirelessInterface = sync.OnceValues(func() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "networksetup", "-listallhardwareports").Output()
+
+	if err != nil {
+		//nolint:errorlint // no wrap
+		return "", fmt.Errorf("network: could not run networksetup. %v", err)
+	}
+
+	return ParseWirelessInterface(string(out))
+})
+
+// GetWirelessInterface runs networksetup once, later calls return the cached interface.
+func GetWirelessInterface() (string, error) {
+	return wirelessInterface()
+}
+
+// ParseWirelessInterface finds the device of the Wi-Fi hardware port in `networksetup -listallhardwareports`.
+func ParseWirelessInterface(output string) (string, error) {
+	isWifiPort := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if port, found := strings.CutPrefix(line, "Hardware Port:"); found {
+			isWifiPort = strings.TrimSpace(port) == "Wi-Fi"
+			continue
+		}
+
+		if device, found := strings.CutPrefix(line, "Device:"); found && isWifiPort {
+			return strings.TrimSpace(device), nil
+		}
+	}
+
+	return "", errors.New("network: could not find the Wi-Fi hardware port")
+}
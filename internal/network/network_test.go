@@ -0,0 +1,39 @@
+package network_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/network"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseWirelessInterface(t *testing.T) {
+	t.Run("should find the device of the Wi-Fi port", func(t *testing.T) {
+		// GIVEN
+		output := "\nHardware Port: Ethernet\n" +
+			"Device: en0\n" +
+			"Ethernet Address: 3c:22:fb:00:00:01\n\n" +
+			"Hardware Port: Wi-Fi\n" +
+			"Device: en1\n" +
+			"Ethernet Address: 3c:22:fb:00:00:02\n\n" +
+			"VLAN Configurations\n===================\n"
+
+		// WHEN
+		device, err := network.ParseWirelessInterface(output)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, "en1", device)
+	})
+
+	t.Run("should fail without a Wi-Fi port", func(t *testing.T) {
+		// GIVEN
+		output := "Hardware Port: Thunderbolt Bridge\nDevice: bridge0\n"
+
+		// WHEN
+		_, err := network.ParseWirelessInterface(output)
+
+		// THEN
+		require.Error(t, err)
+	})
+}
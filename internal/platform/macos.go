@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//nolint:gochecknoglobals // ok
+var macOSVersion = sync.OnceValues(func() ([2]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "sw_vers", "-productVersion").Output()
+
+	if err != nil {
+		//nolint:errorlint // no wrap
+		return [2]int{}, fmt.Errorf("platform: could not run sw_vers. %v", err)
+	}
+
+	major, minor, err := ParseMacOSVersion(string(out))
+
+	return [2]int{major, minor}, err
+})
+
+// GetMacOSVersion runs sw_vers once, later calls return the cached version.
+func GetMacOSVersion() (int, int, error) {
+	version, err := macOSVersion()
+
+	return version[0], version[1], err
+}
+
+// ParseMacOSVersion parses versions like "14.2.1" or "13.0", the patch is ignored.
+func ParseMacOSVersion(version string) (int, int, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("platform: unexpected macos version %q", version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("platform: could not parse major of %q. %w", version, err)
+	}
+
+	minor, err := strconv.Atoi(parts[1])
+
+	if err != nil {
+		return 0, 0, fmt.Errorf("platform: could not parse minor of %q. %w", version, err)
+	}
+
+	return major, minor, nil
+}
@@ -0,0 +1,50 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitParseMacOSVersion(t *testing.T) {
+	t.Run("should parse 14.2.1", func(t *testing.T) {
+		// WHEN
+		major, minor, err := platform.ParseMacOSVersion("14.2.1\n")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 14, major)
+		require.Equal(t, 2, minor)
+	})
+
+	t.Run("should parse 13.0", func(t *testing.T) {
+		// WHEN
+		major, minor, err := platform.ParseMacOSVersion("13.0\n")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 13, major)
+		require.Equal(t, 0, minor)
+	})
+
+	t.Run("should parse 12.6.3", func(t *testing.T) {
+		// WHEN
+		major, minor, err := platform.ParseMacOSVersion("12.6.3\n")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, 12, major)
+		require.Equal(t, 6, minor)
+	})
+
+	t.Run("should fail on invalid versions", func(t *testing.T) {
+		// WHEN
+		_, _, missingMinor := platform.ParseMacOSVersion("14")
+		_, _, notNumber := platform.ParseMacOSVersion("fourteen.2")
+
+		// THEN
+		require.Error(t, missingMinor)
+		require.Error(t, notNumber)
+	})
+}
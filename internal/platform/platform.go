@@ -0,0 +1,16 @@
+package platform
+
+// IsAppleSilicon is true when running natively on arm64,
+// a binary translated by Rosetta reports x86_64 like on Intel.
+func IsAppleSilicon() bool {
+	return machine() == "arm64"
+}
+
+// HomebrewBinDirs returns where homebrew installs binaries, the native one first.
+func HomebrewBinDirs() []string {
+	if IsAppleSilicon() {
+		return []string{"/opt/homebrew/bin", "/usr/local/bin"}
+	}
+
+	return []string{"/usr/local/bin", "/opt/homebrew/bin"}
+}
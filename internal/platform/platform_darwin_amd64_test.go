@@ -0,0 +1,15 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitPlatformAmd64(t *testing.T) {
+	t.Run("should search intel paths first", func(t *testing.T) {
+		require.False(t, platform.IsAppleSilicon())
+		require.Equal(t, []string{"/usr/local/bin", "/opt/homebrew/bin"}, platform.HomebrewBinDirs())
+	})
+}
@@ -0,0 +1,15 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitPlatformArm64(t *testing.T) {
+	t.Run("should search apple silicon paths first", func(t *testing.T) {
+		require.True(t, platform.IsAppleSilicon())
+		require.Equal(t, []string{"/opt/homebrew/bin", "/usr/local/bin"}, platform.HomebrewBinDirs())
+	})
+}
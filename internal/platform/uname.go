@@ -0,0 +1,15 @@
+//go:build darwin || linux
+
+package platform
+
+import "golang.org/x/sys/unix"
+
+func machine() string {
+	var uname unix.Utsname
+
+	if err := unix.Uname(&uname); err != nil {
+		return ""
+	}
+
+	return unix.ByteSliceToString(uname.Machine[:])
+}
@@ -0,0 +1,9 @@
+//go:build !darwin && !linux
+
+package platform
+
+import "runtime"
+
+func machine() string {
+	return runtime.GOARCH
+}
@@ -0,0 +1,66 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"plugin"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
+)
+
+// RegisterSymbol is the function every plugin exports, as func(items.Registry).
+const RegisterSymbol = "Register"
+
+// Load opens every .so of dir and lets it register its items, a missing dir is no plugins.
+// A broken plugin does not stop the others from loading, its error is part of the returned one.
+func Load(logger *slog.Logger, dir string, registry items.Registry) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.so"))
+
+	if err != nil {
+		return fmt.Errorf("plugins: could not list %s. %w", dir, err)
+	}
+
+	var errs []error
+	for _, path := range paths {
+		if err := load(path, registry); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		logger.Info("plugins: loaded", slog.String("plugin", path))
+	}
+
+	return errors.Join(errs...)
+}
+
+func load(path string, registry items.Registry) (err error) {
+	// registering a name twice panics
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("plugins: %s panicked while registering. %v", path, r)
+		}
+	}()
+
+	p, err := plugin.Open(path)
+
+	if err != nil {
+		return fmt.Errorf("plugins: could not open %s. %w", path, err)
+	}
+
+	symbol, err := p.Lookup(RegisterSymbol)
+
+	if err != nil {
+		return fmt.Errorf("plugins: %s does not export %s. %w", path, RegisterSymbol, err)
+	}
+
+	register, ok := symbol.(func(items.Registry))
+
+	if !ok {
+		return fmt.Errorf("plugins: %s exports %s as %T instead of func(items.Registry)", path, RegisterSymbol, symbol)
+	}
+
+	register(registry)
+	return nil
+}
@@ -0,0 +1,38 @@
+package plugins_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
+	"github.com/lucax88x/wentsketchy/internal/plugins"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitLoad(t *testing.T) {
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should load nothing from a missing dir", func(t *testing.T) {
+		// WHEN
+		err := plugins.Load(logger, filepath.Join(t.TempDir(), "plugins"), items.DefaultRegistry())
+
+		// THEN
+		require.NoError(t, err)
+	})
+
+	t.Run("should report the plugins which cannot be opened", func(t *testing.T) {
+		// GIVEN
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.so"), []byte("not a plugin"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o600))
+
+		// WHEN
+		err := plugins.Load(logger, dir, items.DefaultRegistry())
+
+		// THEN
+		require.ErrorContains(t, err, "broken.so")
+		require.NotContains(t, err.Error(), "README.md")
+	})
+}
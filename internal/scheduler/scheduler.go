@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/clock"
+	"github.com/lucax88x/wentsketchy/internal/jobs"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+)
+
+const timeLayout = "15:04"
+
+type window struct {
+	// from and until are minutes since midnight.
+	from  int
+	until int
+}
+
+func (w window) contains(now time.Time) bool {
+	minute := now.Hour()*60 + now.Minute()
+
+	if w.from <= w.until {
+		return minute >= w.from && minute < w.until
+	}
+
+	return minute >= w.from || minute < w.until
+}
+
+// VisibilityScheduler hides the items outside of their configured time window, checking every minute.
+type VisibilityScheduler struct {
+	logger     *slog.Logger
+	clock      clock.Clock
+	sketchybar sketchybar.API
+	windows    map[string]window
+	// targets are the sketchybar items of each item.
+	targets map[string][]string
+	// visible is the last drawing sent by item, so only changes are sent.
+	visible map[string]bool
+	mutex   sync.Mutex
+}
+
+// NewVisibilityScheduler skips the items with an invalid window or without sketchybar items, logging them.
+// sketchybarItems maps the name of an item in config.yaml to its sketchybar items.
+func NewVisibilityScheduler(
+	logger *slog.Logger,
+	clock clock.Clock,
+	sketchybar sketchybar.API,
+	visibility map[string]settings.VisibilityConfig,
+	sketchybarItems func(itemName string) []string,
+) *VisibilityScheduler {
+	windows := make(map[string]window, len(visibility))
+	targets := make(map[string][]string, len(visibility))
+
+	for itemName, config := range visibility {
+		itemWindow, err := parseWindow(config)
+
+		if err != nil {
+			logger.Warn("scheduler: invalid visibility, ignoring it",
+				slog.String("item", itemName),
+				slog.Any("error", err))
+			continue
+		}
+
+		itemTargets := sketchybarItems(itemName)
+
+		if len(itemTargets) == 0 {
+			logger.Warn("scheduler: item has no fixed sketchybar items, ignoring its visibility",
+				slog.String("item", itemName))
+			continue
+		}
+
+		windows[itemName] = itemWindow
+		targets[itemName] = itemTargets
+	}
+
+	return &VisibilityScheduler{
+		logger:     logger,
+		clock:      clock,
+		sketchybar: sketchybar,
+		windows:    windows,
+		targets:    targets,
+		visible:    make(map[string]bool, len(windows)),
+	}
+}
+
+func (s *VisibilityScheduler) Name() string {
+	return "visibility"
+}
+
+func (s *VisibilityScheduler) Start(ctx context.Context) {
+	if len(s.windows) == 0 {
+		return
+	}
+
+	s.logger.InfoContext(ctx, "scheduler: starting")
+
+	s.check(ctx)
+
+	ticks, stop := s.clock.Ticker(time.Minute)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.InfoContext(ctx, "scheduler: stopping")
+			return
+		case <-ticks:
+			s.check(ctx)
+		}
+	}
+}
+
+// Reset sends the drawing of every item again, after the items have been added again with their default drawing.
+func (s *VisibilityScheduler) Reset(ctx context.Context) {
+	s.mutex.Lock()
+	clear(s.visible)
+	s.mutex.Unlock()
+
+	s.check(ctx)
+}
+
+func (s *VisibilityScheduler) check(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.clock.Now()
+
+	var args []string
+	for _, itemName := range slices.Sorted(maps.Keys(s.windows)) {
+		visible := s.windows[itemName].contains(now)
+
+		if last, found := s.visible[itemName]; found && last == visible {
+			continue
+		}
+
+		s.visible[itemName] = visible
+		for _, target := range s.targets[itemName] {
+			args = append(args, "--set", target, "drawing="+onOff(visible))
+		}
+	}
+
+	if len(args) == 0 {
+		return
+	}
+
+	if err := s.sketchybar.Run(ctx, args); err != nil {
+		s.logger.ErrorContext(ctx, "scheduler: could not set visibility", slog.Any("error", err))
+		// send them again on the next check
+		clear(s.visible)
+	}
+}
+
+func parseWindow(config settings.VisibilityConfig) (window, error) {
+	from, err := parseMinutes(config.From)
+
+	if err != nil {
+		return window{}, fmt.Errorf("scheduler: invalid visible_from. %w", err)
+	}
+
+	until, err := parseMinutes(config.Until)
+
+	if err != nil {
+		return window{}, fmt.Errorf("scheduler: invalid visible_until. %w", err)
+	}
+
+	if config.Until == "" {
+		until = 24 * 60
+	}
+
+	return window{from, until}, nil
+}
+
+func parseMinutes(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := time.Parse(timeLayout, value)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+var _ jobs.Job = (*VisibilityScheduler)(nil)
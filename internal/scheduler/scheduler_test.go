@@ -0,0 +1,133 @@
+//nolint:testpackage // want to test internals
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/query"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/lucax88x/wentsketchy/testutils/fake"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAPI struct {
+	calls [][]string
+}
+
+func (api *recordingAPI) Run(_ context.Context, args []string) error {
+	api.calls = append(api.calls, args)
+	return nil
+}
+
+func (api *recordingAPI) RunBatch(ctx context.Context, batches sketchybar.Batches) error {
+	return sketchybar.RunBatchWithFallback(ctx, testutils.CreateTestLogger(), api, batches)
+}
+
+func (api *recordingAPI) RunAsync(ctx context.Context, args []string) {
+	_ = api.Run(ctx, args)
+}
+
+func (api *recordingAPI) QueryBar(_ context.Context) (query.Bar, error) {
+	return query.Bar{}, nil
+}
+
+func at(hour, minute int) time.Time {
+	return time.Date(2024, 1, 1, hour, minute, 0, 0, time.Local)
+}
+
+func sameName(itemName string) []string {
+	return []string{itemName}
+}
+
+func TestUnitVisibilityScheduler(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+
+	t.Run("should hide items outside their window and send only changes", func(t *testing.T) {
+		// GIVEN
+		clock := &fake.Clock{Time: at(8, 59)}
+		api := &recordingAPI{}
+		scheduler := NewVisibilityScheduler(logger, clock, api, map[string]settings.VisibilityConfig{
+			"calendar": {From: "09:00", Until: "18:00"},
+		}, sameName)
+
+		// WHEN
+		scheduler.check(ctx)
+		scheduler.check(ctx)
+		clock.Time = at(9, 0)
+		scheduler.check(ctx)
+
+		// THEN
+		require.Equal(t, [][]string{
+			{"--set", "calendar", "drawing=off"},
+			{"--set", "calendar", "drawing=on"},
+		}, api.calls)
+	})
+
+	t.Run("should send the drawing again after a reset", func(t *testing.T) {
+		// GIVEN
+		api := &recordingAPI{}
+		scheduler := NewVisibilityScheduler(logger, &fake.Clock{Time: at(8, 0)}, api, map[string]settings.VisibilityConfig{
+			"calendar": {From: "09:00", Until: "18:00"},
+		}, sameName)
+		scheduler.check(ctx)
+
+		// WHEN
+		scheduler.Reset(ctx)
+
+		// THEN
+		require.Equal(t, [][]string{
+			{"--set", "calendar", "drawing=off"},
+			{"--set", "calendar", "drawing=off"},
+		}, api.calls)
+	})
+
+	t.Run("should span midnight when until is before from", func(t *testing.T) {
+		// GIVEN
+		nightly := window{from: 22 * 60, until: 6 * 60}
+
+		// THEN
+		require.True(t, nightly.contains(at(23, 30)))
+		require.True(t, nightly.contains(at(5, 59)))
+		require.False(t, nightly.contains(at(6, 0)))
+	})
+
+	t.Run("should ignore invalid windows", func(t *testing.T) {
+		// WHEN
+		scheduler := NewVisibilityScheduler(logger, &fake.Clock{}, &recordingAPI{}, map[string]settings.VisibilityConfig{
+			"calendar": {From: "9am"},
+			"battery":  {Until: "18:00"},
+		}, sameName)
+
+		// THEN
+		require.Equal(t, map[string]window{"battery": {from: 0, until: 18 * 60}}, scheduler.windows)
+	})
+
+	t.Run("should set the drawing of the sketchybar items of the item", func(t *testing.T) {
+		// GIVEN
+		api := &recordingAPI{}
+		scheduler := NewVisibilityScheduler(logger, &fake.Clock{Time: at(8, 0)}, api,
+			map[string]settings.VisibilityConfig{
+				"cpu":       {From: "09:00"},
+				"aerospace": {From: "09:00"},
+			},
+			func(itemName string) []string {
+				if itemName == "cpu" {
+					return []string{"cpu.icon", "cpu.bracket"}
+				}
+				return nil
+			})
+
+		// WHEN
+		scheduler.check(ctx)
+
+		// THEN
+		require.Equal(t, [][]string{
+			{"--set", "cpu.icon", "drawing=off", "--set", "cpu.bracket", "drawing=off"},
+		}, api.calls)
+	})
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/aerospace/events"
+	"github.com/lucax88x/wentsketchy/internal/clock"
+)
+
+// ErrRejected is returned by middlewares which refuse a message, a rejected message is not retried.
+var ErrRejected = errors.New("server: message rejected")
+
+type MessageMiddleware func(ctx context.Context, msg string, next func(ctx context.Context, msg string) error) error
+
+// chain wraps handler with the middlewares, the first middleware is the outermost.
+func chain(
+	middlewares []MessageMiddleware,
+	handler func(ctx context.Context, msg string) error,
+) func(ctx context.Context, msg string) error {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		middleware, next := middlewares[i], handler
+		handler = func(ctx context.Context, msg string) error {
+			return middleware(ctx, msg, next)
+		}
+	}
+
+	return handler
+}
+
+func LoggingMiddleware(logger *slog.Logger) MessageMiddleware {
+	return func(ctx context.Context, msg string, next func(ctx context.Context, msg string) error) error {
+		start := time.Now()
+		logger.DebugContext(ctx, "server: handling message", slog.String("message", msg))
+
+		err := next(ctx, msg)
+
+		logger.DebugContext(ctx, "server: handled message",
+			slog.String("message", msg),
+			slog.Duration("duration", time.Since(start)),
+			slog.Any("error", err))
+
+		return err
+	}
+}
+
+// MessageMetrics counts the messages going through MetricsMiddleware.
+type MessageMetrics struct {
+	handled atomic.Int64
+	failed  atomic.Int64
+}
+
+func NewMessageMetrics() *MessageMetrics {
+	return &MessageMetrics{}
+}
+
+func (m *MessageMetrics) Handled() int64 {
+	return m.handled.Load()
+}
+
+func (m *MessageMetrics) Failed() int64 {
+	return m.failed.Load()
+}
+
+func MetricsMiddleware(metrics *MessageMetrics) MessageMiddleware {
+	return func(ctx context.Context, msg string, next func(ctx context.Context, msg string) error) error {
+		err := next(ctx, msg)
+
+		metrics.handled.Add(1)
+		if err != nil {
+			metrics.failed.Add(1)
+		}
+
+		return err
+	}
+}
+
+// RateLimitMiddleware rejects the messages over limit in every window,
+// so a script stuck in a loop does not keep aerospace and sketchybar busy.
+func RateLimitMiddleware(clock clock.Clock, limit int, window time.Duration) MessageMiddleware {
+	var mutex sync.Mutex
+	var windowStart time.Time
+	count := 0
+
+	return func(ctx context.Context, msg string, next func(ctx context.Context, msg string) error) error {
+		mutex.Lock()
+		now := clock.Now()
+		if now.Sub(windowStart) >= window {
+			windowStart = now
+			count = 0
+		}
+		count++
+		limited := count > limit
+		mutex.Unlock()
+
+		if limited {
+			return fmt.Errorf("%w: more than %d messages in %s", ErrRejected, limit, window)
+		}
+
+		return next(ctx, msg)
+	}
+}
+
+//nolint:gochecknoglobals // ok
+var knownMessagePrefixes = []string{
+	"init",
+	"update",
+	events.WorkspaceChange,
+	events.AerospaceRefresh,
+}
+
+// ValidationMiddleware rejects the messages handleSafely does not know,
+// they usually come from a sketchybar custom event with a typo.
+func ValidationMiddleware(logger *slog.Logger) MessageMiddleware {
+	return func(ctx context.Context, msg string, next func(ctx context.Context, msg string) error) error {
+		for _, prefix := range knownMessagePrefixes {
+			if strings.HasPrefix(msg, prefix) {
+				return next(ctx, msg)
+			}
+		}
+
+		logger.DebugContext(ctx, "server: rejected unknown message", slog.String("message", msg))
+
+		return fmt.Errorf("%w: unknown message", ErrRejected)
+	}
+}
@@ -0,0 +1,99 @@
+//nolint:testpackage // want to test internals
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/lucax88x/wentsketchy/testutils/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitMiddleware(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should run middlewares in order around the handler", func(t *testing.T) {
+		// GIVEN
+		var calls []string
+		record := func(name string) MessageMiddleware {
+			return func(ctx context.Context, msg string, next func(ctx context.Context, msg string) error) error {
+				calls = append(calls, name+" before")
+				err := next(ctx, msg)
+				calls = append(calls, name+" after")
+				return err
+			}
+		}
+		handler := chain([]MessageMiddleware{record("first"), record("second")}, func(_ context.Context, msg string) error {
+			calls = append(calls, "handle "+msg)
+			return nil
+		})
+
+		// WHEN
+		err := handler(ctx, "init")
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, []string{"first before", "second before", "handle init", "second after", "first after"}, calls)
+	})
+
+	t.Run("should count handled and failed messages", func(t *testing.T) {
+		// GIVEN
+		metrics := NewMessageMetrics()
+		handler := chain([]MessageMiddleware{MetricsMiddleware(metrics)}, func(_ context.Context, msg string) error {
+			if msg == "fail" {
+				return errors.New("failed")
+			}
+			return nil
+		})
+
+		// WHEN
+		_ = handler(ctx, "init")
+		_ = handler(ctx, "fail")
+
+		// THEN
+		require.Equal(t, int64(2), metrics.Handled())
+		require.Equal(t, int64(1), metrics.Failed())
+	})
+
+	t.Run("should reject messages over the limit until the window passes", func(t *testing.T) {
+		// GIVEN
+		clock := &fake.Clock{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		handler := chain([]MessageMiddleware{RateLimitMiddleware(clock, 2, time.Second)}, func(context.Context, string) error {
+			return nil
+		})
+
+		// WHEN
+		first := handler(ctx, "init")
+		second := handler(ctx, "init")
+		third := handler(ctx, "init")
+		clock.Time = clock.Time.Add(time.Second)
+		fourth := handler(ctx, "init")
+
+		// THEN
+		require.NoError(t, first)
+		require.NoError(t, second)
+		require.ErrorIs(t, third, ErrRejected)
+		require.NoError(t, fourth)
+	})
+
+	t.Run("should pass known messages and reject unknown ones", func(t *testing.T) {
+		// GIVEN
+		var handled []string
+		handler := chain([]MessageMiddleware{ValidationMiddleware(testutils.CreateTestLogger())}, func(_ context.Context, msg string) error {
+			handled = append(handled, msg)
+			return nil
+		})
+
+		// WHEN
+		refresh := handler(ctx, "aerospace_refresh")
+		typo := handler(ctx, "aerospace_workspace_chnage {}")
+
+		// THEN
+		require.NoError(t, refresh)
+		require.ErrorIs(t, typo, ErrRejected)
+		require.Equal(t, []string{"aerospace_refresh"}, handled)
+	})
+}
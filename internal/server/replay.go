@@ -0,0 +1,123 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/lucax88x/wentsketchy/internal/aerospace/events"
+)
+
+// ReplaySize is how many messages the EventReplayBuffer keeps.
+const ReplaySize = 10
+
+// EventReplayBuffer keeps the last messages in a file, so they can be handled again on the next start.
+// Only aerospace messages are kept, replaying a click would toggle things again.
+type EventReplayBuffer struct {
+	path     string
+	size     int
+	mutex    sync.Mutex
+	messages []string
+	replayed bool
+}
+
+// NewEventReplayBuffer loads the messages persisted on the previous run, a missing file is not an error.
+func NewEventReplayBuffer(path string, size int) (*EventReplayBuffer, error) {
+	buffer := &EventReplayBuffer{
+		path: path,
+		size: size,
+	}
+
+	content, err := os.ReadFile(path)
+
+	if os.IsNotExist(err) {
+		return buffer, nil
+	}
+
+	if err != nil {
+		return buffer, fmt.Errorf("replay: could not read %s. %w", path, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		var msg string
+
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			return buffer, fmt.Errorf("replay: could not deserialize message. %w", err)
+		}
+
+		buffer.messages = append(buffer.messages, msg)
+	}
+
+	buffer.messages = buffer.last(buffer.messages)
+
+	return buffer, nil
+}
+
+// Record keeps msg when it can be replayed, persisting the buffer.
+func (b *EventReplayBuffer) Record(msg string) error {
+	if !isReplayable(msg) {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.messages = b.last(append(b.messages, msg))
+
+	var content bytes.Buffer
+	for _, msg := range b.messages {
+		line, err := json.Marshal(msg)
+
+		if err != nil {
+			return fmt.Errorf("replay: could not serialize message. %w", err)
+		}
+
+		content.Write(line)
+		content.WriteByte('\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0750); err != nil {
+		return fmt.Errorf("replay: could not create directory of %s. %w", b.path, err)
+	}
+
+	if err := os.WriteFile(b.path, content.Bytes(), 0600); err != nil {
+		return fmt.Errorf("replay: could not write %s. %w", b.path, err)
+	}
+
+	return nil
+}
+
+// Replay returns the persisted messages oldest first, only once so a restarted server does not replay them again.
+func (b *EventReplayBuffer) Replay() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.replayed {
+		return nil
+	}
+
+	b.replayed = true
+
+	messages := make([]string, len(b.messages))
+	copy(messages, b.messages)
+
+	return messages
+}
+
+func (b *EventReplayBuffer) last(messages []string) []string {
+	if len(messages) > b.size {
+		return messages[len(messages)-b.size:]
+	}
+
+	return messages
+}
+
+func isReplayable(msg string) bool {
+	return strings.HasPrefix(msg, events.WorkspaceChange) || strings.HasPrefix(msg, events.AerospaceRefresh)
+}
@@ -0,0 +1,63 @@
+package server_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitEventReplayBuffer(t *testing.T) {
+	t.Run("should replay the last aerospace messages of the previous run", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "replay.log")
+		previous, err := server.NewEventReplayBuffer(path, 2)
+		require.NoError(t, err)
+
+		for i := 1; i <= 3; i++ {
+			msg := fmt.Sprintf(`aerospace_workspace_change { "focused": "%d", "prev": "" }`, i)
+			require.NoError(t, previous.Record(msg))
+		}
+		require.NoError(t, previous.Record(`update args: {"name":"wifi","event":"mouse.clicked"} info: `))
+
+		// WHEN
+		buffer, err := server.NewEventReplayBuffer(path, 2)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			`aerospace_workspace_change { "focused": "2", "prev": "" }`,
+			`aerospace_workspace_change { "focused": "3", "prev": "" }`,
+		}, buffer.Replay())
+	})
+
+	t.Run("should replay only once", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "replay.log")
+		previous, err := server.NewEventReplayBuffer(path, server.ReplaySize)
+		require.NoError(t, err)
+		require.NoError(t, previous.Record("aerospace_refresh"))
+
+		buffer, err := server.NewEventReplayBuffer(path, server.ReplaySize)
+		require.NoError(t, err)
+
+		// WHEN
+		first := buffer.Replay()
+		second := buffer.Replay()
+
+		// THEN
+		require.Equal(t, []string{"aerospace_refresh"}, first)
+		require.Empty(t, second)
+	})
+
+	t.Run("should start empty without a file", func(t *testing.T) {
+		// WHEN
+		buffer, err := server.NewEventReplayBuffer(filepath.Join(t.TempDir(), "missing", "replay.log"), server.ReplaySize)
+
+		// THEN
+		require.NoError(t, err)
+		require.Empty(t, buffer.Replay())
+	})
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"strings"
 	"time"
@@ -21,6 +22,10 @@ type FifoServer struct {
 	config    *config.Config
 	fifo      *fifo.Reader
 	aerospace aerospace.Aerospace
+	// replay can be nil, then nothing is replayed on start.
+	replay *EventReplayBuffer
+	// handle is handleSafely wrapped by the middlewares.
+	handle func(ctx context.Context, msg string) error
 }
 
 func NewFifoServer(
@@ -28,13 +33,20 @@ func NewFifoServer(
 	config *config.Config,
 	fifo *fifo.Reader,
 	aerospace aerospace.Aerospace,
+	replay *EventReplayBuffer,
+	middlewares []MessageMiddleware,
 ) *FifoServer {
-	return &FifoServer{
-		logger,
-		config,
-		fifo,
-		aerospace,
+	server := &FifoServer{
+		logger:    logger,
+		config:    config,
+		fifo:      fifo,
+		aerospace: aerospace,
+		replay:    replay,
 	}
+
+	server.handle = chain(middlewares, server.handleSafely)
+
+	return server
 }
 
 func (f FifoServer) Start(ctx context.Context) {
@@ -47,6 +59,8 @@ func (f FifoServer) Start(ctx context.Context) {
 
 	f.logger.InfoContext(ctx, "server: starting FIFO server")
 
+	f.replayMessages(ctx)
+
 	// Retry mechanism for FIFO operations
 	maxRetries := 3
 	retryDelay := time.Second * 5
@@ -59,18 +73,18 @@ func (f FifoServer) Start(ctx context.Context) {
 		default:
 		}
 
-		f.logger.InfoContext(ctx, "server: attempting to start FIFO listener", 
-			slog.Int("attempt", attempt), 
+		f.logger.InfoContext(ctx, "server: attempting to start FIFO listener",
+			slog.Int("attempt", attempt),
 			slog.Int("maxRetries", maxRetries))
 
 		if err := f.startFifoListener(ctx); err != nil {
-			f.logger.ErrorContext(ctx, "server: FIFO listener failed", 
+			f.logger.ErrorContext(ctx, "server: FIFO listener failed",
 				slog.Any("error", err),
 				slog.Int("attempt", attempt))
-			
+
 			if attempt < maxRetries {
 				f.logger.InfoContext(ctx, "server: retrying FIFO listener", slog.Duration("delay", retryDelay))
-				
+
 				select {
 				case <-ctx.Done():
 					f.logger.InfoContext(ctx, "server: context cancelled during retry delay")
@@ -93,6 +107,27 @@ func (f FifoServer) Start(ctx context.Context) {
 	f.runFallbackServer(ctx)
 }
 
+// replayMessages handles the messages of the previous run, sketchybar may have fired them before we were listening.
+// A replayed workspace change may be stale, so the focus is asked again to aerospace with a refresh at the end.
+func (f FifoServer) replayMessages(ctx context.Context) {
+	if f.replay == nil {
+		return
+	}
+
+	focusReplayed := false
+	for _, msg := range f.replay.Replay() {
+		f.logger.InfoContext(ctx, "server: replaying message", slog.String("message", msg))
+		f.handleWithRetry(ctx, msg)
+
+		focusReplayed = focusReplayed || strings.HasPrefix(msg, events.WorkspaceChange)
+	}
+
+	if focusReplayed {
+		f.aerospace.ClearFocusedWorkspaceID()
+		f.handleWithRetry(ctx, events.AerospaceRefresh)
+	}
+}
+
 func (f FifoServer) startFifoListener(ctx context.Context) error {
 	ch := make(chan string, 100) // Buffered channel to prevent blocking
 	defer close(ch)
@@ -128,11 +163,17 @@ func (f FifoServer) startFifoListener(ctx context.Context) error {
 			f.logger.InfoContext(ctx, "server: FIFO listener completed normally")
 			return nil
 		case msg := <-ch:
+			if f.replay != nil {
+				if err := f.replay.Record(msg); err != nil {
+					f.logger.WarnContext(ctx, "server: could not record message for replay", slog.Any("error", err))
+				}
+			}
+
 			// Handle message with error recovery
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						f.logger.ErrorContext(ctx, "server: recovered from panic while handling message", 
+						f.logger.ErrorContext(ctx, "server: recovered from panic while handling message",
 							slog.Any("panic", r),
 							slog.String("message", msg))
 					}
@@ -145,7 +186,7 @@ func (f FifoServer) startFifoListener(ctx context.Context) error {
 
 func (f FifoServer) runFallbackServer(ctx context.Context) {
 	f.logger.InfoContext(ctx, "server: running fallback server mode")
-	
+
 	// Keep the server alive even if FIFO fails
 	ticker := time.NewTicker(time.Minute * 5) // Periodic health check
 	defer ticker.Stop()
@@ -162,7 +203,7 @@ func (f FifoServer) runFallbackServer(ctx context.Context) {
 						f.logger.ErrorContext(ctx, "server: recovered from panic in fallback server", slog.Any("panic", r))
 					}
 				}()
-				
+
 				f.logger.DebugContext(ctx, "server: fallback server health check")
 				// Periodic aerospace refresh to keep data fresh
 				f.aerospace.SingleFlightRefreshTree()
@@ -174,17 +215,24 @@ func (f FifoServer) runFallbackServer(ctx context.Context) {
 func (f FifoServer) handleWithRetry(ctx context.Context, msg string) {
 	maxRetries := 3
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if err := f.handleSafely(ctx, msg); err != nil {
-			f.logger.ErrorContext(ctx, "server: message handling failed", 
+		if err := f.handle(ctx, msg); err != nil {
+			if errors.Is(err, ErrRejected) {
+				f.logger.DebugContext(ctx, "server: message rejected",
+					slog.Any("error", err),
+					slog.String("message", msg))
+				return
+			}
+
+			f.logger.ErrorContext(ctx, "server: message handling failed",
 				slog.Any("error", err),
 				slog.String("message", msg),
 				slog.Int("attempt", attempt))
-			
+
 			if attempt < maxRetries {
 				time.Sleep(time.Millisecond * 100) // Brief delay before retry
 				continue
 			} else {
-				f.logger.ErrorContext(ctx, "server: message handling failed after all retries, skipping message", 
+				f.logger.ErrorContext(ctx, "server: message handling failed after all retries, skipping message",
 					slog.String("message", msg))
 			}
 		} else {
@@ -196,7 +244,7 @@ func (f FifoServer) handleWithRetry(ctx context.Context, msg string) {
 func (f FifoServer) handleSafely(ctx context.Context, msg string) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			f.logger.ErrorContext(ctx, "server: recovered from panic in handleSafely", 
+			f.logger.ErrorContext(ctx, "server: recovered from panic in handleSafely",
 				slog.Any("panic", r),
 				slog.String("message", msg))
 			err = nil // Convert panic to nil error so we don't retry panics
@@ -213,14 +261,14 @@ func (f FifoServer) handleSafely(ctx context.Context, msg string) (err error) {
 
 	if strings.HasPrefix(msg, events.AerospaceRefresh) {
 		f.logger.InfoContext(ctx, "server: handling aerospace refresh")
-		
+
 		f.aerospace.SingleFlightRefreshTree()
 
 		if err := f.config.Update(ctx, &args.In{
 			Name:  items.AerospaceName,
 			Event: events.AerospaceRefresh,
 		}); err != nil {
-			f.logger.ErrorContext(ctx, "server: aerospace refresh update failed", slog.Any("error", err))
+			f.logErrors(ctx, "server: aerospace refresh update failed", err)
 			return err
 		}
 		return nil
@@ -228,20 +276,28 @@ func (f FifoServer) handleSafely(ctx context.Context, msg string) (err error) {
 
 	if strings.HasPrefix(msg, "update") {
 		f.logger.InfoContext(ctx, "server: handling update message")
-		
-		args, err := args.FromEvent(msg)
+
+		in, err := args.FromEvent(msg)
 		if err != nil {
 			f.logger.ErrorContext(ctx, "server: could not parse args", slog.Any("error", err))
 			return err
 		}
 
+		// sketchybar sends incomplete messages while starting up, retrying would not help
+		if err := args.Validate(in); err != nil {
+			f.logger.DebugContext(ctx, "server: skipping invalid update",
+				slog.Any("error", err),
+				slog.String("message", msg))
+			return nil
+		}
+
 		f.logger.InfoContext(ctx, "server: processing update",
-			slog.String("name", args.Name),
-			slog.String("event", args.Event),
-			slog.String("info", args.Info))
+			slog.String("name", in.Name),
+			slog.String("event", in.Event),
+			slog.String("info", in.Info))
 
-		if err := f.config.Update(ctx, args); err != nil {
-			f.logger.ErrorContext(ctx, "server: update failed", slog.Any("error", err))
+		if err := f.config.Update(ctx, in); err != nil {
+			f.logErrors(ctx, "server: update failed", err)
 			return err
 		}
 		return nil
@@ -249,10 +305,10 @@ func (f FifoServer) handleSafely(ctx context.Context, msg string) (err error) {
 
 	if strings.HasPrefix(msg, events.WorkspaceChange) {
 		f.logger.InfoContext(ctx, "server: handling workspace change")
-		
+
 		eventJSON, _ := strings.CutPrefix(msg, events.WorkspaceChange)
 		var data events.WorkspaceChangeEventInfo
-		
+
 		if err := json.Unmarshal([]byte(eventJSON), &data); err != nil {
 			f.logger.ErrorContext(ctx, "server: could not deserialize workspace change data",
 				slog.String("message", msg),
@@ -268,7 +324,7 @@ func (f FifoServer) handleSafely(ctx context.Context, msg string) (err error) {
 			Event: events.WorkspaceChange,
 			Info:  eventJSON,
 		}); err != nil {
-			f.logger.ErrorContext(ctx, "server: workspace change update failed", slog.Any("error", err))
+			f.logErrors(ctx, "server: workspace change update failed", err)
 			return err
 		}
 		return nil
@@ -276,4 +332,17 @@ func (f FifoServer) handleSafely(ctx context.Context, msg string) (err error) {
 
 	f.logger.DebugContext(ctx, "server: unhandled message", slog.String("message", msg))
 	return nil
-}
\ No newline at end of file
+}
+
+// logErrors logs every error joined by config.Update on its own line.
+func (f FifoServer) logErrors(ctx context.Context, msg string, err error) {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		f.logger.ErrorContext(ctx, msg, slog.Any("error", err))
+		return
+	}
+
+	for _, err := range joined.Unwrap() {
+		f.logger.ErrorContext(ctx, msg, slog.Any("error", err))
+	}
+}
@@ -0,0 +1,137 @@
+//nolint:testpackage // want to test internals
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/cmd/cli/config"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/fifo"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/lucax88x/wentsketchy/internal/testhelpers"
+	"github.com/lucax88x/wentsketchy/internal/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupServer(t *testing.T, replay *EventReplayBuffer) (*FifoServer, chan []string, *aerospace.Data) {
+	t.Helper()
+
+	logger := testutils.CreateTestLogger()
+	_, calls := testhelpers.StartFakeSketchybar(t)
+	testhelpers.StartFakeAerospace(t)
+
+	command := command.NewCommand(logger)
+	aerospaceAPI := aerospace.NewAPI(logger, command)
+	aerospaceData := aerospace.New(logger, aerospaceAPI, aerospace.NewTreeBuilder(logger, aerospaceAPI))
+	sketchybarAPI := sketchybar.NewAPI(logger, command)
+
+	cfg := config.NewConfig(
+		&config.Cfg{Left: []string{"aerospace", "front_app"}},
+		logger,
+		sketchybarAPI,
+		items.IndexedWentsketchyItems{
+			"aerospace": items.NewAerospaceItem(logger, aerospaceData, sketchybarAPI),
+			"front_app": items.NewFrontAppItem(logger, command),
+		},
+		items.ItemDeps{},
+	)
+
+	return NewFifoServer(logger, cfg, fifo.NewFifoReader(logger), aerospaceData, replay, nil), calls, aerospaceData
+}
+
+func TestIntegrationServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should render aerospace workspaces on aerospace refresh", func(t *testing.T) {
+		// GIVEN
+		server, calls, _ := setupServer(t, nil)
+
+		// WHEN
+		err := server.handleSafely(ctx, "aerospace_refresh")
+
+		// THEN
+		require.NoError(t, err)
+		call := receiveCall(t, calls)
+		require.True(t, containsSequence(call, "--add", "item", "aerospace.workspace.1", "left"))
+		require.True(t, containsSequence(call, "--add", "item", "aerospace.workspace.2", "left"))
+		require.True(t, containsSequence(call, "--add", "item", "aerospace.window.100", "left"))
+	})
+
+	t.Run("should set front app label on update message", func(t *testing.T) {
+		// GIVEN
+		server, calls, _ := setupServer(t, nil)
+		msg := `update args: {"name":"front_app","event":"front_app_switched","button":"","modifier":""} info: Safari`
+
+		// WHEN
+		err := server.handleSafely(ctx, msg)
+
+		// THEN
+		require.NoError(t, err)
+		call := receiveCall(t, calls)
+		require.True(t, containsSequence(call, "--set", "front_app", "label=Safari"))
+	})
+
+	t.Run("should skip update message without event", func(t *testing.T) {
+		// GIVEN
+		server, calls, _ := setupServer(t, nil)
+		msg := `update args: {"name":"front_app","event":"","button":"","modifier":""} info: Safari`
+
+		// WHEN
+		err := server.handleSafely(ctx, msg)
+
+		// THEN
+		require.NoError(t, err)
+		require.Empty(t, calls)
+	})
+}
+
+func TestIntegrationServerReplay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should ask aerospace the focus after replaying a stale workspace change", func(t *testing.T) {
+		// GIVEN
+		path := filepath.Join(t.TempDir(), "replay.log")
+		previous, err := NewEventReplayBuffer(path, ReplaySize)
+		require.NoError(t, err)
+		// the fake aerospace has workspace 1 focused
+		require.NoError(t, previous.Record(`aerospace_workspace_change {"focused": "2", "prev": "1"}`))
+
+		replay, err := NewEventReplayBuffer(path, ReplaySize)
+		require.NoError(t, err)
+		server, calls, aerospaceData := setupServer(t, replay)
+
+		// WHEN
+		server.replayMessages(ctx)
+
+		// THEN
+		require.Equal(t, "1", aerospaceData.GetFocusedWorkspaceID(ctx))
+		receiveCall(t, calls)
+	})
+}
+
+func receiveCall(t *testing.T, calls chan []string) []string {
+	t.Helper()
+
+	select {
+	case call := <-calls:
+		return call
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not receive any sketchybar call")
+		return nil
+	}
+}
+
+func containsSequence(call []string, sequence ...string) bool {
+	for i := range call {
+		if i+len(sequence) <= len(call) && slices.Equal(call[i:i+len(sequence)], sequence) {
+			return true
+		}
+	}
+	return false
+}
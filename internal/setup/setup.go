@@ -4,8 +4,8 @@ import (
 	"context"
 	"log/slog"
 	"os"
-	// "os/signal"
-	// "syscall"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"fmt"
@@ -61,9 +61,15 @@ func Run(buildExecutor ExecutorBuilder) ExecutionResult {
 		logLevel = slog.LevelInfo
 	}
 
+	console := &console.Console{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+
+	// no ANSI escape codes in log files
 	logger := slog.New(tint.NewHandler(
-		os.Stderr,
-		&tint.Options{Level: logLevel},
+		console.Stderr,
+		&tint.Options{Level: logLevel, NoColor: !console.IsTerminal()},
 	))
 
 	defer func() {
@@ -78,12 +84,10 @@ func Run(buildExecutor ExecutorBuilder) ExecutionResult {
 		return NotOk
 	}
 
-	console := &console.Console{
-		Stdout: os.Stdout,
-		Stderr: os.Stderr,
-	}
+	// cancelled on shutdown, so commands can wait on ctx.Done()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	ctx := context.Background()
 	err = buildExecutor(viper, console, cfg)(ctx, logger)
 
 	if err != nil {
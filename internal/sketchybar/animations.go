@@ -1,10 +1,32 @@
 package sketchybar
 
+import "slices"
+
+// AnimationEasing is one of the animation curves supported by sketchybar --animate.
+type AnimationEasing string
+
 const (
-	AnimationLinear    string = "linear"
-	AnimationQuadratic string = "quadratic"
-	AnimationTanh      string = "tanh"
-	AnimationSin       string = "sin"
-	AnimationExp       string = "exp"
-	AnimationCirc      string = "circ"
+	AnimationLinear    AnimationEasing = "linear"
+	AnimationQuadratic AnimationEasing = "quadratic"
+	AnimationTanh      AnimationEasing = "tanh"
+	AnimationSin       AnimationEasing = "sin"
+	AnimationExp       AnimationEasing = "exp"
+	AnimationCirc      AnimationEasing = "circ"
 )
+
+// IsValid is false for curves which sketchybar does not know.
+func (easing AnimationEasing) IsValid() bool {
+	return slices.Contains([]AnimationEasing{
+		AnimationLinear,
+		AnimationQuadratic,
+		AnimationTanh,
+		AnimationSin,
+		AnimationExp,
+		AnimationCirc,
+	}, easing)
+}
+
+// Animate prefixes args with --animate, duration is in frames.
+func Animate(easing AnimationEasing, duration string, args ...string) []string {
+	return append([]string{"--animate", string(easing), duration}, args...)
+}
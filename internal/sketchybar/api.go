@@ -15,17 +15,23 @@ import (
 type API interface {
 	QueryBar(ctx context.Context) (query.Bar, error)
 	Run(ctx context.Context, arg []string) error
+	// RunBatch runs all the batches at once, see RunBatchWithFallback.
+	RunBatch(ctx context.Context, batches Batches) error
+	// RunAsync does not wait for sketchybar, for commands like --trigger, failures are only logged.
+	RunAsync(ctx context.Context, arg []string)
 }
 
 type realAPI struct {
 	logger  *slog.Logger
 	command *command.Command
+	binary  string
 }
 
 func NewAPI(logger *slog.Logger, command *command.Command) realAPI {
 	return realAPI{
 		logger,
 		command,
+		"sketchybar",
 	}
 }
 
@@ -35,6 +41,20 @@ func (api realAPI) Run(ctx context.Context, arg []string) error {
 	return err
 }
 
+func (api realAPI) RunBatch(ctx context.Context, batches Batches) error {
+	return RunBatchWithFallback(ctx, api.logger, api, batches)
+}
+
+func (api realAPI) RunAsync(ctx context.Context, arg []string) {
+	if len(arg) == 0 {
+		return
+	}
+
+	if err := api.command.RunAsync(ctx, 2*time.Second, api.binary, flattenAndFix(arg)...); err != nil {
+		api.logger.ErrorContext(ctx, "sketchybar: could not run async", slog.Any("error", err))
+	}
+}
+
 func (api realAPI) QueryBar(ctx context.Context) (query.Bar, error) {
 	var bar query.Bar
 
@@ -62,7 +82,7 @@ func (api realAPI) run(ctx context.Context, arg ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
-	out, err := api.command.Run(ctx, "sketchybar", flattenAndFix(arg)...)
+	out, err := api.command.Run(ctx, api.binary, flattenAndFix(arg)...)
 
 	if err != nil {
 		api.logger.ErrorContext(ctx, out)
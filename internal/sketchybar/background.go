@@ -1,13 +1,18 @@
 package sketchybar
 
 type BackgroundOptions struct {
-	Border       BorderOptions
-	Color        ColorOptions
+	Border BorderOptions
+	Color  ColorOptions
+	// Color2 draws a gradient from Color, only some sketchybar versions support it.
+	Color2       ColorOptions
 	Image        ImageOptions
 	Padding      PaddingOptions
 	Drawing      string
 	Height       *int
 	CornerRadius *int
+	BlurRadius   *float64
+	// Opacity is applied to the alpha channel of Color, from 0 to 1.
+	Opacity *float64
 }
 
 func (opts BackgroundOptions) ToArgs(parent *string) []string {
@@ -15,7 +20,12 @@ func (opts BackgroundOptions) ToArgs(parent *string) []string {
 
 	parentAndPrefix := mergeParentAndPrefix(parent, "background")
 
-	args = append(args, opts.Color.ToArgs(parentAndPrefix)...)
+	color := opts.Color
+	if opts.Opacity != nil {
+		color.Color = withOpacity(color.Color, *opts.Opacity)
+	}
+
+	args = append(args, color.ToArgs(parentAndPrefix)...)
 	args = append(args, opts.Border.ToArgs(parentAndPrefix)...)
 	args = append(args, opts.Image.ToArgs(parentAndPrefix)...)
 	args = append(args, opts.Padding.ToArgs(parentAndPrefix)...)
@@ -29,6 +39,17 @@ func (opts BackgroundOptions) ToArgs(parent *string) []string {
 	if opts.CornerRadius != nil {
 		args = withParent(args, parent, "background.corner_radius=%d", *opts.CornerRadius)
 	}
+	if opts.Color2.Color != "" {
+		color2 := opts.Color2.Color
+		if opts.Opacity != nil {
+			color2 = withOpacity(color2, *opts.Opacity)
+		}
+
+		args = withParent(args, parent, "background.color2=%s", color2)
+	}
+	if opts.BlurRadius != nil {
+		args = withParent(args, parent, "background.blur_radius=%g", *opts.BlurRadius)
+	}
 
 	return args
 }
@@ -0,0 +1,37 @@
+package sketchybar_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBackgroundOptions(t *testing.T) {
+	t.Run("should emit the gradient color", func(t *testing.T) {
+		// GIVEN
+		background := sketchybar.BackgroundOptions{
+			Color:  sketchybar.ColorOptions{Color: "0xffcad3f5"},
+			Color2: sketchybar.ColorOptions{Color: "0xff8aadf4"},
+		}
+
+		// WHEN
+		args := background.ToArgs(nil)
+
+		// THEN
+		require.Equal(t, []string{"background.color=0xffcad3f5", "background.color2=0xff8aadf4"}, args)
+	})
+
+	t.Run("should not emit the gradient color when unset", func(t *testing.T) {
+		// GIVEN
+		background := sketchybar.BackgroundOptions{
+			Color: sketchybar.ColorOptions{Color: "0xffcad3f5"},
+		}
+
+		// WHEN
+		args := background.ToArgs(nil)
+
+		// THEN
+		require.Equal(t, []string{"background.color=0xffcad3f5"}, args)
+	})
+}
@@ -0,0 +1,93 @@
+package sketchybar
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/query"
+)
+
+const (
+	backpressureBaseDelay = 25 * time.Millisecond
+	backpressureMaxDelay  = 500 * time.Millisecond
+)
+
+// BackpressureAPI slows down Run while sketchybar keeps failing,
+// so that a busy sketchybar is not flooded by a render on every event.
+type BackpressureAPI struct {
+	logger   *slog.Logger
+	api      API
+	mutex    sync.Mutex
+	failures int
+}
+
+func NewBackpressureAPI(logger *slog.Logger, api API) *BackpressureAPI {
+	return &BackpressureAPI{
+		logger: logger,
+		api:    api,
+	}
+}
+
+func (api *BackpressureAPI) Run(ctx context.Context, arg []string) error {
+	if delay := backpressureDelay(api.consecutiveFailures()); delay > 0 {
+		api.logger.DebugContext(ctx, "sketchybar: backing off", slog.Duration("delay", delay))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	err := api.api.Run(ctx, arg)
+
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	if err != nil {
+		api.failures++
+		return err
+	}
+
+	api.failures = 0
+	return nil
+}
+
+// RunBatch backs off on every run, the fallback ones too.
+func (api *BackpressureAPI) RunBatch(ctx context.Context, batches Batches) error {
+	return RunBatchWithFallback(ctx, api.logger, api, batches)
+}
+
+// RunAsync does not back off, nothing waits for it and its failures are not known.
+func (api *BackpressureAPI) RunAsync(ctx context.Context, arg []string) {
+	api.api.RunAsync(ctx, arg)
+}
+
+func (api *BackpressureAPI) QueryBar(ctx context.Context) (query.Bar, error) {
+	return api.api.QueryBar(ctx)
+}
+
+func (api *BackpressureAPI) consecutiveFailures() int {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+
+	return api.failures
+}
+
+// backpressureDelay doubles on every consecutive failure, up to backpressureMaxDelay.
+func backpressureDelay(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+
+	delay := backpressureBaseDelay
+	for i := 1; i < failures && delay < backpressureMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, backpressureMaxDelay)
+}
+
+var _ API = (*BackpressureAPI)(nil)
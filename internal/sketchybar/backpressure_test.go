@@ -0,0 +1,77 @@
+//nolint:testpackage // want to test internals
+package sketchybar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/query"
+	"github.com/lucax88x/wentsketchy/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type failingAPI struct {
+	err error
+}
+
+func (api *failingAPI) Run(_ context.Context, _ []string) error {
+	return api.err
+}
+
+func (api *failingAPI) RunBatch(ctx context.Context, batches Batches) error {
+	return RunBatchWithFallback(ctx, testutils.CreateTestLogger(), api, batches)
+}
+
+func (api *failingAPI) RunAsync(_ context.Context, _ []string) {}
+
+func (api *failingAPI) QueryBar(_ context.Context) (query.Bar, error) {
+	return query.Bar{}, api.err
+}
+
+func TestUnitBackpressure(t *testing.T) {
+	t.Run("should grow the delay exponentially up to the max", func(t *testing.T) {
+		require.Equal(t, time.Duration(0), backpressureDelay(0))
+		require.Equal(t, 25*time.Millisecond, backpressureDelay(1))
+		require.Equal(t, 50*time.Millisecond, backpressureDelay(2))
+		require.Equal(t, 100*time.Millisecond, backpressureDelay(3))
+		require.Equal(t, 500*time.Millisecond, backpressureDelay(10))
+		require.Equal(t, 500*time.Millisecond, backpressureDelay(1000))
+	})
+
+	t.Run("should count consecutive failures and reset on success", func(t *testing.T) {
+		// GIVEN
+		ctx := context.Background()
+		inner := &failingAPI{err: errors.New("busy")}
+		api := NewBackpressureAPI(testutils.CreateTestLogger(), inner)
+
+		// WHEN
+		require.Error(t, api.Run(ctx, []string{"--update"}))
+		require.Error(t, api.Run(ctx, []string{"--update"}))
+
+		// THEN
+		require.Equal(t, 2, api.consecutiveFailures())
+
+		// WHEN
+		inner.err = nil
+		require.NoError(t, api.Run(ctx, []string{"--update"}))
+
+		// THEN
+		require.Equal(t, 0, api.consecutiveFailures())
+	})
+
+	t.Run("should stop waiting when context is canceled", func(t *testing.T) {
+		// GIVEN
+		ctx, cancel := context.WithCancel(context.Background())
+		api := NewBackpressureAPI(testutils.CreateTestLogger(), &failingAPI{})
+		api.failures = 100
+		cancel()
+
+		// WHEN
+		err := api.Run(ctx, []string{"--update"})
+
+		// THEN
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
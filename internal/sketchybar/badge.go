@@ -0,0 +1,36 @@
+package sketchybar
+
+import "fmt"
+
+// BadgeOptions shows a count next to an item. sketchybar has no badge property,
+// so the badge is the label of its own item, see BadgeItemName, hidden while Value is 0.
+type BadgeOptions struct {
+	Value int
+	Color string
+	Font  string
+}
+
+// BadgeItemName is the item holding the badge of host, so that the label of host is kept.
+func BadgeItemName(host string) string {
+	return host + ".badge"
+}
+
+func (opts BadgeOptions) ToArgs() []string {
+	if opts.Value <= 0 {
+		return []string{"label.drawing=off"}
+	}
+
+	args := []string{
+		"label.drawing=on",
+		fmt.Sprintf("label=%d", opts.Value),
+	}
+
+	if opts.Color != "" {
+		args = with(args, "label.color=%s", opts.Color)
+	}
+	if opts.Font != "" {
+		args = with(args, "label.font=%s", opts.Font)
+	}
+
+	return args
+}
@@ -0,0 +1,40 @@
+package sketchybar_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBadgeOptions(t *testing.T) {
+	t.Run("should draw the count with the label", func(t *testing.T) {
+		// GIVEN
+		badge := sketchybar.BadgeOptions{Value: 3, Color: "0xffed8796"}
+
+		// WHEN
+		args := badge.ToArgs()
+
+		// THEN
+		require.Equal(t, []string{"label.drawing=on", "label=3", "label.color=0xffed8796"}, args)
+	})
+
+	t.Run("should name the badge item after its host", func(t *testing.T) {
+		// WHEN
+		name := sketchybar.BadgeItemName("mail")
+
+		// THEN
+		require.Equal(t, "mail.badge", name)
+	})
+
+	t.Run("should hide the label without count", func(t *testing.T) {
+		// GIVEN
+		badge := sketchybar.BadgeOptions{Value: 0, Color: "0xffed8796"}
+
+		// WHEN
+		args := badge.ToArgs()
+
+		// THEN
+		require.Equal(t, []string{"label.drawing=off"}, args)
+	})
+}
@@ -1,5 +1,10 @@
 package sketchybar
 
+import (
+	"errors"
+	"fmt"
+)
+
 type BarOptions struct {
 	Padding       PaddingOptions
 	Color         ColorOptions
@@ -10,16 +15,26 @@ type BarOptions struct {
 	Background    BackgroundOptions
 	Position      string
 	Sticky        string
-	YOffset       *int
-	Margin        *int
-	Topmost       string
+	// YOffset moves the bar down when positive and up when negative, by at most its height.
+	YOffset      *int
+	Margin       *int
+	Topmost      string
+	BlurRadius   *float64
+	CornerRadius *int
+	// Opacity is applied to the alpha channel of Color, from 0 to 1.
+	Opacity *float64
 }
 
 func (opts BarOptions) ToArgs() []string {
 	args := []string{}
 
 	args = append(args, opts.Padding.ToArgs(nil)...)
-	args = append(args, opts.Color.ToArgs(nil)...)
+	color := opts.Color
+	if opts.Opacity != nil {
+		color.Color = withOpacity(color.Color, *opts.Opacity)
+	}
+
+	args = append(args, color.ToArgs(nil)...)
 	args = append(args, opts.Border.ToArgs(nil)...)
 
 	if opts.Height != nil {
@@ -46,6 +61,28 @@ func (opts BarOptions) ToArgs() []string {
 	if opts.Topmost != "" {
 		args = with(args, "topmost=%s", opts.Topmost)
 	}
+	if opts.BlurRadius != nil {
+		args = with(args, "blur_radius=%g", *opts.BlurRadius)
+	}
+	if opts.CornerRadius != nil {
+		args = with(args, "corner_radius=%d", *opts.CornerRadius)
+	}
 
 	return args
 }
+
+// ValidateBarOptions checks the offsets sketchybar would accept but which would draw the bar off screen.
+// YOffset is only checked against Height when both are set.
+func ValidateBarOptions(opts BarOptions) error {
+	var errs []error
+
+	if opts.YOffset != nil && opts.Height != nil && (*opts.YOffset < -*opts.Height || *opts.YOffset > *opts.Height) {
+		errs = append(errs, fmt.Errorf("bar: y_offset %d is outside [-%d, %d]", *opts.YOffset, *opts.Height, *opts.Height))
+	}
+
+	if opts.Margin != nil && *opts.Margin < 0 {
+		errs = append(errs, fmt.Errorf("bar: margin %d is negative", *opts.Margin))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+package sketchybar_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBarOptions(t *testing.T) {
+	t.Run("should emit blur radius", func(t *testing.T) {
+		// GIVEN
+		blur := 30.0
+		bar := sketchybar.BarOptions{BlurRadius: &blur}
+
+		// WHEN
+		args := bar.ToArgs()
+
+		// THEN
+		require.Equal(t, []string{"blur_radius=30"}, args)
+	})
+
+	t.Run("should emit corner radius", func(t *testing.T) {
+		// GIVEN
+		radius := 9
+		bar := sketchybar.BarOptions{CornerRadius: &radius}
+
+		// WHEN
+		args := bar.ToArgs()
+
+		// THEN
+		require.Equal(t, []string{"corner_radius=9"}, args)
+	})
+
+	t.Run("should apply opacity to the color alpha", func(t *testing.T) {
+		// GIVEN
+		opacity := 0.5
+		bar := sketchybar.BarOptions{
+			Color:   sketchybar.ColorOptions{Color: "0xffcad3f5"},
+			Opacity: &opacity,
+		}
+
+		// WHEN
+		args := bar.ToArgs()
+
+		// THEN
+		require.Equal(t, []string{"color=0x80cad3f5"}, args)
+	})
+
+	t.Run("should apply opacity to the background color", func(t *testing.T) {
+		// GIVEN
+		opacity := 0.0
+		background := sketchybar.BackgroundOptions{
+			Color:   sketchybar.ColorOptions{Color: "0xffcad3f5"},
+			Opacity: &opacity,
+		}
+
+		// WHEN
+		args := background.ToArgs(nil)
+
+		// THEN
+		require.Equal(t, []string{"background.color=0x00cad3f5"}, args)
+	})
+	t.Run("should validate y offset within the bar height", func(t *testing.T) {
+		// GIVEN
+		height := 40
+		up := -40
+		down := 41
+
+		// THEN
+		require.NoError(t, sketchybar.ValidateBarOptions(sketchybar.BarOptions{Height: &height, YOffset: &up}))
+		require.Error(t, sketchybar.ValidateBarOptions(sketchybar.BarOptions{Height: &height, YOffset: &down}))
+		require.NoError(t, sketchybar.ValidateBarOptions(sketchybar.BarOptions{YOffset: &down}))
+	})
+
+	t.Run("should refuse a negative margin", func(t *testing.T) {
+		// GIVEN
+		margin := -1
+
+		// THEN
+		require.Error(t, sketchybar.ValidateBarOptions(sketchybar.BarOptions{Margin: &margin}))
+	})
+}
@@ -0,0 +1,128 @@
+package sketchybar
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Batches groups the args of a sketchybar run, usually one sub-batch per item.
+type Batches [][]string
+
+type batchCommand struct {
+	batch  int
+	tokens []string
+}
+
+// Merge returns a new Batches with the batches of other after the ones of b, neither is modified.
+func (b Batches) Merge(other Batches) Batches {
+	return slices.Concat(b, other)
+}
+
+// Deduplicate keeps only the last value of each (item, property) pair set by --set.
+// Any other command in between (--add, --move, --remove, ...) stops the deduplication,
+// and everything after an --animate is kept as is, since animations chain their values.
+func (b Batches) Deduplicate() Batches {
+	commands := splitCommands(b)
+
+	animateFrom := len(commands)
+	for i, command := range commands {
+		if command.tokens[0] == "--animate" {
+			animateFrom = i
+			break
+		}
+	}
+
+	seen := make(map[[2]string]bool)
+	for i := len(commands) - 1; i >= 0; i-- {
+		command := commands[i]
+
+		if i >= animateFrom || command.tokens[0] != "--set" || len(command.tokens) < 3 {
+			clear(seen)
+			continue
+		}
+
+		item := command.tokens[1]
+		props := make([]string, 0, len(command.tokens)-2)
+
+		for _, prop := range command.tokens[2:] {
+			name, _, found := strings.Cut(prop, "=")
+
+			if found {
+				key := [2]string{item, name}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+
+			props = append(props, prop)
+		}
+
+		if len(props) == 0 {
+			commands[i].tokens = nil
+			continue
+		}
+
+		commands[i].tokens = append([]string{"--set", item}, props...)
+	}
+
+	result := make(Batches, len(b))
+	for _, command := range commands {
+		result[command.batch] = append(result[command.batch], command.tokens...)
+	}
+
+	return compact(result)
+}
+
+func splitCommands(b Batches) []batchCommand {
+	commands := make([]batchCommand, 0, len(b))
+
+	for i, batch := range b {
+		for _, token := range batch {
+			if strings.HasPrefix(token, "--") || len(commands) == 0 || commands[len(commands)-1].batch != i {
+				commands = append(commands, batchCommand{batch: i})
+			}
+
+			last := &commands[len(commands)-1]
+			last.tokens = append(last.tokens, token)
+		}
+	}
+
+	return commands
+}
+
+func compact(b Batches) Batches {
+	result := make(Batches, 0, len(b))
+
+	for _, batch := range b {
+		if len(batch) > 0 {
+			result = append(result, batch)
+		}
+	}
+
+	return result
+}
+
+// Debug formats every sub-batch on its own line, like "[batch 1]: --set foo width=10".
+func (b Batches) Debug() string {
+	var builder strings.Builder
+
+	for i, batch := range b {
+		fmt.Fprintf(&builder, "[batch %d]: %s\n", i+1, strings.Join(batch, " "))
+	}
+
+	return builder.String()
+}
+
+// SplitBatches splits flat args back into batches, one for each command.
+func SplitBatches(args []string) Batches {
+	commands := splitCommands(Batches{args})
+	result := make(Batches, 0, len(commands))
+
+	for _, command := range commands {
+		result = append(result, command.tokens)
+	}
+
+	return result
+}
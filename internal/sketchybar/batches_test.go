@@ -0,0 +1,116 @@
+package sketchybar_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBatches(t *testing.T) {
+	t.Run("should keep only the last value of each item property", func(t *testing.T) {
+		// GIVEN
+		batches := sketchybar.Batches{
+			{"--set", "foo", "width=10", "label=a"},
+			{"--set", "bar", "width=10"},
+			{"--set", "foo", "width=20"},
+		}
+
+		// WHEN
+		result := batches.Deduplicate()
+
+		// THEN
+		require.Equal(t, sketchybar.Batches{
+			{"--set", "foo", "label=a"},
+			{"--set", "bar", "width=10"},
+			{"--set", "foo", "width=20"},
+		}, result)
+	})
+
+	t.Run("should drop a set left without properties", func(t *testing.T) {
+		// GIVEN
+		batches := sketchybar.Batches{
+			{"--set", "foo", "width=10"},
+			{"--set", "foo", "width=20", "--set", "bar", "width=5"},
+		}
+
+		// WHEN
+		result := batches.Deduplicate()
+
+		// THEN
+		require.Equal(t, sketchybar.Batches{
+			{"--set", "foo", "width=20", "--set", "bar", "width=5"},
+		}, result)
+	})
+
+	t.Run("should not deduplicate across other commands", func(t *testing.T) {
+		// GIVEN
+		batches := sketchybar.Batches{
+			{"--set", "foo", "width=10"},
+			{"--remove", "foo"},
+			{"--add", "item", "foo", "left", "--set", "foo", "width=20"},
+		}
+
+		// WHEN
+		result := batches.Deduplicate()
+
+		// THEN
+		require.Equal(t, batches, result)
+	})
+
+	t.Run("should keep everything after an animation", func(t *testing.T) {
+		// GIVEN
+		batches := sketchybar.Batches{
+			{"--animate", "tanh", "10", "--set", "foo", "y_offset=10"},
+			{"--set", "foo", "y_offset=0"},
+		}
+
+		// WHEN
+		result := batches.Deduplicate()
+
+		// THEN
+		require.Equal(t, batches, result)
+	})
+
+	t.Run("should print one line per batch", func(t *testing.T) {
+		// GIVEN
+		batches := sketchybar.Batches{
+			{"--set", "foo", "width=10", "background.color=0xff000000"},
+			{"--set", "bar", "drawing=off"},
+		}
+
+		// WHEN
+		result := batches.Debug()
+
+		// THEN
+		require.Equal(t,
+			"[batch 1]: --set foo width=10 background.color=0xff000000\n[batch 2]: --set bar drawing=off\n",
+			result)
+	})
+
+	t.Run("should split flat args by command", func(t *testing.T) {
+		// WHEN
+		result := sketchybar.SplitBatches([]string{"--add", "item", "foo", "left", "--set", "foo", "width=10"})
+
+		// THEN
+		require.Equal(t, sketchybar.Batches{
+			{"--add", "item", "foo", "left"},
+			{"--set", "foo", "width=10"},
+		}, result)
+	})
+
+	t.Run("should merge without modifying the original batches", func(t *testing.T) {
+		// GIVEN
+		batches := make(sketchybar.Batches, 1, 2)
+		batches[0] = []string{"--set", "foo", "width=10"}
+
+		// WHEN
+		left := batches.Merge(sketchybar.Batches{{"--set", "bar", "width=5"}})
+		right := batches.Merge(sketchybar.Batches{{"--set", "baz", "width=5"}})
+
+		// THEN
+		require.Len(t, batches, 1)
+		require.Equal(t, sketchybar.Batches{{"--set", "foo", "width=10"}, {"--set", "bar", "width=5"}}, left)
+		require.Equal(t, sketchybar.Batches{{"--set", "foo", "width=10"}, {"--set", "baz", "width=5"}}, right)
+	})
+}
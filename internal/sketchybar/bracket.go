@@ -3,6 +3,17 @@ package sketchybar
 type BracketOptions struct {
 	// PADDING NOT SUPPORTED!
 	Background BackgroundOptions
+	Members    []string
+}
+
+func (opts BracketOptions) WithMembers(ids ...string) BracketOptions {
+	opts.Members = append([]string{}, ids...)
+	return opts
+}
+
+func (opts BracketOptions) WithBackground(background BackgroundOptions) BracketOptions {
+	opts.Background = background
+	return opts
 }
 
 func (opts BracketOptions) ToArgs() []string {
@@ -12,3 +23,13 @@ func (opts BracketOptions) ToArgs() []string {
 
 	return args
 }
+
+// Build returns both the --add and the --set of the bracket as a single batch.
+func (opts BracketOptions) Build(name string) []string {
+	args := []string{"--add", "bracket", name}
+	args = append(args, opts.Members...)
+	args = append(args, "--set", name)
+	args = append(args, opts.ToArgs()...)
+
+	return args
+}
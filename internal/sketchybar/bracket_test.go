@@ -0,0 +1,26 @@
+package sketchybar_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitBracketOptions(t *testing.T) {
+	t.Run("should add and set the bracket in one batch", func(t *testing.T) {
+		// GIVEN
+		bracket := sketchybar.BracketOptions{}.
+			WithMembers("a", "b").
+			WithBackground(sketchybar.BackgroundOptions{Drawing: "on"})
+
+		// WHEN
+		args := bracket.Build("bracket.name")
+
+		// THEN
+		require.Equal(t, []string{
+			"--add", "bracket", "bracket.name", "a", "b",
+			"--set", "bracket.name", "background.drawing=on",
+		}, args)
+	})
+}
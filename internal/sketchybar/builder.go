@@ -0,0 +1,166 @@
+package sketchybar
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ItemBuilder assembles ItemOptions step by step,
+// invalid values are collected and reported by Build.
+type ItemBuilder struct {
+	opts ItemOptions
+	errs []error
+}
+
+func NewItem() *ItemBuilder {
+	return &ItemBuilder{}
+}
+
+func (b *ItemBuilder) WithIcon(value string, font FontOptions) *ItemBuilder {
+	if value == "" {
+		b.errs = append(b.errs, errors.New("builder: icon value is empty"))
+	}
+
+	b.opts.Icon.Value = value
+	b.opts.Icon.Font = font
+	return b
+}
+
+func (b *ItemBuilder) WithIconColor(color string) *ItemBuilder {
+	b.opts.Icon.Color.Color = color
+	return b
+}
+
+func (b *ItemBuilder) WithIconPadding(left *int, right *int) *ItemBuilder {
+	b.opts.Icon.Padding = PaddingOptions{Left: left, Right: right}
+	return b
+}
+
+func (b *ItemBuilder) WithIconBackground(background BackgroundOptions) *ItemBuilder {
+	b.opts.Icon.Background = background
+	return b
+}
+
+func (b *ItemBuilder) WithIconDrawing(drawing string) *ItemBuilder {
+	b.opts.Icon.Drawing = drawing
+	return b
+}
+
+func (b *ItemBuilder) WithLabel(value string) *ItemBuilder {
+	b.opts.Label.Value = value
+	return b
+}
+
+func (b *ItemBuilder) WithLabelFont(font FontOptions) *ItemBuilder {
+	b.opts.Label.Font = font
+	return b
+}
+
+func (b *ItemBuilder) WithLabelPadding(left *int, right *int) *ItemBuilder {
+	b.opts.Label.Padding = PaddingOptions{Left: left, Right: right}
+	return b
+}
+
+func (b *ItemBuilder) WithLabelDrawing(drawing string) *ItemBuilder {
+	b.opts.Label.Drawing = drawing
+	return b
+}
+
+func (b *ItemBuilder) WithPadding(left *int, right *int) *ItemBuilder {
+	b.opts.Padding = PaddingOptions{Left: left, Right: right}
+	return b
+}
+
+func (b *ItemBuilder) WithBackground(background BackgroundOptions) *ItemBuilder {
+	b.opts.Background = background
+	return b
+}
+
+func (b *ItemBuilder) WithWidth(width int) *ItemBuilder {
+	if width < 0 {
+		b.errs = append(b.errs, fmt.Errorf("builder: width must not be negative, got %d", width))
+	}
+
+	b.opts.Width = &width
+	return b
+}
+
+func (b *ItemBuilder) WithYOffset(offset int) *ItemBuilder {
+	b.opts.YOffset = &offset
+	return b
+}
+
+func (b *ItemBuilder) WithScrollTexts(scrollTexts string) *ItemBuilder {
+	b.opts.ScrollTexts = scrollTexts
+	return b
+}
+
+func (b *ItemBuilder) WithUpdateFreq(freq int) *ItemBuilder {
+	if freq <= 0 {
+		b.errs = append(b.errs, fmt.Errorf("builder: update_freq must be positive, got %d", freq))
+	}
+
+	b.opts.UpdateFreq = &freq
+	return b
+}
+
+func (b *ItemBuilder) WithUpdates(updates string) *ItemBuilder {
+	b.opts.Updates = updates
+	return b
+}
+
+func (b *ItemBuilder) WithScript(script string) *ItemBuilder {
+	if script == "" {
+		b.errs = append(b.errs, errors.New("builder: script is empty"))
+	}
+
+	b.opts.Script = script
+	return b
+}
+
+func (b *ItemBuilder) WithClickScript(script string) *ItemBuilder {
+	if script == "" {
+		b.errs = append(b.errs, errors.New("builder: click_script is empty"))
+	}
+
+	b.opts.ClickScript = script
+	return b
+}
+
+func (b *ItemBuilder) WithRightClickScript(script string) *ItemBuilder {
+	if script == "" {
+		b.errs = append(b.errs, errors.New("builder: right click script is empty"))
+	}
+
+	b.opts.RightClickScript = script
+	return b
+}
+
+func (b *ItemBuilder) WithScrollScript(script string) *ItemBuilder {
+	if script == "" {
+		b.errs = append(b.errs, errors.New("builder: scroll script is empty"))
+	}
+
+	b.opts.ScrollScript = script
+	return b
+}
+
+func (b *ItemBuilder) WithDisplay(display string) *ItemBuilder {
+	b.opts.Display = display
+	return b
+}
+
+// Build returns the assembled options, display is required.
+func (b *ItemBuilder) Build() (ItemOptions, error) {
+	errs := slices.Clone(b.errs)
+	if b.opts.Display == "" {
+		errs = append(errs, errors.New("builder: display is required"))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return ItemOptions{}, err
+	}
+
+	return b.opts, nil
+}
@@ -0,0 +1,96 @@
+package sketchybar_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitItemBuilder(t *testing.T) {
+	left := 5
+	right := 10
+
+	t.Run("should build the same options as a literal", func(t *testing.T) {
+		// GIVEN
+		expected := sketchybar.ItemOptions{
+			Display: "active",
+			Padding: sketchybar.PaddingOptions{Left: &left, Right: &right},
+			Icon: sketchybar.ItemIconOptions{
+				Value: "x",
+				Font:  sketchybar.FontOptions{Font: "Hack"},
+			},
+			Label:        sketchybar.ItemLabelOptions{Value: "label"},
+			ClickScript:  "echo",
+			ScrollScript: "echo $SCROLL_DELTA",
+		}
+
+		// WHEN
+		opts, err := sketchybar.NewItem().
+			WithDisplay("active").
+			WithPadding(&left, &right).
+			WithIcon("x", sketchybar.FontOptions{Font: "Hack"}).
+			WithLabel("label").
+			WithClickScript("echo").
+			WithScrollScript("echo $SCROLL_DELTA").
+			Build()
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, expected.ToArgs(), opts.ToArgs())
+	})
+
+	t.Run("should branch click_script on the right button", func(t *testing.T) {
+		// WHEN
+		opts, err := sketchybar.NewItem().
+			WithDisplay("active").
+			WithClickScript("open -a Battery").
+			WithRightClickScript("echo popup").
+			Build()
+
+		// THEN
+		require.NoError(t, err)
+		require.Contains(t, opts.ToArgs(),
+			"click_script=if [ \"$BUTTON\" = \"right\" ]; then\necho popup\nelse\nopen -a Battery\nfi")
+	})
+
+	t.Run("should branch script on mouse.scrolled", func(t *testing.T) {
+		// WHEN
+		opts, err := sketchybar.NewItem().
+			WithDisplay("active").
+			WithScript("echo update").
+			WithScrollScript("echo $SCROLL_DELTA").
+			Build()
+
+		// THEN
+		require.NoError(t, err)
+		require.Contains(t, opts.ToArgs(),
+			"script=if [ \"$SENDER\" = \"mouse.scrolled\" ]; then\necho $SCROLL_DELTA\nelse\necho update\nfi")
+		require.NotContains(t, opts.ToArgs(), "scroll_action=echo $SCROLL_DELTA")
+	})
+
+	t.Run("should fail without display", func(t *testing.T) {
+		// WHEN
+		_, err := sketchybar.NewItem().WithLabel("label").Build()
+
+		// THEN
+		require.ErrorContains(t, err, "display is required")
+	})
+
+	t.Run("should collect every invalid value", func(t *testing.T) {
+		// WHEN
+		_, err := sketchybar.NewItem().
+			WithDisplay("active").
+			WithIcon("", sketchybar.EmptyFontOptions).
+			WithUpdateFreq(0).
+			WithClickScript("").
+			WithWidth(-1).
+			Build()
+
+		// THEN
+		require.ErrorContains(t, err, "icon value is empty")
+		require.ErrorContains(t, err, "update_freq must be positive")
+		require.ErrorContains(t, err, "click_script is empty")
+		require.ErrorContains(t, err, "width must not be negative")
+	})
+}
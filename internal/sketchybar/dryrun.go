@@ -0,0 +1,48 @@
+package sketchybar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/query"
+)
+
+// DryRunAPI prints the args instead of sending them to sketchybar.
+type DryRunAPI struct {
+	out io.Writer
+}
+
+func NewDryRunAPI(out io.Writer) DryRunAPI {
+	return DryRunAPI{out}
+}
+
+func (api DryRunAPI) Run(_ context.Context, arg []string) error {
+	if len(arg) == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprint(api.out, SplitBatches(arg).Debug())
+
+	if err != nil {
+		return fmt.Errorf("sketchybar: could not write dry run. %w", err)
+	}
+
+	return nil
+}
+
+func (api DryRunAPI) RunBatch(ctx context.Context, batches Batches) error {
+	return RunBatchWithFallback(ctx, slog.New(slog.DiscardHandler), api, batches)
+}
+
+// RunAsync prints right away, there is nothing to wait for.
+func (api DryRunAPI) RunAsync(ctx context.Context, arg []string) {
+	_ = api.Run(ctx, arg)
+}
+
+func (api DryRunAPI) QueryBar(_ context.Context) (query.Bar, error) {
+	return query.Bar{}, nil
+}
+
+var _ API = DryRunAPI{}
@@ -1,5 +1,7 @@
 package sketchybar
 
+import "fmt"
+
 type ItemOptions struct {
 	Icon        ItemIconOptions
 	Label       ItemLabelOptions
@@ -15,7 +17,14 @@ type ItemOptions struct {
 	ScrollTexts string
 	Script      string
 	ClickScript string
-	MachHelper  string
+	// RightClickScript runs instead of ClickScript on a right click.
+	// sketchybar only knows click_script, so both are emitted there branching on $BUTTON.
+	RightClickScript string
+	// ScrollScript runs instead of Script on mouse.scrolled, $SCROLL_DELTA holds the delta.
+	// sketchybar has no scroll script, so both are emitted in script branching on $SENDER,
+	// the item still has to subscribe to mouse.scrolled.
+	ScrollScript string
+	MachHelper   string
 }
 
 func (opts ItemOptions) ToArgs() []string {
@@ -48,11 +57,11 @@ func (opts ItemOptions) ToArgs() []string {
 	if opts.ScrollTexts != "" {
 		args = with(args, "scroll_texts=%s", opts.ScrollTexts)
 	}
-	if opts.Script != "" {
-		args = with(args, "script=%s", opts.Script)
+	if script := opts.script(); script != "" {
+		args = with(args, "script=%s", script)
 	}
-	if opts.ClickScript != "" {
-		args = with(args, "click_script=%s", opts.ClickScript)
+	if clickScript := opts.clickScript(); clickScript != "" {
+		args = with(args, "click_script=%s", clickScript)
 	}
 	if opts.MachHelper != "" {
 		args = with(args, "mach_helper=%s", opts.MachHelper)
@@ -61,6 +70,32 @@ func (opts ItemOptions) ToArgs() []string {
 	return args
 }
 
+func (opts ItemOptions) script() string {
+	if opts.ScrollScript == "" {
+		return opts.Script
+	}
+
+	otherScript := opts.Script
+	if otherScript == "" {
+		otherScript = ":"
+	}
+
+	return fmt.Sprintf("if [ \"$SENDER\" = \"mouse.scrolled\" ]; then\n%s\nelse\n%s\nfi", opts.ScrollScript, otherScript)
+}
+
+func (opts ItemOptions) clickScript() string {
+	if opts.RightClickScript == "" {
+		return opts.ClickScript
+	}
+
+	leftClickScript := opts.ClickScript
+	if leftClickScript == "" {
+		leftClickScript = ":"
+	}
+
+	return fmt.Sprintf("if [ \"$BUTTON\" = \"right\" ]; then\n%s\nelse\n%s\nfi", opts.RightClickScript, leftClickScript)
+}
+
 type ItemIconOptions struct {
 	Padding    PaddingOptions
 	Color      ColorOptions
@@ -113,8 +148,8 @@ func (opts ItemLabelOptions) ToArgs() []string {
 	args = append(args, opts.Color.ToArgs(&parent)...)
 
 	if opts.Value != "" {
-	args = with(args, "label=%s", opts.Value)
-}
+		args = with(args, "label=%s", opts.Value)
+	}
 	if opts.Font != EmptyFontOptions {
 		args = with(args, "label.font=%s", opts.Font.String())
 	}
@@ -0,0 +1,40 @@
+package sketchybar
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/query"
+)
+
+// LoggingSketchybarAPI logs every batch sent to sketchybar at debug level, like --dry-run but still running it.
+type LoggingSketchybarAPI struct {
+	logger *slog.Logger
+	api    API
+}
+
+func NewLoggingSketchybarAPI(logger *slog.Logger, api API) LoggingSketchybarAPI {
+	return LoggingSketchybarAPI{logger, api}
+}
+
+func (api LoggingSketchybarAPI) Run(ctx context.Context, arg []string) error {
+	api.logger.DebugContext(ctx, "sketchybar: run", slog.Any("args", arg))
+
+	return api.api.Run(ctx, arg)
+}
+
+func (api LoggingSketchybarAPI) RunBatch(ctx context.Context, batches Batches) error {
+	return RunBatchWithFallback(ctx, api.logger, api, batches)
+}
+
+func (api LoggingSketchybarAPI) RunAsync(ctx context.Context, arg []string) {
+	api.logger.DebugContext(ctx, "sketchybar: run async", slog.Any("args", arg))
+
+	api.api.RunAsync(ctx, arg)
+}
+
+func (api LoggingSketchybarAPI) QueryBar(ctx context.Context) (query.Bar, error) {
+	return api.api.QueryBar(ctx)
+}
+
+var _ API = LoggingSketchybarAPI{}
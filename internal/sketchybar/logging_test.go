@@ -0,0 +1,29 @@
+//nolint:testpackage // want to test internals
+package sketchybar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitLoggingSketchybarAPI(t *testing.T) {
+	t.Run("should log the args and run them", func(t *testing.T) {
+		// GIVEN
+		var out bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		inner := &failingAPI{err: errors.New("busy")}
+		api := NewLoggingSketchybarAPI(logger, inner)
+
+		// WHEN
+		err := api.Run(context.Background(), []string{"--set", "battery", "label=42%"})
+
+		// THEN
+		require.ErrorIs(t, err, inner.err)
+		require.Contains(t, out.String(), `msg="sketchybar: run" args="[--set battery label=42%]"`)
+	})
+}
@@ -1,6 +1,11 @@
 package sketchybar
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+
+	"github.com/lucax88x/wentsketchy/internal/color"
+)
 
 type PaddingOptions struct {
 	Left  *int
@@ -43,6 +48,14 @@ func (opts PaddingOptions) ToArgs(parent *string) []string {
 	return args
 }
 
+// WithAlpha returns a copy with the alpha of Color replaced, from 0 to 1.
+// Colors which are not in the 0xAARRGGBB form are left as they are.
+func (opts ColorOptions) WithAlpha(a float64) ColorOptions {
+	opts.Color = withOpacity(opts.Color, a)
+
+	return opts
+}
+
 func (opts ColorOptions) ToArgs(parent *string) []string {
 	args := []string{}
 
@@ -70,6 +83,19 @@ func (opts BorderOptions) ToArgs(parent *string) []string {
 	return args
 }
 
+// withOpacity replaces the alpha channel of a 0xAARRGGBB color,
+// colors in any other format are returned as they are.
+func withOpacity(hex string, opacity float64) string {
+	r, g, b, _, err := color.HexToRGBA(hex)
+	if err != nil {
+		return hex
+	}
+
+	alpha := uint8(math.Round(math.Max(0, math.Min(1, opacity)) * 255))
+
+	return color.RGBAToHex(r, g, b, alpha)
+}
+
 func withParent[T any](args []string, parent *string, format string, value T) []string {
 	if parent != nil {
 		format = *parent + "." + format
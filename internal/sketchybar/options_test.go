@@ -0,0 +1,26 @@
+package sketchybar_test
+
+import (
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/sketchybar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitColorOptions(t *testing.T) {
+	t.Run("should replace the alpha", func(t *testing.T) {
+		// GIVEN
+		color := sketchybar.ColorOptions{Color: "0xffcad3f5", HighlightColor: "0xff8aadf4"}
+
+		// WHEN
+		dimmed := color.WithAlpha(0.585)
+
+		// THEN
+		require.Equal(t, sketchybar.ColorOptions{Color: "0x95cad3f5", HighlightColor: "0xff8aadf4"}, dimmed)
+		require.Equal(t, "0xffcad3f5", color.Color)
+	})
+
+	t.Run("should leave unknown colors as they are", func(t *testing.T) {
+		require.Equal(t, "red", sketchybar.ColorOptions{Color: "red"}.WithAlpha(0.5).Color)
+	})
+}
@@ -0,0 +1,60 @@
+package sketchybar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// RunBatchWithFallback sends all the batches in a single run of api, so a single sketchybar process.
+// When that fails each batch is run on its own, so that a broken item does not take down the others.
+// The single run may have applied most batches already, their second run fails too (an --add of an item
+// which exists), so a failing batch is only logged and an error is returned when every batch failed.
+func RunBatchWithFallback(ctx context.Context, logger *slog.Logger, api API, batches Batches) error {
+	err := api.Run(ctx, slices.Concat(batches...))
+
+	if err == nil {
+		return nil
+	}
+
+	logger.WarnContext(ctx, "sketchybar: single run failed, running the batches one by one", slog.Any("error", err))
+
+	var errs []error
+	ran := 0
+	for index, batch := range batches {
+		if len(batch) == 0 {
+			continue
+		}
+
+		ran++
+		if batchErr := api.Run(ctx, batch); batchErr != nil {
+			logger.ErrorContext(ctx, "sketchybar: batch failed",
+				slog.Int("index", index),
+				slog.String("item", batchItem(batch)),
+				slog.Any("error", batchErr))
+
+			errs = append(errs, batchErr)
+		}
+	}
+
+	if ran > 0 && len(errs) == ran {
+		return fmt.Errorf("sketchybar: every batch failed after one run failed with %w. %w",
+			err, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// batchItem is the item a batch is about, for the logs.
+func batchItem(batch []string) string {
+	switch {
+	case len(batch) > 2 && batch[0] == "--add":
+		return batch[2]
+	case len(batch) > 1:
+		return batch[1]
+	default:
+		return ""
+	}
+}
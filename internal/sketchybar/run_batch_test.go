@@ -0,0 +1,175 @@
+//nolint:testpackage // want to test internals
+package sketchybar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/lucax88x/wentsketchy/internal/command"
+	"github.com/lucax88x/wentsketchy/internal/sketchybar/query"
+	"github.com/lucax88x/wentsketchy/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// brokenItemAPI fails every run containing the broken item.
+type brokenItemAPI struct {
+	broken string
+	runs   [][]string
+}
+
+func (api *brokenItemAPI) Run(_ context.Context, arg []string) error {
+	api.runs = append(api.runs, arg)
+
+	if slices.Contains(arg, api.broken) {
+		return errors.New("broken")
+	}
+	return nil
+}
+
+func (api *brokenItemAPI) RunBatch(ctx context.Context, batches Batches) error {
+	return RunBatchWithFallback(ctx, testutils.CreateTestLogger(), api, batches)
+}
+
+func (api *brokenItemAPI) RunAsync(ctx context.Context, arg []string) {
+	_ = api.Run(ctx, arg)
+}
+
+func (api *brokenItemAPI) QueryBar(_ context.Context) (query.Bar, error) {
+	return query.Bar{}, nil
+}
+
+// sketchybarLikeAPI applies every command of a run and fails the run when one failed,
+// it rejects an --add of an item which exists like sketchybar.
+type sketchybarLikeAPI struct {
+	broken string
+	added  map[string]bool
+}
+
+func (api *sketchybarLikeAPI) Run(_ context.Context, arg []string) error {
+	var errs []error
+	for index := 0; index+2 < len(arg); index++ {
+		if arg[index] != "--add" {
+			continue
+		}
+
+		name := arg[index+2]
+		switch {
+		case name == api.broken:
+			errs = append(errs, errors.New("broken"))
+		case api.added[name]:
+			errs = append(errs, fmt.Errorf("item %s already exists", name))
+		default:
+			api.added[name] = true
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (api *sketchybarLikeAPI) RunBatch(ctx context.Context, batches Batches) error {
+	return RunBatchWithFallback(ctx, testutils.CreateTestLogger(), api, batches)
+}
+
+func (api *sketchybarLikeAPI) RunAsync(ctx context.Context, arg []string) {
+	_ = api.Run(ctx, arg)
+}
+
+func (api *sketchybarLikeAPI) QueryBar(_ context.Context) (query.Bar, error) {
+	return query.Bar{}, nil
+}
+
+func TestUnitRunBatchWithFallback(t *testing.T) {
+	ctx := context.Background()
+	batches := Batches{
+		{"--add", "item", "battery", "right"},
+		{},
+		{"--add", "item", "calendar", "right"},
+	}
+
+	t.Run("should send all the batches in a single run", func(t *testing.T) {
+		// GIVEN
+		api := &brokenItemAPI{}
+
+		// WHEN
+		err := api.RunBatch(ctx, batches)
+
+		// THEN
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"--add", "item", "battery", "right", "--add", "item", "calendar", "right"},
+		}, api.runs)
+	})
+
+	t.Run("should run each batch on its own when the single run fails", func(t *testing.T) {
+		// GIVEN
+		api := &brokenItemAPI{broken: "battery"}
+
+		// WHEN
+		err := api.RunBatch(ctx, batches)
+
+		// THEN
+		require.NoError(t, err)
+		require.Len(t, api.runs, 3)
+		require.Equal(t, []string{"--add", "item", "calendar", "right"}, api.runs[2])
+	})
+
+	t.Run("should not fail on the items the single run already added", func(t *testing.T) {
+		// GIVEN
+		api := &sketchybarLikeAPI{broken: "battery", added: make(map[string]bool)}
+		batches := Batches{
+			{"--bar", "height=32"},
+			{"--add", "item", "battery", "right"},
+			{"--add", "item", "calendar", "right"},
+		}
+
+		// WHEN
+		err := api.RunBatch(ctx, batches)
+
+		// THEN
+		require.NoError(t, err)
+		require.True(t, api.added["calendar"])
+	})
+
+	t.Run("should fail when every batch fails", func(t *testing.T) {
+		// GIVEN
+		api := &failingAPI{err: errors.New("sketchybar is not running")}
+
+		// WHEN
+		err := api.RunBatch(ctx, batches)
+
+		// THEN
+		require.ErrorContains(t, err, "every batch failed")
+	})
+}
+
+func benchmarkBatches() Batches {
+	batches := make(Batches, 0, 50)
+	for range 50 {
+		batches = append(batches, []string{"--set", "battery", "label=42%"})
+	}
+	return batches
+}
+
+// the true binary stands for sketchybar, what is measured is starting the processes.
+func BenchmarkRunBatch(b *testing.B) {
+	ctx := context.Background()
+	logger := testutils.CreateTestLogger()
+	api := realAPI{logger, command.NewCommand(logger), "true"}
+	batches := benchmarkBatches()
+
+	b.Run("single run", func(b *testing.B) {
+		for b.Loop() {
+			require.NoError(b, api.RunBatch(ctx, batches))
+		}
+	})
+
+	b.Run("one run per batch", func(b *testing.B) {
+		for b.Loop() {
+			for _, batch := range batches {
+				require.NoError(b, api.Run(ctx, batch))
+			}
+		}
+	})
+}
@@ -0,0 +1,205 @@
+package testhelpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// FakeAerospaceTree is the canned state served by the fake aerospace executable.
+type FakeAerospaceTree struct {
+	FocusedWorkspace string          `json:"focused-workspace"`
+	Monitors         []FakeMonitor   `json:"monitors"`
+	Workspaces       []FakeWorkspace `json:"workspaces"`
+	Windows          []FakeWindow    `json:"windows"`
+}
+
+type FakeMonitor struct {
+	ID   int    `json:"monitor-id"`
+	Name string `json:"monitor-name"`
+}
+
+type FakeWorkspace struct {
+	ID        string `json:"workspace"`
+	MonitorID int    `json:"monitor-id"`
+}
+
+// FakeWindow is also served as the json of list-windows --json.
+type FakeWindow struct {
+	ID          int    `json:"window-id"`
+	App         string `json:"app-name"`
+	WorkspaceID string `json:"workspace"`
+	MonitorID   int    `json:"monitor-id"`
+}
+
+// CannedAerospaceTree is a single monitor with two workspaces, the first one focused.
+func CannedAerospaceTree() FakeAerospaceTree {
+	return FakeAerospaceTree{
+		FocusedWorkspace: "1",
+		Monitors: []FakeMonitor{
+			{ID: 1, Name: "Built-in Retina Display"},
+		},
+		Workspaces: []FakeWorkspace{
+			{ID: "1", MonitorID: 1},
+			{ID: "2", MonitorID: 1},
+		},
+		Windows: []FakeWindow{
+			{ID: 100, App: "Safari", WorkspaceID: "1", MonitorID: 1},
+			{ID: 101, App: "kitty", WorkspaceID: "1", MonitorID: 1},
+			{ID: 200, App: "Slack", WorkspaceID: "2", MonitorID: 1},
+		},
+	}
+}
+
+const fakeSketchybarScript = `#!/bin/bash
+if [ "$1" = "--query" ]; then
+	echo '{}'
+fi
+exec 3<>/dev/tcp/127.0.0.1/%d
+printf '%%s\0' "$@" >&3
+exec 3>&-
+`
+
+const fakeAerospaceScript = `#!/bin/bash
+dir="%s"
+suffix=""
+for arg in "$@"; do
+	if [ "$arg" = "--focused" ]; then
+		suffix=".focused"
+	fi
+	if [ "$arg" = "--json" ]; then
+		suffix=".json"
+	fi
+done
+case "$1" in
+	list-monitors | list-workspaces | list-windows)
+		cat "$dir/$1$suffix"
+		;;
+esac
+`
+
+// StartFakeSketchybar puts a fake sketchybar executable on PATH,
+// every invocation forwards its arguments to a local listener and ends up in calls.
+func StartFakeSketchybar(t testing.TB) (int, chan []string) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("testhelpers: could not listen for fake sketchybar. %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		t.Fatalf("testhelpers: unexpected listener address %s", listener.Addr())
+	}
+
+	calls := make(chan []string, 100)
+	go acceptCalls(listener, calls)
+
+	dir := t.TempDir()
+	writeExecutable(t, filepath.Join(dir, "sketchybar"), fmt.Sprintf(fakeSketchybarScript, addr.Port))
+	prependPath(t, dir)
+
+	return addr.Port, calls
+}
+
+// StartFakeAerospace puts a fake aerospace executable on PATH which serves
+// CannedAerospaceTree, the canned tree is also written as json to the returned path
+// for the tests which compare against it.
+func StartFakeAerospace(t testing.TB) string {
+	t.Helper()
+
+	tree := CannedAerospaceTree()
+	dir := t.TempDir()
+
+	data, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		t.Fatalf("testhelpers: could not serialize canned tree. %v", err)
+	}
+
+	jsonPath := filepath.Join(dir, "aerospace.json")
+	writeFile(t, jsonPath, string(data))
+
+	writeFile(t, filepath.Join(dir, "list-monitors"), joinLines(tree.Monitors, func(m FakeMonitor) string {
+		return fmt.Sprintf("%d", m.ID)
+	}))
+	writeFile(t, filepath.Join(dir, "list-monitors.focused"), fmt.Sprintf("%d\n", tree.Monitors[0].ID))
+	monitorNames := make(map[int]string, len(tree.Monitors))
+	for _, monitor := range tree.Monitors {
+		monitorNames[monitor.ID] = monitor.Name
+	}
+	writeFile(t, filepath.Join(dir, "list-workspaces"), joinLines(tree.Workspaces, func(w FakeWorkspace) string {
+		return fmt.Sprintf("%s¬%d¬%s", w.ID, w.MonitorID, monitorNames[w.MonitorID])
+	}))
+	writeFile(t, filepath.Join(dir, "list-workspaces.focused"), tree.FocusedWorkspace+"\n")
+	writeFile(t, filepath.Join(dir, "list-windows"), joinLines(tree.Windows, func(w FakeWindow) string {
+		return fmt.Sprintf("%d¬%s¬%s¬%d", w.ID, w.App, w.WorkspaceID, w.MonitorID)
+	}))
+	writeFile(t, filepath.Join(dir, "list-windows.focused"), fmt.Sprintf("%d\n", tree.Windows[0].ID))
+
+	windowsData, err := json.Marshal(tree.Windows)
+	if err != nil {
+		t.Fatalf("testhelpers: could not serialize canned windows. %v", err)
+	}
+	writeFile(t, filepath.Join(dir, "list-windows.json"), string(windowsData))
+
+	writeExecutable(t, filepath.Join(dir, "aerospace"), fmt.Sprintf(fakeAerospaceScript, dir))
+	prependPath(t, dir)
+
+	return jsonPath
+}
+
+func acceptCalls(listener net.Listener, calls chan<- []string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		data, err := io.ReadAll(conn)
+		_ = conn.Close()
+		if err != nil {
+			continue
+		}
+
+		data = bytes.TrimSuffix(data, []byte{0})
+		calls <- strings.Split(string(data), "\x00")
+	}
+}
+
+func joinLines[T any](values []T, format func(T) string) string {
+	var sb strings.Builder
+	for _, value := range values {
+		sb.WriteString(format(value))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func prependPath(t testing.TB, dir string) {
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func writeFile(t testing.TB, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("testhelpers: could not write %s. %v", path, err)
+	}
+}
+
+func writeExecutable(t testing.TB, path string, content string) {
+	//nolint:gosec // test executable
+	if err := os.WriteFile(path, []byte(content), 0700); err != nil {
+		t.Fatalf("testhelpers: could not write %s. %v", path, err)
+	}
+}
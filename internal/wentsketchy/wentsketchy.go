@@ -2,15 +2,24 @@ package wentsketchy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/lucax88x/wentsketchy/cmd/cli/config"
 	"github.com/lucax88x/wentsketchy/cmd/cli/config/items"
+	"github.com/lucax88x/wentsketchy/cmd/cli/config/settings"
 	"github.com/lucax88x/wentsketchy/internal/aerospace"
 	"github.com/lucax88x/wentsketchy/internal/clock"
 	"github.com/lucax88x/wentsketchy/internal/command"
 	"github.com/lucax88x/wentsketchy/internal/fifo"
+	"github.com/lucax88x/wentsketchy/internal/homedir"
+	"github.com/lucax88x/wentsketchy/internal/jobs"
+	"github.com/lucax88x/wentsketchy/internal/plugins"
+	"github.com/lucax88x/wentsketchy/internal/scheduler"
 	"github.com/lucax88x/wentsketchy/internal/server"
 	"github.com/lucax88x/wentsketchy/internal/sketchybar"
 )
@@ -21,13 +30,19 @@ type Wentsketchy struct {
 	Config               *config.Config
 	Fifo                 *fifo.Reader
 	Server               *server.FifoServer
+	ServerMetrics        *server.MessageMetrics
 	Sketchybar           sketchybar.API
 	Aerospace            aerospace.Aerospace
+	Jobs                 *jobs.Manager
 	aerospaceTreeBuilder aerospace.TreeBuilder
 	aerospaceAPI         aerospace.API
 	command              *command.Command
 }
 
+// serverRateLimit is the messages per second the fifo server handles,
+// well above what sketchybar and aerospace send while switching workspaces.
+const serverRateLimit = 100
+
 func NewWentsketchy(
 	ctx context.Context,
 	logger *slog.Logger,
@@ -53,71 +68,144 @@ func initialize(ctx context.Context, di *Wentsketchy, cfg *config.Cfg) error {
 	di.command = command.NewCommand(di.Logger)
 	di.aerospaceAPI = aerospace.NewAPI(di.Logger, di.command)
 	di.aerospaceTreeBuilder = aerospace.NewTreeBuilder(di.Logger, di.aerospaceAPI)
-	di.Aerospace = aerospace.New(di.Logger, di.aerospaceAPI, di.aerospaceTreeBuilder)
-
-	di.Sketchybar = sketchybar.NewAPI(di.Logger, di.command)
-
-	mainIcon := items.NewMainIconItem(di.Logger)
-	calendar := items.NewCalendarItem(di.Logger)
-	frontApp := items.NewFrontAppItem(di.Logger)
-	aerospace := items.NewAerospaceItem(di.Logger, di.Aerospace, di.Sketchybar)
-	battery := items.NewBatteryItem(di.Logger)
-	cpu := items.NewCPUItem(di.Logger, di.command)
-	sensors := items.NewSensorsItem(di.Logger, di.command)
-	volume := items.NewVolumeItem(di.Logger, di.command)
-	bluetooth := items.NewBluetoothItem(di.Logger, di.command)
-	wifi := items.NewWifiItem(di.Logger, di.command)
-	power := items.NewPowerItem(di.Logger, di.command)
-	media := items.NewMediaItem(di.Logger, di.command)
+	aerospaceData := aerospace.New(di.Logger, di.aerospaceAPI, di.aerospaceTreeBuilder)
+	aerospaceData.RefreshTimeout = settings.Sketchybar.Aerospace.RefreshTimeout
+	di.Aerospace = aerospaceData
+
+	if cfg.DryRun {
+		di.Sketchybar = sketchybar.NewDryRunAPI(os.Stdout)
+	} else {
+		var api sketchybar.API = sketchybar.NewAPI(di.Logger, di.command)
+		if cfg.LogLevel == "debug" {
+			api = sketchybar.NewLoggingSketchybarAPI(di.Logger, api)
+		}
+
+		di.Sketchybar = sketchybar.NewBackpressureAPI(di.Logger, api)
+	}
+
+	registerDynamicItems(ctx, di.Logger)
 
 	di.Config = config.NewConfig(
 		cfg,
 		di.Logger,
 		di.Sketchybar,
-		map[string]items.WentsketchyItem{
-			"main_icon": mainIcon,
-			"calendar":  calendar,
-			"front_app": frontApp,
-			"aerospace": aerospace,
-			"battery":   battery,
-			"cpu":       cpu,
-			"sensors":   sensors,
-			"volume":    volume,
-			"bluetooth": bluetooth,
-			"wifi":      wifi,
-			"power":     power,
-			"media":     media,
-		},
-		items.WentsketchyItems{
-			MainIcon:  mainIcon,
-			Calendar:  calendar,
-			FrontApp:  frontApp,
-			Aerospace: aerospace,
-			Battery:   battery,
-			CPU:       cpu,
-			Sensors:   sensors,
-			Volume:    volume,
-			Bluetooth: bluetooth,
-			Wifi:      wifi,
-			Power:     power,
-			Media:     media,
+		nil,
+		items.ItemDeps{
+			Logger:     di.Logger,
+			Command:    di.command,
+			Clock:      di.Clock,
+			Aerospace:  di.Aerospace,
+			Sketchybar: di.Sketchybar,
 		},
 	)
 
 	di.Fifo = fifo.NewFifoReader(di.Logger)
+	di.ServerMetrics = server.NewMessageMetrics()
+
+	replay, err := server.NewEventReplayBuffer(homedir.ReplayLogPath(), server.ReplaySize)
+	if err != nil {
+		di.Logger.WarnContext(ctx, "init: could not load replayed messages, starting without them", slog.Any("error", err))
+	}
+
 	di.Server = server.NewFifoServer(
 		di.Logger,
 		di.Config,
 		di.Fifo,
 		di.Aerospace,
+		replay,
+		[]server.MessageMiddleware{
+			server.LoggingMiddleware(di.Logger),
+			server.MetricsMiddleware(di.ServerMetrics),
+			server.RateLimitMiddleware(di.Clock, serverRateLimit, time.Second),
+			server.ValidationMiddleware(di.Logger),
+		},
+	)
+
+	visibility := scheduler.NewVisibilityScheduler(
+		di.Logger,
+		di.Clock,
+		di.Sketchybar,
+		settings.Sketchybar.Visibility,
+		di.Config.SketchybarItems,
 	)
+	di.Config.OnInit(visibility.Reset)
 
-	bluetoothJob := items.NewBluetoothJob(di.Logger, di.command, di.Sketchybar)
-	bluetoothJob.Start(ctx)
-	wifiJob := items.NewWifiJob(di.Logger, di.command, di.Sketchybar)
-	wifiJob.Start(ctx)
-	aerospaceJob := items.NewAerospaceJob(di.Logger, di.Config)
-	aerospaceJob.Start(ctx)
+	badges := items.NewNotificationBadgeItem(di.Logger, di.Clock, di.command, di.Sketchybar, settings.Sketchybar.Badges)
+	di.Config.OnInit(badges.Reset)
+
+	di.Jobs = jobs.NewManager(di.Logger, []jobs.Job{
+		items.NewBluetoothJob(di.Logger, di.command, di.Sketchybar),
+		items.NewWifiJob(di.Logger, di.command, di.Sketchybar),
+		items.NewAerospaceJob(di.Logger, di.Config),
+		visibility,
+		badges,
+	})
 
 	return nil
 }
+
+// Validate checks that every required dependency has been wired.
+// Jobs do not depend on each other, so there is no ordering to check between them.
+func (di *Wentsketchy) Validate() error {
+	var errs []error
+
+	required := []struct {
+		name  string
+		isNil bool
+	}{
+		{"logger", di.Logger == nil},
+		{"config", di.Config == nil},
+		{"fifo", di.Fifo == nil},
+		{"server", di.Server == nil},
+		{"sketchybar", di.Sketchybar == nil},
+		{"aerospace", di.Aerospace == nil},
+		{"jobs", di.Jobs == nil},
+	}
+
+	for _, dependency := range required {
+		if dependency.isNil {
+			errs = append(errs, fmt.Errorf("wentsketchy: missing %s", dependency.name))
+		}
+	}
+
+	if di.Jobs != nil {
+		if err := di.Jobs.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("wentsketchy: invalid jobs. %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadPlugins only warns, the bar still works with the built in items.
+func loadPlugins(ctx context.Context, logger *slog.Logger) {
+	dir, err := homedir.PluginsDir()
+
+	if err != nil {
+		logger.WarnContext(ctx, "init: could not get plugins dir", slog.Any("error", err))
+		return
+	}
+
+	if err := plugins.Load(logger, dir, items.DefaultRegistry()); err != nil {
+		logger.WarnContext(ctx, "init: could not load some plugins", slog.Any("error", err))
+	}
+}
+
+//nolint:gochecknoglobals // ok
+var registerDynamicItemsOnce sync.Once
+
+// registerDynamicItems registers the items which are not built in, the plugins and the shell scripts of config.yaml.
+// Registering an item twice panics, so it only runs once.
+func registerDynamicItems(ctx context.Context, logger *slog.Logger) {
+	registerDynamicItemsOnce.Do(func() {
+		loadPlugins(ctx, logger)
+		items.RegisterShellScriptItems(logger, settings.Sketchybar.ShellScripts)
+	})
+}
+
+// ItemNames are the names which can be used in the positions of config.yaml, plugins and shell scripts included.
+func ItemNames(ctx context.Context, logger *slog.Logger) []string {
+	registerDynamicItems(ctx, logger)
+
+	return items.RegisteredItemNames()
+}
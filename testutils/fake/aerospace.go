@@ -0,0 +1,192 @@
+package fake
+
+import (
+	"context"
+
+	"github.com/lucax88x/wentsketchy/internal/aerospace"
+)
+
+type Aerospace struct {
+	Tree               *aerospace.Tree
+	PrevWorkspaceID    string
+	FocusedWorkspaceID string
+	FocusedMonitorID   int
+	FocusedApp         string
+	WorkspaceHistory   []string
+	Monitors           []aerospace.Monitor
+	Refreshes          int
+}
+
+func (m *Aerospace) GetTree() *aerospace.Tree {
+	if m.Tree == nil {
+		return aerospace.EmptyTree()
+	}
+	return m.Tree
+}
+
+func (m *Aerospace) GetPrevWorkspaceID() string {
+	return m.PrevWorkspaceID
+}
+
+func (m *Aerospace) SetPrevWorkspaceID(workspaceID string) {
+	m.PrevWorkspaceID = workspaceID
+}
+
+func (m *Aerospace) GetWorkspaceHistory() []string {
+	return m.WorkspaceHistory
+}
+
+func (m *Aerospace) GetFocusedWorkspaceID(_ context.Context) string {
+	return m.FocusedWorkspaceID
+}
+
+func (m *Aerospace) SetFocusedWorkspaceID(workspaceID string) {
+	m.FocusedWorkspaceID = workspaceID
+}
+
+func (m *Aerospace) ClearFocusedWorkspaceID() {
+	m.FocusedWorkspaceID = ""
+}
+
+func (m *Aerospace) GetFocusedMonitorID(_ context.Context) int {
+	return m.FocusedMonitorID
+}
+
+func (m *Aerospace) SetFocusedMonitorID(monitorID int) {
+	m.FocusedMonitorID = monitorID
+}
+
+func (m *Aerospace) GetFocusedApp() string {
+	return m.FocusedApp
+}
+
+func (m *Aerospace) SetFocusedApp(app string) {
+	m.FocusedApp = app
+}
+
+func (m *Aerospace) SingleFlightRefreshTree() {
+	m.Refreshes++
+}
+
+func (m *Aerospace) AddWindow(workspaceID aerospace.WorkspaceID, window *aerospace.Window) bool {
+	tree, found := m.GetTree().WithWindow(workspaceID, window)
+	if !found {
+		return false
+	}
+
+	m.Tree = tree
+	return true
+}
+
+func (m *Aerospace) RemoveWindow(windowID aerospace.WindowID) bool {
+	if _, found := m.GetTree().IndexedWindows[windowID]; !found {
+		return false
+	}
+
+	m.Tree = m.GetTree().WithoutWindow(windowID)
+	return true
+}
+
+func (m *Aerospace) MoveWindow(windowID aerospace.WindowID, workspaceID aerospace.WorkspaceID) bool {
+	window, found := m.GetTree().IndexedWindows[windowID]
+	if !found {
+		return false
+	}
+
+	return m.AddWindow(workspaceID, window)
+}
+
+func (m *Aerospace) FocusedMonitor(_ context.Context) (aerospace.MonitorID, error) {
+	return m.FocusedMonitorID, nil
+}
+
+func (m *Aerospace) FocusWorkspace(_ context.Context, workspaceID aerospace.WorkspaceID) error {
+	m.FocusedWorkspaceID = workspaceID
+	return nil
+}
+
+func (m *Aerospace) MoveWindowToWorkspace(
+	_ context.Context,
+	windowID aerospace.WindowID,
+	workspaceID aerospace.WorkspaceID,
+) error {
+	m.MoveWindow(windowID, workspaceID)
+	return nil
+}
+
+func (m *Aerospace) ListMonitors(_ context.Context) ([]aerospace.Monitor, error) {
+	return m.Monitors, nil
+}
+
+func (m *Aerospace) WindowsOfWorkspace(workspaceID string) []*aerospace.Window {
+	windows := make([]*aerospace.Window, 0)
+
+	workspace, found := m.GetTree().IndexedWorkspaces[workspaceID]
+	if !found {
+		return windows
+	}
+
+	for _, windowID := range workspace.Windows {
+		if window, foundWindow := m.GetTree().IndexedWindows[windowID]; foundWindow {
+			windows = append(windows, window)
+		}
+	}
+
+	return windows
+}
+
+func (m *Aerospace) WindowsOfFocusedWorkspace(_ context.Context) (aerospace.IndexedWindows, error) {
+	windows := make(aerospace.IndexedWindows)
+	for _, window := range m.WindowsOfWorkspace(m.FocusedWorkspaceID) {
+		windows[window.ID] = window
+	}
+	return windows, nil
+}
+
+func (m *Aerospace) WindowsOfFocusedMonitor(_ context.Context) (aerospace.IndexedWindows, error) {
+	windows := make(aerospace.IndexedWindows)
+
+	monitor, found := m.GetTree().IndexedMonitors[m.FocusedMonitorID]
+	if !found {
+		return windows, nil
+	}
+
+	for _, workspaceID := range monitor.Workspaces {
+		for _, window := range m.WindowsOfWorkspace(workspaceID) {
+			windows[window.ID] = window
+		}
+	}
+	return windows, nil
+}
+
+func (m *Aerospace) FocusedWindow(_ context.Context) (aerospace.WindowID, error) {
+	for _, window := range m.WindowsOfWorkspace(m.FocusedWorkspaceID) {
+		return window.ID, nil
+	}
+	return 0, nil
+}
+
+func (m *Aerospace) AllFullWindows(_ context.Context) (aerospace.IndexedFullWindows, error) {
+	windows := make(aerospace.IndexedFullWindows)
+
+	for _, branch := range m.GetTree().Monitors {
+		for _, workspace := range branch.Workspaces {
+			for _, windowID := range workspace.Windows {
+				window, found := m.GetTree().IndexedWindows[windowID]
+				if !found {
+					continue
+				}
+
+				windows[windowID] = &aerospace.FullWindow{
+					ID:          window.ID,
+					App:         window.App,
+					WorkspaceID: workspace.Workspace,
+					MonitorID:   branch.Monitor,
+				}
+			}
+		}
+	}
+	return windows, nil
+}
+
+var _ aerospace.Aerospace = (*Aerospace)(nil)
@@ -8,10 +8,16 @@ import (
 
 type Clock struct {
 	Time time.Time
+	// Ticks is returned by Ticker, send on it to tick manually.
+	Ticks chan time.Time
 }
 
 func (m *Clock) Now() time.Time {
 	return m.Time
 }
 
+func (m *Clock) Ticker(_ time.Duration) (<-chan time.Time, func()) {
+	return m.Ticks, func() {}
+}
+
 var _ clock.Clock = (*Clock)(nil)